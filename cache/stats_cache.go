@@ -0,0 +1,135 @@
+// Package cache holds the rendered-response cache used by the public standings and stats endpoints.
+package cache
+
+import (
+	"container/list"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Entry is a rendered JSON response plus its validators.
+type Entry struct {
+	Body         []byte
+	ETag         string
+	LastModified time.Time
+}
+
+// StatsCache stores per-season responses. Every key belongs to exactly one season so
+// a write to any game in that season can drop all of them at once.
+//
+// A response computed while a write lands would be stale as soon as it is stored, so
+// callers read the season's Generation before computing and hand it to Set, which
+// drops the entry if the season was invalidated in between.
+type StatsCache interface {
+	Get(seasonID int64, key string) (Entry, bool)
+	Generation(seasonID int64) uint64
+	Set(seasonID int64, gen uint64, key string, e Entry)
+	InvalidateSeason(seasonID int64)
+}
+
+// LRU is the default in-memory StatsCache, bounded by entry count.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List                    // front = most recently used
+	items    map[string]*list.Element      // composite key -> element
+	bySeason map[int64]map[string]struct{} // season -> composite keys
+	gens     map[int64]uint64              // season -> invalidation count
+}
+
+type lruItem struct {
+	seasonID int64
+	key      string
+	entry    Entry
+}
+
+const DefaultLRUCapacity = 512
+
+func NewLRU(capacity int) *LRU {
+	if capacity <= 0 {
+		capacity = DefaultLRUCapacity
+	}
+	return &LRU{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    map[string]*list.Element{},
+		bySeason: map[int64]map[string]struct{}{},
+		gens:     map[int64]uint64{},
+	}
+}
+
+func compositeKey(seasonID int64, key string) string {
+	return strconv.FormatInt(seasonID, 10) + "|" + key
+}
+
+func (c *LRU) Get(seasonID int64, key string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[compositeKey(seasonID, key)]
+	if !ok {
+		return Entry{}, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*lruItem).entry, true
+}
+
+func (c *LRU) Generation(seasonID int64) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gens[seasonID]
+}
+
+// Set stores e unless the season has been invalidated since gen was read.
+func (c *LRU) Set(seasonID int64, gen uint64, key string, e Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.gens[seasonID] != gen {
+		return
+	}
+
+	ck := compositeKey(seasonID, key)
+	if el, ok := c.items[ck]; ok {
+		el.Value.(*lruItem).entry = e
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[ck] = c.ll.PushFront(&lruItem{seasonID: seasonID, key: ck, entry: e})
+	if c.bySeason[seasonID] == nil {
+		c.bySeason[seasonID] = map[string]struct{}{}
+	}
+	c.bySeason[seasonID][ck] = struct{}{}
+
+	for c.ll.Len() > c.capacity {
+		c.removeElement(c.ll.Back())
+	}
+}
+
+func (c *LRU) InvalidateSeason(seasonID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gens[seasonID]++
+
+	for ck := range c.bySeason[seasonID] {
+		if el, ok := c.items[ck]; ok {
+			c.removeElement(el)
+		}
+	}
+	delete(c.bySeason, seasonID)
+}
+
+func (c *LRU) removeElement(el *list.Element) {
+	it := el.Value.(*lruItem)
+	c.ll.Remove(el)
+	delete(c.items, it.key)
+	if keys := c.bySeason[it.seasonID]; keys != nil {
+		delete(keys, it.key)
+		if len(keys) == 0 {
+			delete(c.bySeason, it.seasonID)
+		}
+	}
+}
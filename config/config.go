@@ -10,18 +10,25 @@ import (
 )
 
 type Environment struct {
-	LocalPort     int    `env:"LOCAL_PORT" validate:"required"`
-	Port          int    `env:"PORT"` // Render sets this
-	DBHost        string `env:"DB_HOST" validate:"required"`
-	DBPort        string `env:"DB_PORT" validate:"required"`
-	DBUser        string `env:"DB_USER" validate:"required"`
-	DBPassword    string `env:"DB_PASSWORD" validate:"required"`
-	DBName        string `env:"DB_NAME" validate:"required"`
-	RunMode       string `env:"RUN_MODE" validate:"required,oneof=local production"`
-	FrontEndURL   string `env:"FRONT_END_URL" validate:"required,url"`
-	JWTSecret     string `env:"JWT_SECRET" validate:"required"`
-	JWTIssuer     string `env:"JWT_ISSUER" validate:"required"`
-	JWTExpMinutes int    `env:"JWT_EXP_MINUTES" validate:"required"`
+	LocalPort      int    `env:"LOCAL_PORT" validate:"required"`
+	Port           int    `env:"PORT"` // Render sets this
+	DBHost         string `env:"DB_HOST" validate:"required"`
+	DBPort         string `env:"DB_PORT" validate:"required"`
+	DBUser         string `env:"DB_USER" validate:"required"`
+	DBPassword     string `env:"DB_PASSWORD" validate:"required"`
+	DBName         string `env:"DB_NAME" validate:"required"`
+	RunMode        string `env:"RUN_MODE" validate:"required,oneof=local production"`
+	FrontEndURL    string `env:"FRONT_END_URL" validate:"required,url"`
+	JWTSecret      string `env:"JWT_SECRET" validate:"required"`
+	JWTIssuer      string `env:"JWT_ISSUER" validate:"required"`
+	JWTExpMinutes  int    `env:"JWT_EXP_MINUTES" validate:"required"`
+	StatsCacheSize int    `env:"STATS_CACHE_SIZE"`      // max cached standings/stats responses
+	GameMinutes    int    `env:"EXPECTED_GAME_MINUTES"` // expected game length for double-booking checks
+
+	TemplateWeeksAhead       int `env:"TEMPLATE_WEEKS_AHEAD"`       // how far ahead recurring games are materialized
+	TemplateSchedulerMinutes int `env:"TEMPLATE_SCHEDULER_MINUTES"` // how often the template scheduler runs
+
+	ScheduleWebhookURL string `env:"SCHEDULE_WEBHOOK_URL" validate:"omitempty,url"` // receives schedule.published events
 }
 
 func LoadConfig() (Environment, error) {
@@ -64,6 +71,18 @@ func LoadConfig() (Environment, error) {
 	if cfg.JWTExpMinutes == 0 {
 		cfg.JWTExpMinutes = 60
 	}
+	if cfg.StatsCacheSize <= 0 {
+		cfg.StatsCacheSize = 512
+	}
+	if cfg.GameMinutes <= 0 {
+		cfg.GameMinutes = 60
+	}
+	if cfg.TemplateWeeksAhead <= 0 {
+		cfg.TemplateWeeksAhead = 4
+	}
+	if cfg.TemplateSchedulerMinutes <= 0 {
+		cfg.TemplateSchedulerMinutes = 60
+	}
 
 	return cfg, nil
 }
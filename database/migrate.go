@@ -1,16 +1,21 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 
 	"gorm.io/gorm"
 
 	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
 )
 
 func RunMigrations(db *gorm.DB) error {
 	slog.Info("Starting database migrations...")
+	if err := detachDuplicateOccurrences(db); err != nil {
+		return fmt.Errorf("template occurrence cleanup failed: %w", err)
+	}
 	if err := db.AutoMigrate(
 		&models.User{},
 		&models.Player{},
@@ -21,9 +26,164 @@ func RunMigrations(db *gorm.DB) error {
 		&models.TeamSeason{},
 		&models.Game{},
 		&models.GameSide{},
+		&models.PlayerGameResult{},
+		&models.SeasonAwardSetting{},
+		&models.SeasonAward{},
+		&models.LeagueRecord{},
+		&models.Availability{},
+		&models.Venue{},
+		&models.Board{},
+		&models.Matchday{},
+		&models.GameTemplate{},
+		&models.DataMigration{},
 	); err != nil {
 		return fmt.Errorf("database migration failed: %w", err)
 	}
+	if err := runOnce(db, "venues_from_locations", backfillVenues); err != nil {
+		return fmt.Errorf("venue backfill failed: %w", err)
+	}
+	if err := runOnce(db, "season_timezone_inherited", markInheritedTimezones); err != nil {
+		return fmt.Errorf("season timezone backfill failed: %w", err)
+	}
+	if err := backfillPlayerGameResults(db); err != nil {
+		return fmt.Errorf("player_game_results backfill failed: %w", err)
+	}
 	slog.Info("✅ GORM database migration completed successfully")
 	return nil
 }
+
+// detachDuplicateOccurrences keeps the oldest game for each (template, occurrence
+// date) and turns the rest into plain games, so the unique index on the pair can be
+// built over data written before it existed. It also drops the non-unique index the
+// unique one replaces.
+func detachDuplicateOccurrences(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.Game{}, "OccurrenceDate") {
+		return nil // first deploy with templates
+	}
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_game_template_occurrence`).Error; err != nil {
+		return err
+	}
+	res := db.Exec(`
+UPDATE games g
+SET template_id = NULL, occurrence_date = NULL
+WHERE g.template_id IS NOT NULL
+  AND EXISTS (
+    SELECT 1 FROM games o
+    WHERE o.template_id = g.template_id
+      AND o.occurrence_date = g.occurrence_date
+      AND o.id < g.id
+  )`)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		slog.Info("detached duplicate template occurrences", "rows", res.RowsAffected)
+	}
+	return nil
+}
+
+// runOnce runs fn in a transaction together with recording name in data_migrations,
+// skipping it if the name is already recorded. A replica booting concurrently blocks
+// on the marker row and then skips.
+func runOnce(db *gorm.DB, name string, fn func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Exec(`INSERT INTO data_migrations (name, ran_at) VALUES (?, NOW()) ON CONFLICT (name) DO NOTHING`, name)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		return fn(tx)
+	})
+}
+
+// backfillVenues maps free-text game locations onto venues. Each distinct location,
+// compared case-insensitively and trimmed, becomes a one-board venue named after its
+// most common spelling (unless a venue with that name exists), and the games are linked
+// to it with their location normalised to the venue name. It runs once: afterwards
+// venues are managed through the API, and a deleted venue's games keep its name as
+// their location without the venue coming back.
+func backfillVenues(tx *gorm.DB) error {
+	steps := []string{
+		`INSERT INTO venues (name, timezone, board_count, created_at, updated_at)
+SELECT MODE() WITHIN GROUP (ORDER BY TRIM(g.location)),
+       MODE() WITHIN GROUP (ORDER BY g.timezone),
+       1, NOW(), NOW()
+FROM games g
+WHERE g.venue_id IS NULL
+  AND g.deleted_at IS NULL
+  AND NULLIF(TRIM(g.location), '') IS NOT NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM venues v
+    WHERE v.deleted_at IS NULL AND LOWER(v.name) = LOWER(TRIM(g.location))
+  )
+GROUP BY LOWER(TRIM(g.location))`,
+		`INSERT INTO boards (venue_id, number, created_at, updated_at)
+SELECT v.id, 1, NOW(), NOW()
+FROM venues v
+WHERE NOT EXISTS (SELECT 1 FROM boards b WHERE b.venue_id = v.id)`,
+		`UPDATE player_game_results pr
+SET location = v.name
+FROM games g
+JOIN venues v ON v.deleted_at IS NULL AND LOWER(v.name) = LOWER(TRIM(g.location))
+WHERE pr.game_id = g.id AND g.venue_id IS NULL AND g.deleted_at IS NULL`,
+		`UPDATE games g
+SET venue_id = v.id, location = v.name
+FROM venues v
+WHERE g.venue_id IS NULL
+  AND g.deleted_at IS NULL
+  AND v.deleted_at IS NULL
+  AND LOWER(v.name) = LOWER(TRIM(g.location))`,
+	}
+	for _, sql := range steps {
+		res := tx.Exec(sql)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			slog.Info("venue backfill", "rows", res.RowsAffected)
+		}
+	}
+	return nil
+}
+
+// markInheritedTimezones flags seasons whose timezone matches what they would inherit
+// (the league's, else the built-in default). Seasons used to copy it on creation
+// without recording that, so a matching one is taken as never set.
+func markInheritedTimezones(tx *gorm.DB) error {
+	res := tx.Exec(`
+UPDATE seasons s
+SET timezone_inherited = TRUE
+FROM leagues l
+WHERE l.id = s.league_id
+  AND s.timezone = COALESCE(NULLIF(l.timezone, ''), 'America/New_York')`)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		slog.Info("marked inherited season timezones", "rows", res.RowsAffected)
+	}
+	return nil
+}
+
+// backfillPlayerGameResults fills player_game_results from the games on the first
+// deploy that has it. Once the table has rows it is kept in sync by every game write,
+// so this only runs while it is empty; "go run . rebuild-player-results" repairs it.
+func backfillPlayerGameResults(db *gorm.DB) error {
+	var populated bool
+	if err := db.Raw("SELECT EXISTS (SELECT 1 FROM player_game_results)").Scan(&populated).Error; err != nil {
+		return err
+	}
+	if populated {
+		return nil
+	}
+	n, err := repositories.NewPlayerGameResultRepository(db).Rebuild(context.Background())
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		slog.Info("player_game_results backfill", "rows", n)
+	}
+	return nil
+}
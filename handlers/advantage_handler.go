@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+type AdvantageHandler struct {
+	services *services.ServicesCollection
+}
+
+func NewAdvantageHandler(svcs *services.ServicesCollection) *AdvantageHandler {
+	return &AdvantageHandler{services: svcs}
+}
+
+// GET /api/v1/seasons/:seasonId/stats/advantage
+func (h *AdvantageHandler) SeasonAdvantage(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	serveSeasonCached(c, h.services.StatsCache, seasonID, func() (any, bool) {
+		out, err := h.services.AdvantageService.GetSeasonAdvantage(c.Request.Context(), seasonID)
+		if err != nil {
+			if utils.IsNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+				return nil, false
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute advantage analysis"})
+			return nil, false
+		}
+		return out, true
+	})
+}
+
+// GET /api/v1/leagues/:id/stats/advantage
+func (h *AdvantageHandler) LeagueAdvantage(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+	out, err := h.services.AdvantageService.GetLeagueAdvantage(c.Request.Context(), id)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute advantage analysis"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
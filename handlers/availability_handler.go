@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+type AvailabilityHandler struct {
+	services *services.ServicesCollection
+}
+
+func NewAvailabilityHandler(svcs *services.ServicesCollection) *AvailabilityHandler {
+	return &AvailabilityHandler{services: svcs}
+}
+
+// GET /api/v1/seasons/:seasonId/availability?playerId=&teamId=&kind=
+func (h *AvailabilityHandler) List(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	f := repositories.ListAvailabilityFilter{SeasonID: seasonID}
+	for _, p := range []struct {
+		name string
+		dst  **int64
+	}{{"playerId", &f.PlayerID}, {"teamId", &f.TeamID}} {
+		if v := c.Query(p.name); v != "" {
+			id, ok := parseIDParam(v)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + p.name})
+				return
+			}
+			*p.dst = &id
+		}
+	}
+	if v := strings.ToLower(strings.TrimSpace(c.Query("kind"))); v != "" {
+		k := models.AvailabilityKind(v)
+		f.Kind = &k
+	}
+
+	out, err := h.services.AvailabilityService.List(c.Request.Context(), f)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list availability"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// POST /api/v1/seasons/:seasonId/availability
+func (h *AvailabilityHandler) Create(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	var in services.CreateAvailabilityInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	in.SeasonID = seasonID
+
+	out, err := h.services.AvailabilityService.Create(c.Request.Context(), in)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, out)
+}
+
+// DELETE /api/v1/seasons/:seasonId/availability/:availabilityId
+func (h *AvailabilityHandler) Delete(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	id, ok := parseIDParam(c.Param("availabilityId"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid availabilityId"})
+		return
+	}
+	if err := h.services.AvailabilityService.Delete(c.Request.Context(), seasonID, id); err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "availability not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GET /api/v1/seasons/:seasonId/conflicts
+// Every availability, double-booking and venue constraint currently broken by the
+// season's scheduled games. Draft games are only checked for admins.
+func (h *AvailabilityHandler) SeasonConflicts(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	out, err := h.services.AvailabilityService.SeasonConflicts(c.Request.Context(), seasonID, canSeeDrafts(c, h.services))
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute conflicts"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+type AwardHandler struct {
+	services *services.ServicesCollection
+}
+
+func NewAwardHandler(svcs *services.ServicesCollection) *AwardHandler {
+	return &AwardHandler{services: svcs}
+}
+
+// writeAwardError answers 404 for a missing season, 400 for input the caller can
+// correct and 500, as "failed to <action>", for anything else.
+func writeAwardError(c *gin.Context, err error, action string) {
+	var input *services.AwardInputError
+	switch {
+	case utils.IsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+	case errors.As(err, &input):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to " + action})
+	}
+}
+
+// GET /api/v1/seasons/:seasonId/awards
+func (h *AwardHandler) SeasonAwards(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	out, err := h.services.AwardService.GetSeasonAwards(c.Request.Context(), seasonID)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute awards"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+type updateAwardSettingsReq struct {
+	Awards []services.AwardSettingInput `json:"awards" binding:"required"`
+}
+
+// PUT /api/v1/seasons/:seasonId/awards/settings
+func (h *AwardHandler) UpdateSettings(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	var req updateAwardSettingsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	out, err := h.services.AwardService.UpdateSettings(c.Request.Context(), seasonID, req.Awards)
+	if err != nil {
+		writeAwardError(c, err, "update award settings")
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+type lockAwardReq struct {
+	PlayerID  *int64 `json:"playerId"`
+	PartnerID *int64 `json:"partnerId"`
+}
+
+// POST /api/v1/seasons/:seasonId/awards/:award/lock
+// An empty body locks the current leader.
+func (h *AwardHandler) Lock(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	var req lockAwardReq
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+	}
+
+	in := services.LockAwardInput{PlayerID: req.PlayerID, PartnerID: req.PartnerID}
+	if sub := c.GetString("userID"); sub != "" {
+		in.LockedBy = &sub
+	}
+	out, err := h.services.AwardService.LockAward(c.Request.Context(), seasonID, c.Param("award"), in)
+	if err != nil {
+		writeAwardError(c, err, "lock award")
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// DELETE /api/v1/seasons/:seasonId/awards/:award/lock
+func (h *AwardHandler) Unlock(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	if err := h.services.AwardService.UnlockAward(c.Request.Context(), seasonID, c.Param("award")); err != nil {
+		writeAwardError(c, err, "unlock award")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+type CalendarHandler struct {
+	services *services.ServicesCollection
+}
+
+func NewCalendarHandler(svcs *services.ServicesCollection) *CalendarHandler {
+	return &CalendarHandler{services: svcs}
+}
+
+// GET /api/v1/seasons/:seasonId/calendar.ics
+func (h *CalendarHandler) Season(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	body, err := h.services.CalendarService.SeasonCalendar(c.Request.Context(), seasonID)
+	writeCalendar(c, "season", body, err)
+}
+
+// GET /api/v1/teams/:id/calendar.ics
+func (h *CalendarHandler) Team(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team ID"})
+		return
+	}
+	body, err := h.services.CalendarService.TeamCalendar(c.Request.Context(), id)
+	writeCalendar(c, "team", body, err)
+}
+
+// GET /api/v1/players/:id/calendar.ics
+func (h *CalendarHandler) Player(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid player ID"})
+		return
+	}
+	body, err := h.services.CalendarService.PlayerCalendar(c.Request.Context(), id)
+	writeCalendar(c, "player", body, err)
+}
+
+// writeCalendar serves an iCalendar body; calendar apps poll, so it is never cached.
+func writeCalendar(c *gin.Context, what string, body []byte, err error) {
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": what + " not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build calendar"})
+		return
+	}
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Content-Disposition", `inline; filename="calendar.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", body)
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+type CareerStatsHandler struct {
+	services *services.ServicesCollection
+}
+
+func NewCareerStatsHandler(svcs *services.ServicesCollection) *CareerStatsHandler {
+	return &CareerStatsHandler{services: svcs}
+}
+
+// GET /api/v1/players/:id/stats?leagueId=&seasonId=&from=&to=&matchType=&location=&includeExhibition=
+func (h *CareerStatsHandler) PlayerCareerStats(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid player ID"})
+		return
+	}
+	opts, ok := parseCareerStatsQuery(c)
+	if !ok {
+		return
+	}
+
+	out, err := h.services.CareerStatsService.GetPlayerCareerStats(c.Request.Context(), id, opts)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "player not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// GET /api/v1/teams/:id/stats?leagueId=&seasonId=&from=&to=&matchType=&location=&includeExhibition=
+func (h *CareerStatsHandler) TeamCareerStats(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team ID"})
+		return
+	}
+	opts, ok := parseCareerStatsQuery(c)
+	if !ok {
+		return
+	}
+
+	out, err := h.services.CareerStatsService.GetTeamCareerStats(c.Request.Context(), id, opts)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// parseCareerStatsQuery reads the shared career filters; on failure it writes a 400 and returns false.
+func parseCareerStatsQuery(c *gin.Context) (services.CareerStatsOptions, bool) {
+	opts := services.CareerStatsOptions{IncludeExhibition: true}
+
+	if v := c.Query("leagueId"); v != "" {
+		id, ok := parseIDParam(v)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid leagueId"})
+			return opts, false
+		}
+		opts.LeagueID = &id
+	}
+	if v := c.Query("seasonId"); v != "" {
+		id, ok := parseIDParam(v)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid seasonId"})
+			return opts, false
+		}
+		opts.SeasonID = &id
+	}
+	if v := strings.TrimSpace(c.Query("from")); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be RFC3339"})
+			return opts, false
+		}
+		opts.From = &t
+	}
+	if v := strings.TrimSpace(c.Query("to")); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be RFC3339"})
+			return opts, false
+		}
+		opts.To = &t
+	}
+	if v := strings.ToLower(strings.TrimSpace(c.Query("matchType"))); v != "" {
+		if v != "teams" && v != "players" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "matchType must be 'teams' or 'players'"})
+			return opts, false
+		}
+		opts.MatchType = &v
+	}
+	if v := strings.TrimSpace(c.Query("location")); v != "" {
+		opts.Location = &v
+	}
+	if v := c.Query("includeExhibition"); v != "" {
+		b, ok := parseBoolFlexible(v)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid includeExhibition"})
+			return opts, false
+		}
+		opts.IncludeExhibition = b
+	}
+	return opts, true
+}
+
+// GET /api/v1/players/:id/form
+func (h *CareerStatsHandler) PlayerForm(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid player ID"})
+		return
+	}
+	out, err := h.services.CareerStatsService.GetPlayerForm(c.Request.Context(), id)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "player not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute form"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"playerId": id, "career": out.Career, "seasons": out.Seasons})
+}
+
+// GET /api/v1/teams/:id/form
+func (h *CareerStatsHandler) TeamForm(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team ID"})
+		return
+	}
+	out, err := h.services.CareerStatsService.GetTeamForm(c.Request.Context(), id)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute form"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"teamId": id, "career": out.Career, "seasons": out.Seasons})
+}
+
+// GET /api/v1/players/:id/partners
+func (h *CareerStatsHandler) PlayerPartners(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid player ID"})
+		return
+	}
+	rows, err := h.services.CareerStatsService.GetPlayerPartners(c.Request.Context(), id)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "player not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch partners"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"playerId": id, "data": rows})
+}
+
+// GET /api/v1/leagues/:id/pairings?minGames=&limit=
+func (h *CareerStatsHandler) LeaguePairings(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+	minGames := parseIntDefault(c.Query("minGames"), 5)
+	limit := parseIntDefault(c.Query("limit"), 25)
+
+	rows, err := h.services.CareerStatsService.ListLeaguePairings(c.Request.Context(), id, minGames, limit)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch pairings"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"leagueId": id, "minGames": minGames, "data": rows})
+}
+
+// GET /api/v1/players/:id/timeseries?metric=winPct|pointDiff|twenties&bucket=week|month&window=
+func (h *CareerStatsHandler) PlayerTimeseries(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid player ID"})
+		return
+	}
+	opts, ok := parseTimeseriesQuery(c)
+	if !ok {
+		return
+	}
+	out, err := h.services.CareerStatsService.GetPlayerTimeseries(c.Request.Context(), id, opts)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "player not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// GET /api/v1/teams/:id/timeseries?metric=winPct|pointDiff|twenties&bucket=week|month&window=
+func (h *CareerStatsHandler) TeamTimeseries(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team ID"})
+		return
+	}
+	opts, ok := parseTimeseriesQuery(c)
+	if !ok {
+		return
+	}
+	out, err := h.services.CareerStatsService.GetTeamTimeseries(c.Request.Context(), id, opts)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// parseTimeseriesQuery reads metric/bucket/window on top of the career filters; on
+// failure it writes a 400 and returns false. Metric and bucket are validated by the service.
+func parseTimeseriesQuery(c *gin.Context) (services.TimeseriesOptions, bool) {
+	base, ok := parseCareerStatsQuery(c)
+	if !ok {
+		return services.TimeseriesOptions{}, false
+	}
+	opts := services.TimeseriesOptions{
+		CareerStatsOptions: base,
+		Metric:             c.DefaultQuery("metric", services.MetricWinPct),
+		Bucket:             strings.ToLower(c.DefaultQuery("bucket", services.BucketWeek)),
+	}
+	if v := c.Query("window"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid window"})
+			return opts, false
+		}
+		opts.Window = n
+	}
+	return opts, true
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/matt-j-deasy/betty-crokers-api/models"
 	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
 )
 
 type GameHandler struct {
@@ -28,27 +30,61 @@ type gameParticipantReq struct {
 }
 
 type createGameReq struct {
-	SeasonID     *int64             `json:"seasonId"`
-	MatchType    string             `json:"matchType" binding:"required,oneof=teams players"`
-	TargetPoints *int               `json:"targetPoints"`
-	ScheduledAt  *string            `json:"scheduledAt"` // RFC3339
-	Timezone     *string            `json:"timezone"`    // IANA
-	Location     *string            `json:"location"`
-	Description  *string            `json:"description"`
-	SideA        gameParticipantReq `json:"sideA" binding:"required"`
-	SideB        gameParticipantReq `json:"sideB" binding:"required"`
+	SeasonID       *int64             `json:"seasonId"`
+	MatchdayID     *int64             `json:"matchdayId"`
+	MatchType      string             `json:"matchType" binding:"omitempty,oneof=teams players"` // default from season or league
+	TargetPoints   *int               `json:"targetPoints"`
+	ScoringMode    *string            `json:"scoringMode"` // first_to|highest_total
+	ColorPolicy    *string            `json:"colorPolicy"` // natural|side_a_white|random
+	ScheduledAt    *string            `json:"scheduledAt"` // RFC3339
+	Timezone       *string            `json:"timezone"`    // IANA
+	Location       *string            `json:"location"`    // ignored when venueId/boardId is given
+	VenueID        *int64             `json:"venueId"`
+	BoardID        *int64             `json:"boardId"`
+	Description    *string            `json:"description"`
+	Draft          *bool              `json:"draft"` // default: the matchday's state, else published
+	SideA          gameParticipantReq `json:"sideA" binding:"required"`
+	SideB          gameParticipantReq `json:"sideB" binding:"required"`
+	AllowConflicts bool               `json:"allowConflicts"` // schedule despite availability/double-booking conflicts
 }
 
 type updateGameReq struct {
-	SeasonID     *int64  `json:"seasonId"`
-	TargetPoints *int    `json:"targetPoints"`
-	ScheduledAt  *string `json:"scheduledAt"` // RFC3339 or "" to clear
-	Timezone     *string `json:"timezone"`
-	Location     *string `json:"location"`    // send null to clear
-	Description  *string `json:"description"` // send null to clear
-	Status       *string `json:"status"`      // scheduled|in_progress|canceled|completed
-	SideAColor   *string `json:"sideAColor"`  // "white" | "black" | "natural"
-	SideBColor   *string `json:"sideBColor"`  // "white" | "black" | "natural"
+	SeasonID       *int64  `json:"seasonId"`
+	MatchdayID     *int64  `json:"matchdayId"` // 0 detaches
+	TargetPoints   *int    `json:"targetPoints"`
+	ScoringMode    *string `json:"scoringMode"` // first_to|highest_total
+	ScheduledAt    *string `json:"scheduledAt"` // RFC3339 or "" to clear
+	Timezone       *string `json:"timezone"`
+	Location       *string `json:"location"`       // send null to clear
+	VenueID        *int64  `json:"venueId"`        // 0 clears venue and board
+	BoardID        *int64  `json:"boardId"`        // 0 clears the board
+	Description    *string `json:"description"`    // send null to clear
+	Status         *string `json:"status"`         // scheduled|in_progress|canceled|completed
+	Draft          *bool   `json:"draft"`          // true moves a scheduled game back to draft
+	SideAColor     *string `json:"sideAColor"`     // "white" | "black" | "natural"
+	SideBColor     *string `json:"sideBColor"`     // "white" | "black" | "natural"
+	AllowConflicts bool    `json:"allowConflicts"` // reschedule despite availability/double-booking conflicts
+}
+
+type bulkFilterReq struct {
+	SeasonID       *int64   `json:"seasonId"`
+	ExhibitionOnly *bool    `json:"exhibitionOnly"`
+	Status         []string `json:"status"` // scheduled|canceled; default scheduled
+	MatchType      *string  `json:"matchType"`
+	ScheduledFrom  *string  `json:"scheduledFrom"` // RFC3339
+	ScheduledTo    *string  `json:"scheduledTo"`   // RFC3339
+	TeamID         *int64   `json:"teamId"`
+	PlayerID       *int64   `json:"playerId"`
+}
+
+type bulkRescheduleReq struct {
+	Filter         bulkFilterReq `json:"filter" binding:"required"`
+	ShiftMinutes   *int          `json:"shiftMinutes"`
+	Date           *string       `json:"date"`     // YYYY-MM-DD, keeps each game's local kick-off time
+	VenueID        *int64        `json:"venueId"`  // clears the board
+	Location       *string       `json:"location"` // clears venue and board; "" clears
+	DryRun         bool          `json:"dryRun"`
+	AllowConflicts bool          `json:"allowConflicts"`
 }
 
 type completeReq struct {
@@ -57,6 +93,17 @@ type completeReq struct {
 
 /* ===== Handlers ===== */
 
+// writeGameWriteError maps create/update failures: scheduling conflicts are a 409
+// listing each conflict, anything else is a validation 400.
+func writeGameWriteError(c *gin.Context, err error) {
+	var conflict *services.ScheduleConflictError
+	if errors.As(err, &conflict) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "conflicts": conflict.Conflicts})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
 func (h *GameHandler) Create(c *gin.Context) {
 	var req createGameReq
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -76,12 +123,18 @@ func (h *GameHandler) Create(c *gin.Context) {
 
 	game, sides, err := h.services.GameService.Create(c, services.CreateGameInput{
 		SeasonID:     req.SeasonID,
+		MatchdayID:   req.MatchdayID,
 		MatchType:    req.MatchType,
 		TargetPoints: req.TargetPoints,
+		ScoringMode:  req.ScoringMode,
+		ColorPolicy:  req.ColorPolicy,
 		ScheduledAt:  req.ScheduledAt,
 		Timezone:     req.Timezone,
 		Location:     req.Location,
+		VenueID:      req.VenueID,
+		BoardID:      req.BoardID,
 		Description:  req.Description,
+		Draft:        req.Draft,
 		SideA: services.GameParticipantInput{
 			TeamID:   req.SideA.TeamID,
 			PlayerID: req.SideA.PlayerID,
@@ -92,9 +145,10 @@ func (h *GameHandler) Create(c *gin.Context) {
 			PlayerID: req.SideB.PlayerID,
 			Color:    colorB,
 		},
+		AllowConflicts: req.AllowConflicts,
 	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeGameWriteError(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{"game": game, "sides": sides})
@@ -107,7 +161,7 @@ func (h *GameHandler) Get(c *gin.Context) {
 		return
 	}
 	g, err := h.services.GameService.GetByID(c, id)
-	if err != nil {
+	if err != nil || (g.Draft && !canSeeDrafts(c, h.services)) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
 		return
 	}
@@ -121,7 +175,7 @@ func (h *GameHandler) GetWithSides(c *gin.Context) {
 		return
 	}
 	g, sides, err := h.services.GameService.GetWithSides(c, id)
-	if err != nil {
+	if err != nil || (g.Draft && !canSeeDrafts(c, h.services)) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
 		return
 	}
@@ -152,18 +206,24 @@ func (h *GameHandler) Update(c *gin.Context) {
 	}
 
 	out, err := h.services.GameService.Update(c, id, services.UpdateGameInput{
-		SeasonID:     req.SeasonID,
-		TargetPoints: req.TargetPoints,
-		ScheduledAt:  req.ScheduledAt,
-		Timezone:     req.Timezone,
-		Location:     req.Location,
-		Description:  req.Description,
-		Status:       req.Status,
-		SideAColor:   colorA,
-		SideBColor:   colorB,
+		SeasonID:       req.SeasonID,
+		MatchdayID:     req.MatchdayID,
+		TargetPoints:   req.TargetPoints,
+		ScoringMode:    req.ScoringMode,
+		ScheduledAt:    req.ScheduledAt,
+		Timezone:       req.Timezone,
+		Location:       req.Location,
+		VenueID:        req.VenueID,
+		BoardID:        req.BoardID,
+		Description:    req.Description,
+		Status:         req.Status,
+		Draft:          req.Draft,
+		SideAColor:     colorA,
+		SideBColor:     colorB,
+		AllowConflicts: req.AllowConflicts,
 	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeGameWriteError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, out)
@@ -182,7 +242,7 @@ func (h *GameHandler) Delete(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
-// GET /api/v1/games?seasonId=&exhibitionOnly=&status=&matchType=&scheduledFrom=&scheduledTo=&teamId=&playerId=&page=&size=&orderBy=
+// GET /api/v1/games?seasonId=&exhibitionOnly=&status=&matchType=&scheduledFrom=&scheduledTo=&timezone=&teamId=&playerId=&templateId=&page=&size=&orderBy=
 func (h *GameHandler) List(c *gin.Context) {
 	page := parseIntDefault(c.Query("page"), 1)
 	size := parseIntDefault(c.Query("size"), 25)
@@ -235,25 +295,33 @@ func (h *GameHandler) List(c *gin.Context) {
 		}
 	}
 
+	// scheduledFrom/scheduledTo are instants (RFC3339) or local dates (YYYY-MM-DD,
+	// inclusive) in ?timezone=, defaulting to the season's timezone.
 	var scheduledFromPtr, scheduledToPtr *time.Time
+	var scheduledFromDate, scheduledToDate, tzPtr *string
 	if v := strings.TrimSpace(c.Query("scheduledFrom")); v != "" {
-		t, err := time.Parse(time.RFC3339, v)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "scheduledFrom must be RFC3339"})
+		var ok bool
+		if scheduledFromPtr, scheduledFromDate, ok = parseInstantOrDate(v); !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "scheduledFrom must be RFC3339 or YYYY-MM-DD"})
 			return
 		}
-		scheduledFromPtr = &t
 	}
 	if v := strings.TrimSpace(c.Query("scheduledTo")); v != "" {
-		t, err := time.Parse(time.RFC3339, v)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "scheduledTo must be RFC3339"})
+		var ok bool
+		if scheduledToPtr, scheduledToDate, ok = parseInstantOrDate(v); !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "scheduledTo must be RFC3339 or YYYY-MM-DD"})
 			return
 		}
-		scheduledToPtr = &t
+	}
+	if v := strings.TrimSpace(c.Query("timezone")); v != "" {
+		if _, err := time.LoadLocation(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid timezone"})
+			return
+		}
+		tzPtr = &v
 	}
 
-	var teamIDPtr, playerIDPtr *int64
+	var teamIDPtr, playerIDPtr, templateIDPtr *int64
 	if v := c.Query("teamId"); v != "" {
 		if id, ok := parseID(v); ok {
 			teamIDPtr = &id
@@ -270,19 +338,32 @@ func (h *GameHandler) List(c *gin.Context) {
 			return
 		}
 	}
+	if v := c.Query("templateId"); v != "" {
+		if id, ok := parseID(v); ok {
+			templateIDPtr = &id
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid templateId"})
+			return
+		}
+	}
 
 	out, err := h.services.GameService.List(c, services.ListGamesOptions{
-		SeasonID:       seasonIDPtr,
-		ExhibitionOnly: exhibitionOnlyPtr,
-		Status:         statuses,
-		MatchType:      matchTypePtr,
-		ScheduledFrom:  scheduledFromPtr,
-		ScheduledTo:    scheduledToPtr,
-		TeamID:         teamIDPtr,
-		PlayerID:       playerIDPtr,
-		Page:           page,
-		Size:           size,
-		OrderBy:        orderBy,
+		SeasonID:          seasonIDPtr,
+		ExhibitionOnly:    exhibitionOnlyPtr,
+		Status:            statuses,
+		MatchType:         matchTypePtr,
+		ScheduledFrom:     scheduledFromPtr,
+		ScheduledTo:       scheduledToPtr,
+		ScheduledFromDate: scheduledFromDate,
+		ScheduledToDate:   scheduledToDate,
+		Timezone:          tzPtr,
+		TeamID:            teamIDPtr,
+		PlayerID:          playerIDPtr,
+		TemplateID:        templateIDPtr,
+		IncludeDrafts:     canSeeDrafts(c, h.services),
+		Page:              page,
+		Size:              size,
+		OrderBy:           orderBy,
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list games"})
@@ -310,9 +391,123 @@ func (h *GameHandler) Complete(c *gin.Context) {
 	c.JSON(http.StatusOK, out)
 }
 
+// POST /api/v1/games/:id/publish
+func (h *GameHandler) Publish(c *gin.Context) {
+	id, ok := parseID(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+		return
+	}
+	out, err := h.services.PublishService.PublishGame(c.Request.Context(), id)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to publish game"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
 /* ===== helpers ===== */
 
 func parseID(s string) (int64, bool) {
 	id, err := strconv.ParseInt(s, 10, 64)
 	return id, err == nil && id > 0
 }
+
+// parseInstantOrDate accepts an RFC3339 instant or a YYYY-MM-DD local date and
+// returns whichever it got.
+func parseInstantOrDate(s string) (*time.Time, *string, bool) {
+	if _, err := time.Parse("2006-01-02", s); err == nil {
+		return nil, &s, true
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, nil, false
+	}
+	return &t, nil, true
+}
+
+// canSeeDrafts reports whether the caller may see unpublished games and matchdays:
+// signed-in admins only. The role is read from the user row rather than the token,
+// so a demotion takes effect immediately.
+func canSeeDrafts(c *gin.Context, svcs *services.ServicesCollection) bool {
+	if !c.GetBool("authenticated") {
+		return false
+	}
+	id, err := strconv.ParseUint(c.GetString("userID"), 10, 64)
+	if err != nil {
+		return false
+	}
+	u, err := svcs.UserService.GetByID(uint(id))
+	return err == nil && u.Role == "admin"
+}
+
+// POST /api/v1/games/bulk-reschedule
+// Applies a time shift, new date and/or new venue/location to every game matching the
+// filter, in one transaction. Scheduling conflicts answer 409 with the summary and
+// nothing applied; dryRun previews without writing.
+func (h *GameHandler) BulkReschedule(c *gin.Context) {
+	var req bulkRescheduleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	f := services.ListGamesOptions{
+		SeasonID:       req.Filter.SeasonID,
+		ExhibitionOnly: req.Filter.ExhibitionOnly,
+		TeamID:         req.Filter.TeamID,
+		PlayerID:       req.Filter.PlayerID,
+	}
+	for _, st := range req.Filter.Status {
+		if st = strings.ToLower(strings.TrimSpace(st)); st != "" {
+			f.Status = append(f.Status, st)
+		}
+	}
+	if req.Filter.MatchType != nil {
+		mt := strings.ToLower(strings.TrimSpace(*req.Filter.MatchType))
+		if mt != "teams" && mt != "players" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "matchType must be 'teams' or 'players'"})
+			return
+		}
+		f.MatchType = &mt
+	}
+	for _, p := range []struct {
+		name string
+		raw  *string
+		dst  **time.Time
+	}{{"scheduledFrom", req.Filter.ScheduledFrom, &f.ScheduledFrom}, {"scheduledTo", req.Filter.ScheduledTo, &f.ScheduledTo}} {
+		if p.raw == nil || strings.TrimSpace(*p.raw) == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(*p.raw))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": p.name + " must be RFC3339"})
+			return
+		}
+		*p.dst = &t
+	}
+
+	out, err := h.services.GameService.BulkReschedule(c.Request.Context(), services.BulkRescheduleInput{
+		Filter:         f,
+		ShiftMinutes:   req.ShiftMinutes,
+		Date:           req.Date,
+		VenueID:        req.VenueID,
+		Location:       req.Location,
+		DryRun:         req.DryRun,
+		AllowConflicts: req.AllowConflicts,
+	})
+	if err != nil {
+		var conflict *services.ScheduleConflictError
+		if errors.As(err, &conflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "conflicts": conflict.Conflicts, "summary": out})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
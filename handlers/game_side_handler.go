@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/matt-j-deasy/betty-crokers-api/models"
 	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
 )
 
 type GameSideHandler struct {
@@ -31,6 +32,16 @@ type setPointsReq struct {
 	Points *int `json:"points" binding:"required,gte=0"`
 }
 
+type setTwentiesReq struct {
+	Twenties *int `json:"twenties" binding:"required,gte=0"`
+}
+
+type setParticipantReq struct {
+	TeamID         *int64 `json:"teamId"`
+	PlayerID       *int64 `json:"playerId"`
+	AllowConflicts bool   `json:"allowConflicts"`
+}
+
 /* ===== Handlers ===== */
 
 // GET /api/v1/games/:id/sides
@@ -40,6 +51,10 @@ func (h *GameSideHandler) ListByGame(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
 		return
 	}
+	if g, err := h.services.GameService.GetByID(c, gameID); err == nil && g.Draft && !canSeeDrafts(c, h.services) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+		return
+	}
 	out, err := h.services.GameSideService.ListByGame(c, gameID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list sides"})
@@ -123,3 +138,60 @@ func (h *GameSideHandler) SetPoints(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"game": game, "sides": sides})
 }
+
+// PUT /api/v1/games/:id/sides/:side/twenties
+func (h *GameSideHandler) SetTwenties(c *gin.Context) {
+	gameID, ok := parseID(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+		return
+	}
+	side := strings.ToUpper(strings.TrimSpace(c.Param("side")))
+	var req setTwentiesReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	out, err := h.services.GameSideService.SetTwenties(c, services.SetTwentiesInput{
+		GameID:   gameID,
+		Side:     side,
+		Twenties: *req.Twenties,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// PUT /api/v1/games/:id/sides/:side/participant
+// Draws the team or player for a side, e.g. one left "to be drawn" by a recurring template.
+func (h *GameSideHandler) SetParticipant(c *gin.Context) {
+	gameID, ok := parseID(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+		return
+	}
+	side := strings.ToUpper(strings.TrimSpace(c.Param("side")))
+	var req setParticipantReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	out, err := h.services.GameSideService.SetParticipant(c, services.SetSideParticipantInput{
+		GameID:         gameID,
+		Side:           side,
+		TeamID:         req.TeamID,
+		PlayerID:       req.PlayerID,
+		AllowConflicts: req.AllowConflicts,
+	})
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+		writeGameWriteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+type GameTemplateHandler struct {
+	services *services.ServicesCollection
+}
+
+func NewGameTemplateHandler(svcs *services.ServicesCollection) *GameTemplateHandler {
+	return &GameTemplateHandler{services: svcs}
+}
+
+// writeGameTemplateError maps not-found to 404 and anything else to a validation 400.
+func writeGameTemplateError(c *gin.Context, err error) {
+	if utils.IsNotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "game template not found"})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
+// GET /api/v1/game-templates?seasonId=&active=&page=&size=
+func (h *GameTemplateHandler) List(c *gin.Context) {
+	opts := services.ListGameTemplatesOptions{
+		Page: parseIntDefault(c.Query("page"), 1),
+		Size: parseIntDefault(c.Query("size"), 25),
+	}
+	if v := c.Query("seasonId"); v != "" {
+		id, ok := parseID(v)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid seasonId"})
+			return
+		}
+		opts.SeasonID = &id
+	}
+	if v := c.Query("active"); v != "" {
+		b, ok := parseBoolFlexible(v)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid active"})
+			return
+		}
+		opts.ActiveOnly = b
+	}
+	out, err := h.services.GameTemplateService.List(c.Request.Context(), opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list game templates"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// GET /api/v1/game-templates/:id
+// Its games are listed by GET /api/v1/games?templateId=:id.
+func (h *GameTemplateHandler) Get(c *gin.Context) {
+	id, ok := parseID(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid game template id"})
+		return
+	}
+	out, err := h.services.GameTemplateService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load game template"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// POST /api/v1/game-templates
+// Creates the template and materializes its upcoming games right away.
+func (h *GameTemplateHandler) Create(c *gin.Context) {
+	var in services.CreateGameTemplateInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	out, err := h.services.GameTemplateService.Create(c.Request.Context(), in)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, out)
+}
+
+// PUT /api/v1/game-templates/:id
+// Future games that have not started are updated to match.
+func (h *GameTemplateHandler) Update(c *gin.Context) {
+	id, ok := parseID(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid game template id"})
+		return
+	}
+	var in services.UpdateGameTemplateInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	out, err := h.services.GameTemplateService.Update(c.Request.Context(), id, in)
+	if err != nil {
+		writeGameTemplateError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// DELETE /api/v1/game-templates/:id
+// Removes the template and its future unplayed games; played games stay.
+func (h *GameTemplateHandler) Delete(c *gin.Context) {
+	id, ok := parseID(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid game template id"})
+		return
+	}
+	if err := h.services.GameTemplateService.Delete(c.Request.Context(), id); err != nil {
+		if utils.IsNotFound(err) {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete game template"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// POST /api/v1/game-templates/:id/materialize
+// Runs the scheduler for one template now instead of waiting for its next pass.
+func (h *GameTemplateHandler) Materialize(c *gin.Context) {
+	id, ok := parseID(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid game template id"})
+		return
+	}
+	out, err := h.services.GameTemplateService.Materialize(c.Request.Context(), id)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to materialize game template"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
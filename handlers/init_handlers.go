@@ -8,31 +8,47 @@ import (
 // InitializeHandlers initializes all the handlers
 func InitializeHandlers(services *services.ServicesCollection, cfg config.Environment) (*HandlersCollection, error) {
 	return &HandlersCollection{
-		HealthCheckHandler: NewHealthCheckHandler(*services, cfg),
-		AuthHandler:        NewAuthHandler(services),
-		UserHandler:        NewUserHandler(services),
-		PlayerHandler:      NewPlayerHandler(services),
-		LeagueHandler:      NewLeagueHandler(services),
-		SeasonHandler:      NewSeasonHandler(services),
-		TeamHandler:        NewTeamHandler(services),
-		TeamSeasonHandler:  NewTeamSeasonHandler(services),
-		GameHandler:        NewGameHandler(services),
-		GameSideHandler:    NewGameSideHandler(services),
-		SeasonStatsHandler: NewSeasonStatsHandler(services),
+		HealthCheckHandler:  NewHealthCheckHandler(*services, cfg),
+		AuthHandler:         NewAuthHandler(services),
+		UserHandler:         NewUserHandler(services),
+		PlayerHandler:       NewPlayerHandler(services),
+		LeagueHandler:       NewLeagueHandler(services),
+		SeasonHandler:       NewSeasonHandler(services),
+		TeamHandler:         NewTeamHandler(services),
+		TeamSeasonHandler:   NewTeamSeasonHandler(services),
+		GameHandler:         NewGameHandler(services),
+		GameSideHandler:     NewGameSideHandler(services),
+		SeasonStatsHandler:  NewSeasonStatsHandler(services),
+		CareerStatsHandler:  NewCareerStatsHandler(services),
+		AwardHandler:        NewAwardHandler(services),
+		AdvantageHandler:    NewAdvantageHandler(services),
+		AvailabilityHandler: NewAvailabilityHandler(services),
+		VenueHandler:        NewVenueHandler(services),
+		CalendarHandler:     NewCalendarHandler(services),
+		MatchdayHandler:     NewMatchdayHandler(services),
+		GameTemplateHandler: NewGameTemplateHandler(services),
 	}, nil
 }
 
 // HandlersCollection contains all the handlers
 type HandlersCollection struct {
-	HealthCheckHandler *HealthCheckHandler
-	AuthHandler        *AuthHandler
-	UserHandler        *UserHandler
-	PlayerHandler      *PlayerHandler
-	LeagueHandler      *LeagueHandler
-	SeasonHandler      *SeasonHandler
-	TeamHandler        *TeamHandler
-	TeamSeasonHandler  *TeamSeasonHandler
-	GameHandler        *GameHandler
-	GameSideHandler    *GameSideHandler
-	SeasonStatsHandler *SeasonStatsHandler
+	HealthCheckHandler  *HealthCheckHandler
+	AuthHandler         *AuthHandler
+	UserHandler         *UserHandler
+	PlayerHandler       *PlayerHandler
+	LeagueHandler       *LeagueHandler
+	SeasonHandler       *SeasonHandler
+	TeamHandler         *TeamHandler
+	TeamSeasonHandler   *TeamSeasonHandler
+	GameHandler         *GameHandler
+	GameSideHandler     *GameSideHandler
+	SeasonStatsHandler  *SeasonStatsHandler
+	CareerStatsHandler  *CareerStatsHandler
+	AwardHandler        *AwardHandler
+	AdvantageHandler    *AdvantageHandler
+	AvailabilityHandler *AvailabilityHandler
+	VenueHandler        *VenueHandler
+	CalendarHandler     *CalendarHandler
+	MatchdayHandler     *MatchdayHandler
+	GameTemplateHandler *GameTemplateHandler
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
 )
 
 type LeagueHandler struct {
@@ -17,7 +18,9 @@ func NewLeagueHandler(svcs *services.ServicesCollection) *LeagueHandler {
 }
 
 type createLeagueReq struct {
-	Name string `json:"name" binding:"required,min=1,max=200"`
+	Name     string                      `json:"name" binding:"required,min=1,max=200"`
+	Timezone *string                     `json:"timezone"` // IANA; default for new seasons
+	Defaults *services.GameDefaultsInput `json:"defaults"` // targetPoints, matchType, scoringMode, colorPolicy
 }
 
 func (h *LeagueHandler) Create(c *gin.Context) {
@@ -27,7 +30,9 @@ func (h *LeagueHandler) Create(c *gin.Context) {
 		return
 	}
 	l, err := h.services.LeagueService.Create(c, services.CreateLeagueInput{
-		Name: req.Name,
+		Name:     req.Name,
+		Timezone: req.Timezone,
+		Defaults: req.Defaults,
 	})
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -50,8 +55,29 @@ func (h *LeagueHandler) Get(c *gin.Context) {
 	c.JSON(http.StatusOK, l)
 }
 
+// GET /api/v1/leagues/:id/records
+func (h *LeagueHandler) Records(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+	out, err := h.services.RecordService.GetLeagueRecords(c.Request.Context(), id)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load league records"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
 type updateLeagueReq struct {
-	Name *string `json:"name" binding:"omitempty,min=1,max=200"`
+	Name     *string                     `json:"name" binding:"omitempty,min=1,max=200"`
+	Timezone *string                     `json:"timezone"` // "" clears
+	Defaults *services.GameDefaultsInput `json:"defaults"` // "" (or 0) clears a default
 }
 
 func (h *LeagueHandler) Update(c *gin.Context) {
@@ -66,7 +92,9 @@ func (h *LeagueHandler) Update(c *gin.Context) {
 		return
 	}
 	l, err := h.services.LeagueService.Update(c, id, services.UpdateLeagueInput{
-		Name: req.Name,
+		Name:     req.Name,
+		Timezone: req.Timezone,
+		Defaults: req.Defaults,
 	})
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+type MatchdayHandler struct {
+	services *services.ServicesCollection
+}
+
+func NewMatchdayHandler(svcs *services.ServicesCollection) *MatchdayHandler {
+	return &MatchdayHandler{services: svcs}
+}
+
+// parseMatchdayParams reads :seasonId and :number; on failure it writes a 400.
+func parseMatchdayParams(c *gin.Context) (int64, int, bool) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return 0, 0, false
+	}
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil || number < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid matchday number"})
+		return 0, 0, false
+	}
+	return seasonID, number, true
+}
+
+// writeMatchdayError maps not-found to 404, scheduling conflicts to 409 and anything
+// else to a validation 400.
+func writeMatchdayError(c *gin.Context, err error) {
+	if utils.IsNotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "matchday not found"})
+		return
+	}
+	writeGameWriteError(c, err)
+}
+
+// GET /api/v1/seasons/:seasonId/matchdays
+func (h *MatchdayHandler) List(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	out, err := h.services.MatchdayService.List(c.Request.Context(), seasonID, canSeeDrafts(c, h.services))
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list matchdays"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// GET /api/v1/seasons/:seasonId/matchdays/:number
+// The matchday with its fixtures (not yet completed) and results.
+func (h *MatchdayHandler) Get(c *gin.Context) {
+	seasonID, number, ok := parseMatchdayParams(c)
+	if !ok {
+		return
+	}
+	out, err := h.services.MatchdayService.GetFixtures(c.Request.Context(), seasonID, number, canSeeDrafts(c, h.services))
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "matchday not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load matchday"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// GET /api/v1/seasons/:seasonId/matchdays/:number/standings
+// Team and player standings counting only games on matchdays 1..number.
+func (h *MatchdayHandler) Standings(c *gin.Context) {
+	seasonID, number, ok := parseMatchdayParams(c)
+	if !ok {
+		return
+	}
+	serveSeasonCached(c, h.services.StatsCache, seasonID, func() (any, bool) {
+		out, err := h.services.SeasonService.GetMatchdayStandings(c.Request.Context(), seasonID, number)
+		if err != nil {
+			if utils.IsNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "season or matchday not found"})
+				return nil, false
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute standings"})
+			return nil, false
+		}
+		return out, true
+	})
+}
+
+// POST /api/v1/seasons/:seasonId/matchdays
+func (h *MatchdayHandler) Create(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	var in services.CreateMatchdayInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	out, err := h.services.MatchdayService.Create(c.Request.Context(), seasonID, in)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, out)
+}
+
+// PUT /api/v1/seasons/:seasonId/matchdays/:number
+func (h *MatchdayHandler) Update(c *gin.Context) {
+	seasonID, number, ok := parseMatchdayParams(c)
+	if !ok {
+		return
+	}
+	var in services.UpdateMatchdayInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	out, err := h.services.MatchdayService.Update(c.Request.Context(), seasonID, number, in)
+	if err != nil {
+		writeMatchdayError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// DELETE /api/v1/seasons/:seasonId/matchdays/:number
+func (h *MatchdayHandler) Delete(c *gin.Context) {
+	seasonID, number, ok := parseMatchdayParams(c)
+	if !ok {
+		return
+	}
+	if err := h.services.MatchdayService.Delete(c.Request.Context(), seasonID, number); err != nil {
+		writeMatchdayError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// POST /api/v1/seasons/:seasonId/matchdays/:number/postpone
+// Body: {"days": N} or {"date": "YYYY-MM-DD"}, optionally with "allowConflicts": true.
+// Blocking conflicts at the new times leave the matchday unchanged and return 409.
+func (h *MatchdayHandler) Postpone(c *gin.Context) {
+	seasonID, number, ok := parseMatchdayParams(c)
+	if !ok {
+		return
+	}
+	var in services.PostponeMatchdayInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	out, err := h.services.MatchdayService.Postpone(c.Request.Context(), seasonID, number, in)
+	if err != nil {
+		writeMatchdayError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// POST /api/v1/seasons/:seasonId/matchdays/:number/publish
+// Publishes the matchday and all of its games together.
+func (h *MatchdayHandler) Publish(c *gin.Context) {
+	seasonID, number, ok := parseMatchdayParams(c)
+	if !ok {
+		return
+	}
+	out, err := h.services.PublishService.PublishMatchday(c.Request.Context(), seasonID, number)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "matchday not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to publish matchday"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
 )
 
 type PlayerHandler struct {
@@ -48,9 +49,13 @@ func (h *PlayerHandler) Get(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid player ID"})
 		return
 	}
-	p, err := h.services.PlayerService.GetByID(c, id)
+	p, err := h.services.PlayerService.GetProfile(c, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "player not found"})
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "player not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch player"})
 		return
 	}
 	c.JSON(http.StatusOK, p)
@@ -3,13 +3,17 @@ package handlers
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/matt-j-deasy/betty-crokers-api/repositories"
 	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
 )
 
 type SeasonHandler struct {
@@ -25,8 +29,13 @@ type createSeasonReq struct {
 	Name        string  `json:"name" binding:"required,min=1,max=200"`
 	StartsOn    string  `json:"startsOn"` // "YYYY-MM-DD"
 	EndsOn      string  `json:"endsOn"`   // "YYYY-MM-DD"
-	Timezone    *string `json:"timezone"` // IANA
+	Timezone    *string `json:"timezone"` // IANA; default from the league
 	Description *string `json:"description"`
+
+	Defaults *services.GameDefaultsInput `json:"defaults"` // targetPoints, matchType, scoringMode, colorPolicy
+
+	Tiebreakers    []string `json:"tiebreakers"` // ordered: wins|win_pct|head_to_head|point_diff|points_for|strength_of_schedule|opp_opp_win_pct|adjusted_point_diff|coin_flip
+	TiebreakerSeed *int64   `json:"tiebreakerSeed"`
 }
 
 func (h *SeasonHandler) Create(c *gin.Context) {
@@ -42,6 +51,10 @@ func (h *SeasonHandler) Create(c *gin.Context) {
 		EndsOn:      req.EndsOn,
 		Timezone:    req.Timezone,
 		Description: req.Description,
+		Defaults:    req.Defaults,
+
+		Tiebreakers:    req.Tiebreakers,
+		TiebreakerSeed: req.TiebreakerSeed,
 	})
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -69,8 +82,13 @@ type updateSeasonReq struct {
 	Name        *string `json:"name" binding:"omitempty,min=1,max=200"`
 	StartsOn    *string `json:"startsOn"` // "YYYY-MM-DD"
 	EndsOn      *string `json:"endsOn"`   // "YYYY-MM-DD"
-	Timezone    *string `json:"timezone"` // IANA
+	Timezone    *string `json:"timezone"` // IANA; "" follows the league again
 	Description *string `json:"description"`
+
+	Defaults *services.GameDefaultsInput `json:"defaults"` // "" (or 0) clears a default
+
+	Tiebreakers    []string `json:"tiebreakers"`
+	TiebreakerSeed *int64   `json:"tiebreakerSeed"`
 }
 
 func (h *SeasonHandler) Update(c *gin.Context) {
@@ -91,6 +109,10 @@ func (h *SeasonHandler) Update(c *gin.Context) {
 		EndsOn:      req.EndsOn,
 		Timezone:    req.Timezone,
 		Description: req.Description,
+		Defaults:    req.Defaults,
+
+		Tiebreakers:    req.Tiebreakers,
+		TiebreakerSeed: req.TiebreakerSeed,
 	})
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -116,6 +138,26 @@ func (h *SeasonHandler) Delete(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// POST /api/v1/seasons/:seasonId/schedule/publish
+// Publishes every draft matchday and game in the season at once.
+func (h *SeasonHandler) PublishSchedule(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	out, err := h.services.PublishService.PublishSeason(c.Request.Context(), seasonID)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+			return
+		}
+		slog.Error("failed to publish season schedule", "seasonID", seasonID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to publish schedule"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
 func (h *SeasonHandler) List(c *gin.Context) {
 	page := parseIntDefault(c.Query("page"), 1)
 	size := parseIntDefault(c.Query("size"), 25)
@@ -150,13 +192,22 @@ func (h *SeasonHandler) Standings(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid season ID"})
 		return
 	}
-	rows, err := h.services.SeasonService.GetStandings(c, seasonID)
-	if err != nil {
-		// If season missing, your GetByID returns an error → 404
-		c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+	asOf, ok := parseAsOfQuery(c)
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, rows)
+	serveSeasonCached(c, h.services.StatsCache, seasonID, func() (any, bool) {
+		rows, err := h.services.SeasonService.GetStandings(c, seasonID, asOf)
+		if err != nil {
+			if utils.IsNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+				return nil, false
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute standings"})
+			return nil, false
+		}
+		return rows, true
+	})
 }
 
 func (h *SeasonHandler) ListPlayerStandings(c *gin.Context) {
@@ -181,24 +232,79 @@ func (h *SeasonHandler) ListPlayerStandings(c *gin.Context) {
 
 	var cur *repositories.PlayerStandingsCursor
 	if s := c.Query("cursor"); s != "" {
+		var tmp repositories.PlayerStandingsCursor
 		dec, err := base64.StdEncoding.DecodeString(s)
-		if err == nil {
-			var tmp repositories.PlayerStandingsCursor
-			if json.Unmarshal(dec, &tmp) == nil {
-				cur = &tmp
-			}
+		if err != nil || json.Unmarshal(dec, &tmp) != nil || tmp.PlayerID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+			return
 		}
+		cur = &tmp
 	}
 
-	rows, next, err := h.services.SeasonService.ListPlayerStandings(c.Request.Context(), seasonID, limit, cur)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute standings"})
+	asOf, ok := parseAsOfQuery(c)
+	if !ok {
+		return
+	}
+
+	serveSeasonCached(c, h.services.StatsCache, seasonID, func() (any, bool) {
+		rows, next, err := h.services.SeasonService.ListPlayerStandings(c.Request.Context(), seasonID, asOf, limit, cur)
+		if err != nil {
+			if utils.IsNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+				return nil, false
+			}
+			if errors.Is(err, services.ErrStaleCursor) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return nil, false
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute standings"})
+			return nil, false
+		}
+		return gin.H{
+			"seasonId":    seasonID,
+			"standings":   rows,
+			"next_cursor": next,
+		}, true
+	})
+}
+
+// GET /api/v1/seasons/:seasonId/standings/progression?type=teams|players
+func (h *SeasonHandler) RankProgression(c *gin.Context) {
+	seasonID, err := strconv.ParseInt(c.Param("seasonId"), 10, 64)
+	if err != nil || seasonID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid season ID"})
+		return
+	}
+	kind := strings.ToLower(strings.TrimSpace(c.DefaultQuery("type", "teams")))
+	if kind != "teams" && kind != "players" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be 'teams' or 'players'"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"seasonId":    seasonID,
-		"standings":   rows,
-		"next_cursor": next,
+	serveSeasonCached(c, h.services.StatsCache, seasonID, func() (any, bool) {
+		out, err := h.services.SeasonService.GetRankProgression(c.Request.Context(), seasonID, kind)
+		if err != nil {
+			if utils.IsNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+				return nil, false
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute rank progression"})
+			return nil, false
+		}
+		return out, true
 	})
 }
+
+// parseAsOfQuery reads the optional ?asOf= RFC3339 cutoff; on failure it writes a 400 and returns false.
+func parseAsOfQuery(c *gin.Context) (*time.Time, bool) {
+	v := strings.TrimSpace(c.Query("asOf"))
+	if v == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "asOf must be RFC3339"})
+		return nil, false
+	}
+	return &t, true
+}
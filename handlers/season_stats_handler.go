@@ -5,9 +5,13 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
 	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
 )
 
 type SeasonStatsHandler struct {
@@ -39,41 +43,280 @@ func parseSeasonIDParam(c *gin.Context) (int64, bool) {
 	return id, true
 }
 
-// GET /seasons/:seasonId/stats/players
+// GET /seasons/:seasonId/stats/players?from=&to=&location=&color=&opponentId=&matchType=&minGames=&sort=&order=&page=&size=
+// Without page/size the response is the bare array; with either it is {data,total,page,size}.
 func (h *SeasonStatsHandler) ListSeasonPlayerStats(c *gin.Context) {
 	seasonID, ok := parseSeasonIDParam(c)
 	if !ok {
 		slog.Error("invalid season ID param")
 		return
 	}
-
-	stats, err := h.services.SeasonStatsService.ListPlayerStats(c.Request.Context(), seasonID)
-	if err != nil {
-		slog.Error("failed to list season player stats", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to fetch player stats." + err.Error(),
-		})
+	q, paged, ok := parseSeasonStatsQuery(c)
+	if !ok {
 		return
 	}
 
-	slog.Info("fetched season player stats", "seasonID", seasonID, "count", len(stats))
-	c.JSON(http.StatusOK, stats)
+	serveSeasonCached(c, h.services.StatsCache, seasonID, func() (any, bool) {
+		stats, err := h.services.SeasonStatsService.ListPlayerStats(c.Request.Context(), repositories.SeasonScope(seasonID), q)
+		if err != nil {
+			slog.Error("failed to list season player stats", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to fetch player stats." + err.Error(),
+			})
+			return nil, false
+		}
+
+		slog.Info("fetched season player stats", "seasonID", seasonID, "count", len(stats.Data))
+		if paged {
+			return stats, true
+		}
+		return stats.Data, true
+	})
 }
 
-// GET /seasons/:seasonId/stats/teams
+// GET /seasons/:seasonId/stats/teams?from=&to=&location=&color=&opponentId=&matchType=&minGames=&sort=&order=&page=&size=
+// Without page/size the response is the bare array; with either it is {data,total,page,size}.
 func (h *SeasonStatsHandler) ListSeasonTeamStats(c *gin.Context) {
 	seasonID, ok := parseSeasonIDParam(c)
 	if !ok {
 		return
 	}
+	q, paged, ok := parseSeasonStatsQuery(c)
+	if !ok {
+		return
+	}
+
+	serveSeasonCached(c, h.services.StatsCache, seasonID, func() (any, bool) {
+		stats, err := h.services.SeasonStatsService.ListTeamStats(c.Request.Context(), repositories.SeasonScope(seasonID), q)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to fetch team stats." + err.Error(),
+			})
+			return nil, false
+		}
+		if paged {
+			return stats, true
+		}
+		return stats.Data, true
+	})
+}
+
+// parseSeasonStatsQuery reads the stats filters, sort and paging; on failure it writes
+// a 400 and returns ok=false. paged reports whether page or size was given.
+func parseSeasonStatsQuery(c *gin.Context) (q services.SeasonStatsQuery, paged bool, ok bool) {
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", &q.From}, {"to", &q.To}} {
+		if v := strings.TrimSpace(c.Query(p.name)); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": p.name + " must be RFC3339"})
+				return q, false, false
+			}
+			*p.dst = &t
+		}
+	}
+	for _, p := range []struct {
+		name string
+		dst  **string
+	}{{"location", &q.Location}, {"color", &q.Color}, {"matchType", &q.MatchType}} {
+		if v := strings.TrimSpace(c.Query(p.name)); v != "" {
+			if p.name != "location" {
+				v = strings.ToLower(v)
+			}
+			*p.dst = &v
+		}
+	}
+	if v := c.Query("opponentId"); v != "" {
+		id, ok := parseIDParam(v)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid opponentId"})
+			return q, false, false
+		}
+		q.OpponentID = &id
+	}
+	q.MinGames = parseIntDefault(c.Query("minGames"), 0)
+	q.Sort = strings.TrimSpace(c.Query("sort"))
+	q.Order = strings.ToLower(strings.TrimSpace(c.Query("order")))
+
+	if c.Query("page") != "" || c.Query("size") != "" {
+		paged = true
+		q.Page = parseIntDefault(c.Query("page"), 1)
+		q.Size = parseIntDefault(c.Query("size"), 25)
+		if q.Page < 1 {
+			q.Page = 1
+		}
+		if q.Size <= 0 || q.Size > 100 {
+			q.Size = 25
+		}
+	}
+
+	if err := q.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return q, false, false
+	}
+	return q, paged, true
+}
+
+// GET /seasons/:seasonId/stats/duration?staleAfterMinutes=
+// Not cached: the stale in_progress list depends on the current time.
+func (h *SeasonStatsHandler) SeasonDurationStats(c *gin.Context) {
+	seasonID, ok := parseSeasonIDParam(c)
+	if !ok {
+		return
+	}
+	staleAfter := services.DefaultStaleAfter
+	if v := c.Query("staleAfterMinutes"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "staleAfterMinutes must be a positive integer"})
+			return
+		}
+		staleAfter = time.Duration(n) * time.Minute
+	}
 
-	stats, err := h.services.SeasonStatsService.ListTeamStats(c.Request.Context(), seasonID)
+	stats, err := h.services.SeasonStatsService.GetDurationStats(c.Request.Context(), seasonID, staleAfter)
 	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "season not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to fetch team stats." + err.Error(),
+			"error": "failed to fetch duration stats." + err.Error(),
 		})
 		return
 	}
-
 	c.JSON(http.StatusOK, stats)
 }
+
+// ---------- Scoped stats (season, league, exhibition or global)
+
+// GET /stats/players?scope=season|league|exhibition|global&seasonId=&leagueId=&<season stats filters>
+func (h *SeasonStatsHandler) ScopedPlayerStats(c *gin.Context) {
+	scope, ok := parseStatsScope(c)
+	if !ok {
+		return
+	}
+	q, paged, ok := parseSeasonStatsQuery(c)
+	if !ok {
+		return
+	}
+	serveScoped(c, h.services, scope, func() (any, error) {
+		stats, err := h.services.SeasonStatsService.ListPlayerStats(c.Request.Context(), scope, q)
+		if err != nil || paged {
+			return stats, err
+		}
+		return stats.Data, nil
+	})
+}
+
+// GET /stats/teams?scope=season|league|exhibition|global&seasonId=&leagueId=&<season stats filters>
+func (h *SeasonStatsHandler) ScopedTeamStats(c *gin.Context) {
+	scope, ok := parseStatsScope(c)
+	if !ok {
+		return
+	}
+	q, paged, ok := parseSeasonStatsQuery(c)
+	if !ok {
+		return
+	}
+	serveScoped(c, h.services, scope, func() (any, error) {
+		stats, err := h.services.SeasonStatsService.ListTeamStats(c.Request.Context(), scope, q)
+		if err != nil || paged {
+			return stats, err
+		}
+		return stats.Data, nil
+	})
+}
+
+// GET /stats/standings?scope=&seasonId=&leagueId=&asOf=
+func (h *SeasonStatsHandler) ScopedStandings(c *gin.Context) {
+	scope, ok := parseStatsScope(c)
+	if !ok {
+		return
+	}
+	asOf, ok := parseAsOfQuery(c)
+	if !ok {
+		return
+	}
+	serveScoped(c, h.services, scope, func() (any, error) {
+		return h.services.SeasonService.GetScopedStandings(c.Request.Context(), scope, asOf)
+	})
+}
+
+// GET /stats/standings/players?scope=&seasonId=&leagueId=&asOf=
+func (h *SeasonStatsHandler) ScopedPlayerStandings(c *gin.Context) {
+	scope, ok := parseStatsScope(c)
+	if !ok {
+		return
+	}
+	asOf, ok := parseAsOfQuery(c)
+	if !ok {
+		return
+	}
+	serveScoped(c, h.services, scope, func() (any, error) {
+		return h.services.SeasonService.GetScopedPlayerStandings(c.Request.Context(), scope, asOf)
+	})
+}
+
+// parseStatsScope reads scope/seasonId/leagueId; on failure it writes a 400 and returns false.
+// Without ?scope= the scope is inferred: seasonId, then leagueId, else global.
+func parseStatsScope(c *gin.Context) (repositories.StatsScope, bool) {
+	kind := repositories.ScopeKind(strings.ToLower(strings.TrimSpace(c.Query("scope"))))
+	seasonID, leagueID := c.Query("seasonId"), c.Query("leagueId")
+	if kind == "" {
+		switch {
+		case seasonID != "":
+			kind = repositories.ScopeSeason
+		case leagueID != "":
+			kind = repositories.ScopeLeague
+		default:
+			kind = repositories.ScopeGlobal
+		}
+	}
+
+	scope := repositories.StatsScope{Kind: kind}
+	switch kind {
+	case repositories.ScopeSeason, repositories.ScopeLeague:
+		name, raw := "seasonId", seasonID
+		if kind == repositories.ScopeLeague {
+			name, raw = "leagueId", leagueID
+		}
+		id, ok := parseIDParam(raw)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "scope '" + string(kind) + "' requires a valid " + name})
+			return scope, false
+		}
+		scope.ID = id
+	case repositories.ScopeExhibition, repositories.ScopeGlobal:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": services.ErrInvalidScope.Error()})
+		return scope, false
+	}
+	return scope, true
+}
+
+// serveScoped answers a scoped stats GET. Season scopes go through the season stats
+// cache; wider scopes span many seasons' invalidations and are computed per request.
+func serveScoped(c *gin.Context, svcs *services.ServicesCollection, scope repositories.StatsScope, compute func() (any, error)) {
+	run := func() (any, bool) {
+		out, err := compute()
+		if err != nil {
+			if utils.IsNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": string(scope.Kind) + " not found"})
+				return nil, false
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute stats"})
+			return nil, false
+		}
+		return out, true
+	}
+	if scope.Kind == repositories.ScopeSeason {
+		serveSeasonCached(c, svcs.StatsCache, scope.ID, run)
+		return
+	}
+	if out, ok := run(); ok {
+		c.JSON(http.StatusOK, out)
+	}
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
+)
+
+// serveSeasonCached answers a season-scoped GET from the stats cache, honouring
+// If-None-Match / If-Modified-Since with a 304. On a miss it calls compute, which
+// returns the payload, or writes its own error response and returns ok=false
+// (errors are never cached).
+func serveSeasonCached(c *gin.Context, sc cache.StatsCache, seasonID int64, compute func() (any, bool)) {
+	// Path plus the canonically re-encoded query, so ?a=1&b=2 and ?b=2&a=1 share an entry.
+	key := c.Request.URL.Path + "?" + c.Request.URL.Query().Encode()
+
+	entry, hit := sc.Get(seasonID, key)
+	if !hit {
+		gen := sc.Generation(seasonID) // before compute, so a concurrent write discards this result
+		payload, ok := compute()
+		if !ok {
+			return
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to encode response"})
+			return
+		}
+		sum := sha256.Sum256(body)
+		entry = cache.Entry{
+			Body:         body,
+			ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+			LastModified: time.Now().UTC().Truncate(time.Second),
+		}
+		sc.Set(seasonID, gen, key, entry)
+	}
+
+	c.Header("ETag", entry.ETag)
+	c.Header("Last-Modified", entry.LastModified.Format(http.TimeFormat))
+	c.Header("Cache-Control", "public, no-cache") // always revalidate
+
+	if notModified(c.Request, entry) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", entry.Body)
+}
+
+// notModified applies RFC 9110 precedence: If-None-Match wins over If-Modified-Since.
+func notModified(r *http.Request, e cache.Entry) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == e.ETag {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		if t, err := http.ParseTime(ims); err == nil {
+			return !e.LastModified.After(t)
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/services"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+type VenueHandler struct {
+	services *services.ServicesCollection
+}
+
+func NewVenueHandler(svcs *services.ServicesCollection) *VenueHandler {
+	return &VenueHandler{services: svcs}
+}
+
+type createVenueReq struct {
+	Name       string  `json:"name" binding:"required,min=1,max=200"`
+	Address    *string `json:"address"`
+	Timezone   *string `json:"timezone"` // IANA
+	BoardCount *int    `json:"boardCount"`
+}
+
+type updateVenueReq struct {
+	Name       *string `json:"name" binding:"omitempty,min=1,max=200"`
+	Address    *string `json:"address"` // send null to clear
+	Timezone   *string `json:"timezone"`
+	BoardCount *int    `json:"boardCount"`
+}
+
+type renameBoardReq struct {
+	Name *string `json:"name" binding:"omitempty,max=64"` // null or "" clears
+}
+
+func (h *VenueHandler) Create(c *gin.Context) {
+	var req createVenueReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	v, err := h.services.VenueService.Create(c, services.CreateVenueInput{
+		Name:       req.Name,
+		Address:    req.Address,
+		Timezone:   req.Timezone,
+		BoardCount: req.BoardCount,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, v)
+}
+
+func (h *VenueHandler) Get(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid venue ID"})
+		return
+	}
+	v, err := h.services.VenueService.GetByID(c, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "venue not found"})
+		return
+	}
+	c.JSON(http.StatusOK, v)
+}
+
+func (h *VenueHandler) Update(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid venue ID"})
+		return
+	}
+	var req updateVenueReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	v, err := h.services.VenueService.Update(c, id, services.UpdateVenueInput{
+		Name:       req.Name,
+		Address:    req.Address,
+		Timezone:   req.Timezone,
+		BoardCount: req.BoardCount,
+	})
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "venue not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, v)
+}
+
+// PUT /api/v1/venues/:id/boards/:boardId
+func (h *VenueHandler) RenameBoard(c *gin.Context) {
+	venueID, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid venue ID"})
+		return
+	}
+	boardID, ok := parseIDParam(c.Param("boardId"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid board ID"})
+		return
+	}
+	var req renameBoardReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	b, err := h.services.VenueService.RenameBoard(c, venueID, boardID, req.Name)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "board not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, b)
+}
+
+func (h *VenueHandler) Delete(c *gin.Context) {
+	id, ok := parseIDParam(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid venue ID"})
+		return
+	}
+	if err := h.services.VenueService.Delete(c, id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *VenueHandler) List(c *gin.Context) {
+	out, err := h.services.VenueService.List(c, services.ListVenuesOptions{
+		Search: c.DefaultQuery("q", ""),
+		Page:   parseIntDefault(c.Query("page"), 1),
+		Size:   parseIntDefault(c.Query("size"), 25),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list venues"})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,210 @@
+// Package ical writes RFC 5545 iCalendar feeds.
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+// Calendar is a published (METHOD:PUBLISH) feed.
+type Calendar struct {
+	ProdID string
+	Name   string // X-WR-CALNAME
+	Events []Event
+}
+
+// Event is one VEVENT. Start/End are written as local times in TZID; a matching
+// VTIMEZONE is generated from the Go tz database.
+type Event struct {
+	UID          string
+	Sequence     int // bump on every change so clients apply reschedules
+	Stamp        time.Time
+	LastModified time.Time
+	Start        time.Time
+	End          time.Time
+	TZID         string // IANA name; empty or unknown falls back to UTC
+	Summary      string
+	Location     string
+	Description  string
+	Status       string // StatusConfirmed | StatusCancelled
+}
+
+// Encode renders the calendar with CRLF line endings and 75-octet line folding.
+func (c Calendar) Encode() []byte {
+	var b bytes.Buffer
+	w := func(line string) { writeFolded(&b, line) }
+
+	w("BEGIN:VCALENDAR")
+	w("VERSION:2.0")
+	w("PRODID:" + c.ProdID)
+	w("CALSCALE:GREGORIAN")
+	w("METHOD:PUBLISH")
+	if c.Name != "" {
+		w("X-WR-CALNAME:" + escapeText(c.Name))
+	}
+
+	// One VTIMEZONE per zone, covering the years the events span.
+	spans := map[string][2]int{}
+	for _, e := range c.Events {
+		loc := location(e.TZID)
+		if loc == time.UTC {
+			continue
+		}
+		from, to := e.Start.In(loc).Year(), e.End.In(loc).Year()
+		if s, ok := spans[loc.String()]; ok {
+			from, to = min(from, s[0]), max(to, s[1])
+		}
+		spans[loc.String()] = [2]int{from, to}
+	}
+	zones := make([]string, 0, len(spans))
+	for z := range spans {
+		zones = append(zones, z)
+	}
+	sort.Strings(zones)
+	for _, z := range zones {
+		loc, _ := time.LoadLocation(z)
+		writeTimezone(w, loc, spans[z][0], spans[z][1])
+	}
+
+	for _, e := range c.Events {
+		loc := location(e.TZID)
+		w("BEGIN:VEVENT")
+		w("UID:" + e.UID)
+		w(fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+		w("DTSTAMP:" + utcStamp(e.Stamp))
+		if !e.LastModified.IsZero() {
+			w("LAST-MODIFIED:" + utcStamp(e.LastModified))
+		}
+		if loc == time.UTC {
+			w("DTSTART:" + utcStamp(e.Start))
+			w("DTEND:" + utcStamp(e.End))
+		} else {
+			w("DTSTART;TZID=" + loc.String() + ":" + e.Start.In(loc).Format("20060102T150405"))
+			w("DTEND;TZID=" + loc.String() + ":" + e.End.In(loc).Format("20060102T150405"))
+		}
+		w("SUMMARY:" + escapeText(e.Summary))
+		if e.Location != "" {
+			w("LOCATION:" + escapeText(e.Location))
+		}
+		if e.Description != "" {
+			w("DESCRIPTION:" + escapeText(e.Description))
+		}
+		if e.Status != "" {
+			w("STATUS:" + e.Status)
+		}
+		w("END:VEVENT")
+	}
+	w("END:VCALENDAR")
+	return b.Bytes()
+}
+
+func location(tzid string) *time.Location {
+	if tzid == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(tzid)
+	if err != nil || loc.String() == "UTC" {
+		return time.UTC
+	}
+	return loc
+}
+
+func utcStamp(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// writeTimezone emits a VTIMEZONE with one STANDARD/DAYLIGHT block per UTC-offset
+// transition in [fromYear, toYear], or a single STANDARD block for fixed-offset zones.
+func writeTimezone(w func(string), loc *time.Location, fromYear, toYear int) {
+	start := time.Date(fromYear, 1, 1, 0, 0, 0, 0, loc)
+	end := time.Date(toYear+1, 1, 1, 0, 0, 0, 0, loc)
+
+	w("BEGIN:VTIMEZONE")
+	w("TZID:" + loc.String())
+	_, prevOffset := start.Zone()
+	found := false
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+		_, off := next.Zone()
+		if off == prevOffset {
+			continue
+		}
+		// Narrow the change to the second within this day.
+		lo, hi := day.Unix(), next.Unix()
+		for hi-lo > 1 {
+			mid := (lo + hi) / 2
+			if _, o := time.Unix(mid, 0).In(loc).Zone(); o == prevOffset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		at := time.Unix(hi, 0).In(loc)
+		name, _ := at.Zone()
+		writeObservance(w, at.IsDST(), at.UTC().Add(time.Duration(prevOffset)*time.Second), name, prevOffset, off)
+		prevOffset = off
+		found = true
+	}
+	if !found {
+		name, off := start.Zone()
+		writeObservance(w, false, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), name, off, off)
+	}
+	w("END:VTIMEZONE")
+}
+
+// writeObservance writes one STANDARD/DAYLIGHT block; localStart is the wall-clock
+// start in the offset that was in effect before the transition.
+func writeObservance(w func(string), dst bool, localStart time.Time, name string, from, to int) {
+	kind := "STANDARD"
+	if dst {
+		kind = "DAYLIGHT"
+	}
+	w("BEGIN:" + kind)
+	w("DTSTART:" + localStart.Format("20060102T150405"))
+	w("TZOFFSETFROM:" + formatOffset(from))
+	w("TZOFFSETTO:" + formatOffset(to))
+	if name != "" {
+		w("TZNAME:" + escapeText(name))
+	}
+	w("END:" + kind)
+}
+
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign, seconds = "-", -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// writeFolded writes line + CRLF, folding at 75 octets without splitting a UTF-8 rune.
+func writeFolded(b *bytes.Buffer, line string) {
+	const limit = 75
+	width := limit
+	for len(line) > width {
+		cut := width
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		width = limit - 1 // continuation lines start with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
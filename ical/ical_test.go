@@ -0,0 +1,106 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"Smith; Jones", `Smith\; Jones`},
+		{"a, b", `a\, b`},
+		{`back\slash`, `back\\slash`},
+		{"line1\nline2", `line1\nline2`},
+		{"line1\r\nline2", `line1\nline2`},
+		{"line1\rline2", `line1\nline2`},
+		{`\;,`, `\\\;\,`}, // the backslash is escaped once, not again after ; and ,
+		{"colon: stays", "colon: stays"},
+	}
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		wantLines int
+	}{
+		{"short", "SUMMARY:Game", 1},
+		{"exactly 75 octets", strings.Repeat("a", 75), 1},
+		{"76 octets", strings.Repeat("a", 76), 2},
+		{"first line 75, continuation 74", strings.Repeat("a", 75+74), 2},
+		{"one more spills to a third", strings.Repeat("a", 75+74+1), 3},
+		// "é" is two octets; at 74 + 2 the rune straddles octet 75 and must move down whole.
+		{"multi-byte rune at the boundary", strings.Repeat("a", 74) + "é" + "b", 2},
+		{"all multi-byte", strings.Repeat("日", 60), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			writeFolded(&b, tt.line)
+			out := b.String()
+
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("output %q does not end in CRLF", out)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			if len(lines) != tt.wantLines {
+				t.Errorf("got %d lines, want %d: %q", len(lines), tt.wantLines, lines)
+			}
+			for i, l := range lines {
+				if len(l) > 75 {
+					t.Errorf("line %d is %d octets", i, len(l))
+				}
+				if i > 0 && !strings.HasPrefix(l, " ") {
+					t.Errorf("continuation line %d does not start with a space: %q", i, l)
+				}
+				if !utf8.ValidString(l) {
+					t.Errorf("line %d splits a rune: %q", i, l)
+				}
+			}
+
+			// Unfolding (RFC 5545 3.1) must give the original line back.
+			if got := strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", ""); got != tt.line {
+				t.Errorf("unfolded = %q, want %q", got, tt.line)
+			}
+		})
+	}
+}
+
+func TestEncodeTimezoneTransitions(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tz database unavailable")
+	}
+	start := time.Date(2025, 3, 9, 19, 0, 0, 0, ny)
+	out := string(Calendar{
+		ProdID: "-//test//EN",
+		Events: []Event{{
+			UID: "game-1@test", Stamp: start, Start: start, End: start.Add(time.Hour),
+			TZID: "America/New_York", Summary: "A vs B",
+		}},
+	}.Encode())
+
+	for _, want := range []string{
+		"BEGIN:VTIMEZONE\r\nTZID:America/New_York\r\n",
+		// Spring forward at 02:00 EST, fall back at 02:00 EDT, both as local wall time.
+		"BEGIN:DAYLIGHT\r\nDTSTART:20250309T020000\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0400\r\nTZNAME:EDT\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20251102T020000\r\nTZOFFSETFROM:-0400\r\nTZOFFSETTO:-0500\r\nTZNAME:EST\r\n",
+		"DTSTART;TZID=America/New_York:20250309T190000\r\n",
+		"DTEND;TZID=America/New_York:20250309T200000\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence is the subset of an RFC 5545 RRULE used for recurring games:
+// FREQ=DAILY|WEEKLY, INTERVAL, BYDAY (weekly only, plain weekday codes), UNTIL
+// (a date) and COUNT. Weeks start on Monday, as with the RRULE default WKST=MO.
+type Recurrence struct {
+	Freq     string // "DAILY" | "WEEKLY"
+	Interval int    // >= 1
+	ByDay    []time.Weekday
+	Until    *time.Time // inclusive, date only
+	Count    int        // 0 = unbounded
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// ParseRRule parses s, with or without a leading "RRULE:".
+func ParseRRule(s string) (Recurrence, error) {
+	r := Recurrence{Interval: 1}
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if s == "" {
+		return r, errors.New("recurrence is empty")
+	}
+	for _, part := range strings.Split(s, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return r, fmt.Errorf("recurrence: malformed part %q", part)
+		}
+		switch key {
+		case "FREQ":
+			if val != "DAILY" && val != "WEEKLY" {
+				return r, errors.New("recurrence: FREQ must be DAILY or WEEKLY")
+			}
+			r.Freq = val
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return r, errors.New("recurrence: INTERVAL must be a positive integer")
+			}
+			r.Interval = n
+		case "BYDAY":
+			r.ByDay = nil
+			seen := map[time.Weekday]bool{}
+			for _, code := range strings.Split(val, ",") {
+				wd, ok := weekdayCodes[code]
+				if !ok {
+					return r, fmt.Errorf("recurrence: unknown BYDAY %q", code)
+				}
+				if !seen[wd] {
+					seen[wd] = true
+					r.ByDay = append(r.ByDay, wd)
+				}
+			}
+		case "UNTIL":
+			// Only the date part matters; a trailing time (e.g. T235959Z) is ignored.
+			d, err := time.Parse("20060102", val[:min(len(val), 8)])
+			if err != nil {
+				return r, errors.New("recurrence: UNTIL must be YYYYMMDD")
+			}
+			r.Until = &d
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return r, errors.New("recurrence: COUNT must be a positive integer")
+			}
+			r.Count = n
+		default:
+			return r, fmt.Errorf("recurrence: %s is not supported", key)
+		}
+	}
+	if r.Freq == "" {
+		return r, errors.New("recurrence: FREQ is required")
+	}
+	if r.Freq == "DAILY" && len(r.ByDay) > 0 {
+		return r, errors.New("recurrence: BYDAY is only supported with FREQ=WEEKLY")
+	}
+	if r.Until != nil && r.Count > 0 {
+		return r, errors.New("recurrence: UNTIL and COUNT are mutually exclusive")
+	}
+	sort.Slice(r.ByDay, func(i, j int) bool { return mondayIndex(r.ByDay[i]) < mondayIndex(r.ByDay[j]) })
+	return r, nil
+}
+
+// String renders r in canonical RRULE form (without the "RRULE:" prefix).
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, wd := range r.ByDay {
+			codes = append(codes, strings.ToUpper(wd.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Dates expands the rule from start (the first possible date) and returns the
+// occurrence dates that fall within [from, to]. All values are calendar dates at
+// midnight UTC; COUNT is counted from start, not from.
+func (r Recurrence) Dates(start, from, to time.Time) []time.Time {
+	start, from, to = dateOnly(start), dateOnly(from), dateOnly(to)
+	if r.Until != nil && r.Until.Before(to) {
+		to = dateOnly(*r.Until)
+	}
+	interval := max(r.Interval, 1)
+
+	var out []time.Time
+	n := 0
+	emit := func(d time.Time) bool {
+		if d.After(to) {
+			return false
+		}
+		n++
+		if !d.Before(from) {
+			out = append(out, d)
+		}
+		return r.Count == 0 || n < r.Count
+	}
+
+	if r.Freq == "DAILY" {
+		for d := start; ; d = d.AddDate(0, 0, interval) {
+			if !emit(d) {
+				return out
+			}
+		}
+	}
+
+	days := r.ByDay
+	if len(days) == 0 {
+		days = []time.Weekday{start.Weekday()}
+	}
+	week := start.AddDate(0, 0, -mondayIndex(start.Weekday()))
+	for ; !week.After(to); week = week.AddDate(0, 0, 7*interval) {
+		for _, wd := range days {
+			d := week.AddDate(0, 0, mondayIndex(wd))
+			if d.Before(start) {
+				continue
+			}
+			if !emit(d) {
+				return out
+			}
+		}
+	}
+	return out
+}
+
+func mondayIndex(wd time.Weekday) int {
+	return (int(wd) + 6) % 7
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
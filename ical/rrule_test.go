@@ -0,0 +1,142 @@
+package ical
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string // canonical String(); empty when an error is expected
+		wantErr bool
+	}{
+		{in: "FREQ=WEEKLY", want: "FREQ=WEEKLY"},
+		{in: "RRULE:freq=weekly;byday=we,mo,we", want: "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{in: "FREQ=WEEKLY;BYDAY=SU,MO", want: "FREQ=WEEKLY;BYDAY=MO,SU"}, // weeks start on Monday
+		{in: "FREQ=DAILY;INTERVAL=1", want: "FREQ=DAILY"},
+		{in: "FREQ=WEEKLY;INTERVAL=2;COUNT=10", want: "FREQ=WEEKLY;INTERVAL=2;COUNT=10"},
+		{in: "FREQ=WEEKLY;UNTIL=20251231T235959Z", want: "FREQ=WEEKLY;UNTIL=20251231"},
+
+		{in: "", wantErr: true},
+		{in: "RRULE:", wantErr: true},
+		{in: "INTERVAL=2", wantErr: true},
+		{in: "FREQ=MONTHLY", wantErr: true},
+		{in: "FREQ=WEEKLY;INTERVAL=0", wantErr: true},
+		{in: "FREQ=WEEKLY;COUNT=-1", wantErr: true},
+		{in: "FREQ=WEEKLY;COUNT", wantErr: true},
+		{in: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{in: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{in: "FREQ=DAILY;BYDAY=MO", wantErr: true},
+		{in: "FREQ=WEEKLY;UNTIL=2025", wantErr: true},
+		{in: "FREQ=WEEKLY;COUNT=2;UNTIL=20250101", wantErr: true},
+		{in: "FREQ=WEEKLY;WKST=SU", wantErr: true},
+	}
+	for _, tt := range tests {
+		r, err := ParseRRule(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseRRule(%q) = %q, want an error", tt.in, r.String())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseRRule(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got := r.String(); got != tt.want {
+			t.Errorf("ParseRRule(%q).String() = %q, want %q", tt.in, got, tt.want)
+		}
+		// The canonical form must parse back to itself.
+		if again, err := ParseRRule(r.String()); err != nil || again.String() != tt.want {
+			t.Errorf("round trip of %q = %q, %v", tt.want, again.String(), err)
+		}
+	}
+}
+
+func TestRecurrenceDates(t *testing.T) {
+	d := func(s string) time.Time {
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			panic(err)
+		}
+		return t
+	}
+	ds := func(ss ...string) []time.Time {
+		var out []time.Time
+		for _, s := range ss {
+			out = append(out, d(s))
+		}
+		return out
+	}
+
+	// 2025-01-01 is a Wednesday.
+	tests := []struct {
+		name            string
+		rule            string
+		start, from, to string
+		want            []time.Time
+	}{
+		{
+			name: "weekly without BYDAY repeats the start weekday",
+			rule: "FREQ=WEEKLY", start: "2025-01-01", from: "2025-01-01", to: "2025-01-22",
+			want: ds("2025-01-01", "2025-01-08", "2025-01-15", "2025-01-22"),
+		},
+		{
+			name: "BYDAY days before start in its first week are skipped",
+			rule: "FREQ=WEEKLY;BYDAY=MO,WE", start: "2025-01-01", from: "2025-01-01", to: "2025-01-13",
+			want: ds("2025-01-01", "2025-01-06", "2025-01-08", "2025-01-13"),
+		},
+		{
+			name: "INTERVAL skips whole weeks counted from the start week",
+			rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH", start: "2025-01-01", from: "2025-01-01", to: "2025-01-31",
+			want: ds("2025-01-02", "2025-01-14", "2025-01-16", "2025-01-28", "2025-01-30"),
+		},
+		{
+			name: "COUNT stops the series",
+			rule: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=3", start: "2025-01-01", from: "2025-01-01", to: "2025-12-31",
+			want: ds("2025-01-01", "2025-01-06", "2025-01-08"),
+		},
+		{
+			name: "COUNT is counted from start, not from",
+			rule: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4", start: "2025-01-01", from: "2025-01-07", to: "2025-12-31",
+			want: ds("2025-01-08", "2025-01-13"),
+		},
+		{
+			name: "COUNT exhausted before from",
+			rule: "FREQ=DAILY;COUNT=2", start: "2025-01-01", from: "2025-01-05", to: "2025-01-31",
+		},
+		{
+			name: "UNTIL is inclusive",
+			rule: "FREQ=DAILY;INTERVAL=3;UNTIL=20250110", start: "2025-01-01", from: "2025-01-01", to: "2025-01-31",
+			want: ds("2025-01-01", "2025-01-04", "2025-01-07", "2025-01-10"),
+		},
+		{
+			name: "to earlier than UNTIL wins",
+			rule: "FREQ=DAILY;UNTIL=20250131", start: "2025-01-01", from: "2025-01-01", to: "2025-01-02",
+			want: ds("2025-01-01", "2025-01-02"),
+		},
+		{
+			name: "daily across the spring-forward weekend keeps calendar days",
+			rule: "FREQ=DAILY", start: "2025-03-08", from: "2025-03-08", to: "2025-03-10",
+			want: ds("2025-03-08", "2025-03-09", "2025-03-10"),
+		},
+		{
+			name: "window before start",
+			rule: "FREQ=WEEKLY", start: "2025-02-01", from: "2025-01-01", to: "2025-01-31",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRRule(%q): %v", tt.rule, err)
+			}
+			got := r.Dates(d(tt.start), d(tt.from), d(tt.to))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
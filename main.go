@@ -1,9 +1,15 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
+	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib" // Register pgx with database/sql
 
@@ -49,6 +55,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	// One-off maintenance commands run against the migrated DB and exit
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(repos, os.Args[1]))
+	}
+
 	// Initialize services
 	services, err := services.InitializeServices(repos, cfg)
 	if err != nil {
@@ -56,6 +67,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Cancelled on SIGINT/SIGTERM to stop background work and the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Materialize recurring games in the background
+	go services.GameTemplateService.RunScheduler(
+		ctx,
+		time.Duration(cfg.TemplateSchedulerMinutes)*time.Minute,
+	)
+
 	// Initialize handlers
 	handlers, err := handlers.InitializeHandlers(services, cfg)
 	if err != nil {
@@ -79,10 +100,21 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Start
-	if err := s.Start(addr); err != nil {
-		slog.Error("Server crashed", "err", err)
-		os.Exit(1)
+	// Start, then serve until a signal arrives
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- s.Start(addr) }()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Server crashed", "err", err)
+			os.Exit(1)
+		}
+	case <-ctx.Done():
+		stop()
+		if err := s.Shutdown(); err != nil {
+			os.Exit(1)
+		}
 	}
 }
 
@@ -99,3 +131,20 @@ func pickPort(portEnv string, localPort int, fallback int) string {
 	}
 	return strconv.Itoa(fallback)
 }
+
+// runCommand executes a maintenance command and returns the process exit code.
+func runCommand(repos *repositories.RepositoriesCollection, name string) int {
+	switch name {
+	case "rebuild-player-results":
+		n, err := repos.PlayerResultRepo.Rebuild(context.Background())
+		if err != nil {
+			slog.Error("Failed to rebuild player_game_results", "err", err)
+			return 1
+		}
+		slog.Info("Rebuilt player_game_results", "rows", n)
+		return 0
+	default:
+		slog.Error("unknown command", "command", name)
+		return 2
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -10,29 +11,62 @@ import (
 	"github.com/matt-j-deasy/betty-crokers-api/config"
 )
 
+var (
+	errMissingBearer = errors.New("missing bearer token")
+	errInvalidToken  = errors.New("invalid token")
+)
+
 func AuthMiddleware(cfg config.Environment) gin.HandlerFunc {
 	secret := []byte(cfg.JWTSecret)
 	return func(c *gin.Context) {
-		authz := c.GetHeader("Authorization")
-		if !strings.HasPrefix(authz, "Bearer ") {
+		claims, err := bearerClaims(c, secret)
+		if errors.Is(err, errMissingBearer) {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
 			return
 		}
-		tokenStr := strings.TrimPrefix(authz, "Bearer ")
-		token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (any, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrTokenUnverifiable
-			}
-			return secret, nil
-		})
-		if err != nil || !token.Valid {
+		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
 			return
 		}
-		claims, _ := token.Claims.(jwt.MapClaims)
+		c.Set("authenticated", true)
 		if sub, ok := claims["sub"].(string); ok {
 			c.Set("userID", sub)
 		}
 		c.Next()
 	}
 }
+
+// OptionalAuthMiddleware identifies the caller on public routes: a valid bearer token
+// sets "authenticated" (and "userID"), while a missing or invalid one is ignored.
+func OptionalAuthMiddleware(cfg config.Environment) gin.HandlerFunc {
+	secret := []byte(cfg.JWTSecret)
+	return func(c *gin.Context) {
+		if claims, err := bearerClaims(c, secret); err == nil {
+			c.Set("authenticated", true)
+			if sub, ok := claims["sub"].(string); ok {
+				c.Set("userID", sub)
+			}
+		}
+		c.Next()
+	}
+}
+
+// bearerClaims validates the request's bearer token and returns its claims.
+func bearerClaims(c *gin.Context, secret []byte) (jwt.MapClaims, error) {
+	authz := c.GetHeader("Authorization")
+	if !strings.HasPrefix(authz, "Bearer ") {
+		return nil, errMissingBearer
+	}
+	tokenStr := strings.TrimPrefix(authz, "Bearer ")
+	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrTokenUnverifiable
+		}
+		return secret, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, errInvalidToken
+	}
+	claims, _ := token.Claims.(jwt.MapClaims)
+	return claims, nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AvailabilityKind enumerates the scheduling constraints a player or team can register.
+type AvailabilityKind string
+
+const (
+	// AvailabilityBlackout: cannot play on any date from StartsOn to EndsOn (inclusive).
+	AvailabilityBlackout AvailabilityKind = "blackout"
+	// AvailabilityPreferred: a weekday and/or time window the participant can play in.
+	// Once a participant has any, games outside all of them conflict.
+	AvailabilityPreferred AvailabilityKind = "preferred"
+)
+
+// Availability is a player's or team's scheduling constraint within one season.
+// Dates and times are local to the season's timezone. Exactly one of PlayerID/TeamID is set.
+type Availability struct {
+	ID       int64 `gorm:"primaryKey"`
+	SeasonID int64 `gorm:"not null;index;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+
+	PlayerID *int64 `gorm:"index"`
+	TeamID   *int64 `gorm:"index"`
+
+	Kind AvailabilityKind `gorm:"type:varchar(16);not null"`
+
+	// Blackout range
+	StartsOn *time.Time `gorm:"type:date"`
+	EndsOn   *time.Time `gorm:"type:date"`
+
+	// Preferred window; nil fields match anything.
+	Weekday   *int    // 0 = Sunday ... 6 = Saturday
+	StartTime *string `gorm:"type:varchar(5)"` // "HH:MM"
+	EndTime   *string `gorm:"type:varchar(5)"` // "HH:MM"
+
+	Note *string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
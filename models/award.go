@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// AwardType enumerates the season awards the engine can compute.
+type AwardType string
+
+const (
+	AwardBestWinPct      AwardType = "best_win_pct"
+	AwardMostTwenties    AwardType = "most_twenties"
+	AwardBestPointDiff   AwardType = "best_point_diff"
+	AwardMostImproved    AwardType = "most_improved" // win% gain over the player's previous season in the league
+	AwardIronPlayer      AwardType = "iron_player"   // most games played
+	AwardBestPartnership AwardType = "best_partnership"
+)
+
+// SeasonAwardSetting overrides an award's defaults for one season.
+// Awards without a row use the engine defaults.
+type SeasonAwardSetting struct {
+	ID       int64     `gorm:"primaryKey"`
+	SeasonID int64     `gorm:"not null;uniqueIndex:uniq_season_award_setting,priority:1;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	Award    AwardType `gorm:"type:varchar(32);not null;uniqueIndex:uniq_season_award_setting,priority:2"`
+
+	Enabled  bool `gorm:"not null;default:true"`
+	MinGames int  `gorm:"not null;default:1"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SeasonAward is a winner locked in by an admin. Once locked it no longer follows
+// the computed leader. PartnerID is set for best_partnership only.
+type SeasonAward struct {
+	ID       int64     `gorm:"primaryKey"`
+	SeasonID int64     `gorm:"not null;uniqueIndex:uniq_season_award,priority:1;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	Award    AwardType `gorm:"type:varchar(32);not null;uniqueIndex:uniq_season_award,priority:2"`
+
+	PlayerID  int64  `gorm:"not null;index"`
+	PartnerID *int64 `gorm:"index"`
+
+	// Snapshot of the winning figure at lock time.
+	Value float64 `gorm:"not null;default:0"`
+	Games int64   `gorm:"not null;default:0"`
+
+	LockedBy *string // JWT subject of the admin who locked it
+	LockedAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
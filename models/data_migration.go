@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// DataMigration marks a one-shot data backfill as done so later boots skip it.
+type DataMigration struct {
+	Name  string    `gorm:"primaryKey;type:varchar(64)"`
+	RanAt time.Time `gorm:"not null"`
+}
@@ -13,13 +13,21 @@ type Game struct {
 	// Nullable: if NULL, this is an exhibition game.
 	SeasonID *int64 `gorm:"index;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
 
+	// Optional round within the season; must belong to SeasonID.
+	MatchdayID *int64 `gorm:"index;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+
 	// "teams" or "players" — both sides must be the same kind; enforce in service.
 	MatchType string `gorm:"type:varchar(16);not null;default:players;index"`
 
 	// Scoring
-	TargetPoints int     `gorm:"not null;default:100"`                              // first to 100
-	Status       string  `gorm:"type:varchar(16);not null;default:scheduled;index"` // scheduled|in_progress|completed|canceled
-	WinnerSide   *string `gorm:"type:char(1)"`                                      // "A" or "B" when completed
+	TargetPoints int         `gorm:"not null;default:100"` // see ScoringMode
+	ScoringMode  ScoringMode `gorm:"type:varchar(16);not null;default:first_to"`
+	ColorPolicy  ColorPolicy `gorm:"type:varchar(16);not null;default:natural"`         // how side colours were assigned
+	Status       string      `gorm:"type:varchar(16);not null;default:scheduled;index"` // scheduled|in_progress|completed|canceled
+	WinnerSide   *string     `gorm:"type:char(1)"`                                      // "A" or "B" when completed
+
+	// Draft games are hidden from anonymous readers until published.
+	Draft bool `gorm:"not null;default:false;index"`
 
 	// Scheduling
 	ScheduledAt *time.Time
@@ -27,10 +35,23 @@ type Game struct {
 	EndedAt     *time.Time
 	Timezone    string `gorm:"not null;default:America/New_York"`
 
+	// Venue/board (optional). Location mirrors the venue name for older clients.
+	VenueID *int64 `gorm:"index;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+	BoardID *int64 `gorm:"index;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+
+	// Set on games materialized from a recurring template. OccurrenceDate is the
+	// template date the game stands for, even if ScheduledAt is later moved. A date
+	// has at most one game, soft-deleted ones included: those mark dates not to refill.
+	TemplateID     *int64     `gorm:"uniqueIndex:uniq_game_template_occurrence,priority:1;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+	OccurrenceDate *time.Time `gorm:"type:date;uniqueIndex:uniq_game_template_occurrence,priority:2"`
+
 	// Optional metadata
 	Location    *string
 	Description *string
 
+	// Where Timezone, TargetPoints, MatchType, ScoringMode and ColorPolicy came from.
+	Provenance GameProvenance `gorm:"type:jsonb"`
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// ScoringMode says how a game is decided.
+type ScoringMode string
+
+const (
+	// ScoringFirstTo: the first side to reach TargetPoints wins.
+	ScoringFirstTo ScoringMode = "first_to"
+	// ScoringHighestTotal: a fixed number of rounds is played and the higher total
+	// wins; TargetPoints is only a guide.
+	ScoringHighestTotal ScoringMode = "highest_total"
+)
+
+// ColorPolicy says which disc colour each side gets when none is given at creation.
+type ColorPolicy string
+
+const (
+	ColorPolicyNatural    ColorPolicy = "natural"      // both sides natural
+	ColorPolicySideAWhite ColorPolicy = "side_a_white" // A white, B black
+	ColorPolicyRandom     ColorPolicy = "random"       // one white and one black, drawn per game
+)
+
+// GameDefaults are the settings a league or season hands down to its new games.
+// A nil field defers to the next level: season, then league, then the built-in default.
+type GameDefaults struct {
+	TargetPoints *int
+	MatchType    *string      `gorm:"type:varchar(16)"`
+	ScoringMode  *ScoringMode `gorm:"type:varchar(16)"`
+	ColorPolicy  *ColorPolicy `gorm:"type:varchar(16)"`
+}
+
+// Sources recorded in GameProvenance.
+const (
+	SourceRequest = "request" // given when the game (or its template) was created or edited
+	SourceVenue   = "venue"   // timezone only
+	SourceSeason  = "season"
+	SourceLeague  = "league"
+	SourceDefault = "default" // built-in
+)
+
+// GameProvenance records where each of a game's inheritable settings came from.
+// Games created before settings were inherited have it empty.
+type GameProvenance struct {
+	Timezone     string
+	TargetPoints string
+	MatchType    string
+	ScoringMode  string
+	ColorPolicy  string
+}
+
+// Value stores the provenance as JSON.
+func (p GameProvenance) Value() (driver.Value, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan reads the JSON written by Value; NULL leaves p empty.
+func (p *GameProvenance) Scan(src any) error {
+	*p = GameProvenance{}
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return errors.New("unsupported provenance value")
+	}
+}
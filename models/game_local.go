@@ -0,0 +1,64 @@
+package models
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// LocalSchedule is a game's ScheduledAt as seen in the game's Timezone.
+type LocalSchedule struct {
+	StartsAt  string // RFC3339 with the local offset, e.g. 2025-03-09T19:00:00-04:00
+	Date      string // YYYY-MM-DD
+	Time      string // HH:MM, 24-hour
+	DayOfWeek string // e.g. "Sunday"
+	UTCOffset string // e.g. "-04:00"
+	Zone      string // abbreviation, e.g. "EDT"
+}
+
+var locations sync.Map // IANA name => *time.Location
+
+// cachedLocation is time.LoadLocation without rereading the zoneinfo every time.
+func cachedLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// Local renders ScheduledAt in the game's timezone; nil when the game is
+// unscheduled or its timezone is unknown.
+func (g Game) Local() *LocalSchedule {
+	if g.ScheduledAt == nil {
+		return nil
+	}
+	loc, err := cachedLocation(g.Timezone)
+	if err != nil {
+		return nil
+	}
+	t := g.ScheduledAt.In(loc)
+	zone, _ := t.Zone()
+	return &LocalSchedule{
+		StartsAt:  t.Format(time.RFC3339),
+		Date:      t.Format("2006-01-02"),
+		Time:      t.Format("15:04"),
+		DayOfWeek: t.Weekday().String(),
+		UTCOffset: t.Format("-07:00"),
+		Zone:      zone,
+	}
+}
+
+// MarshalJSON adds Local to the game's fields so clients don't convert ScheduledAt
+// themselves.
+func (g Game) MarshalJSON() ([]byte, error) {
+	type game Game // drops the method set, avoiding recursion
+	return json.Marshal(struct {
+		game
+		Local *LocalSchedule
+	}{game(g), g.Local()})
+}
@@ -27,6 +27,9 @@ type GameSide struct {
 	Color  DiscColor `gorm:"type:varchar(16);not null;default:natural;index"`
 	Points int       `gorm:"not null;default:0"`
 
+	// Number of 20-point shots (discs sunk in the centre hole) made by this side.
+	Twenties int `gorm:"not null;default:0"`
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GameTemplate is a recurring game, e.g. a weekly exhibition night. The template
+// scheduler materializes its occurrences as Games a few weeks ahead; each game keeps
+// TemplateID and its OccurrenceDate. A side with neither TeamID nor PlayerID is
+// "to be drawn" and its participant is assigned on the game itself.
+type GameTemplate struct {
+	ID   int64  `gorm:"primaryKey"`
+	Name string `gorm:"type:varchar(64);not null"`
+
+	// Nullable: if NULL, occurrences are exhibition games.
+	SeasonID *int64 `gorm:"index;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+
+	MatchType    string      `gorm:"type:varchar(16);not null;default:players"` // "teams" | "players"; fixed once created
+	TargetPoints int         `gorm:"not null;default:100"`
+	Timezone     string      `gorm:"not null;default:America/New_York"`
+	ScoringMode  ScoringMode `gorm:"type:varchar(16);not null;default:first_to"`
+	ColorPolicy  ColorPolicy `gorm:"type:varchar(16);not null;default:natural"`
+	// Where the settings above came from. Only "request" settings are fixed; the
+	// others are inherited again for each occurrence as it is materialized.
+	Provenance GameProvenance `gorm:"type:jsonb"`
+
+	VenueID     *int64 `gorm:"index;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+	Location    *string
+	Description *string
+
+	// Recurrence is an RRULE subset, e.g. "FREQ=WEEKLY;BYDAY=TU" (see ical.ParseRRule).
+	// Occurrences start at StartTime ("HH:MM", local to Timezone) from StartsOn
+	// until EndsOn, if set.
+	Recurrence string     `gorm:"type:varchar(255);not null"`
+	StartTime  string     `gorm:"type:varchar(5);not null"`
+	StartsOn   time.Time  `gorm:"type:date;not null"`
+	EndsOn     *time.Time `gorm:"type:date"`
+
+	// Participants; nil on both IDs of a side means "to be drawn".
+	SideATeamID   *int64
+	SideAPlayerID *int64
+	SideBTeamID   *int64
+	SideBPlayerID *int64
+
+	// Inactive templates keep their past games but materialize nothing new.
+	Active bool `gorm:"not null;default:true"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
@@ -8,8 +8,13 @@ import (
 
 // League is an organizational concept
 type League struct {
-	ID        int64  `gorm:"primaryKey"`
-	Name      string `gorm:"not null;index;"`
+	ID   int64  `gorm:"primaryKey"`
+	Name string `gorm:"not null;index;"`
+
+	// Defaults for new seasons (Timezone) and, below any season default, for new games.
+	Timezone *string
+	Defaults GameDefaults `gorm:"embedded;embeddedPrefix:default_"`
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
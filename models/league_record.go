@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// RecordType enumerates the all-time highs tracked per league.
+type RecordType string
+
+const (
+	RecordBiggestMargin    RecordType = "biggest_margin"     // winning side's points minus the loser's
+	RecordHighestScore     RecordType = "highest_score"      // one side's points in a game
+	RecordMostTwenties     RecordType = "most_twenties_game" // one side's twenties in a game
+	RecordLongestWinStreak RecordType = "longest_win_streak" // consecutive player wins across the league's seasons
+	RecordMostGamesPlayed  RecordType = "most_games_played"  // player's completed games across the league's seasons
+)
+
+// LeagueRecord is one holder of a league record. The current holder has a nil
+// SupersededAt; earlier holders are kept as history.
+// Side records are held by a team (teams format) or player (players format);
+// streak and games-played records are always held by a player.
+type LeagueRecord struct {
+	ID       int64      `gorm:"primaryKey"`
+	LeagueID int64      `gorm:"not null;index:idx_league_record,priority:1;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	Record   RecordType `gorm:"type:varchar(32);not null;index:idx_league_record,priority:2"`
+
+	Value    int64  `gorm:"not null"`
+	PlayerID *int64 `gorm:"index"`
+	TeamID   *int64 `gorm:"index"`
+
+	// The record-setting game (for streaks/games played: the game that reached the value).
+	GameID *int64 `gorm:"index;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+	SetAt  *time.Time
+
+	SupersededAt *time.Time `gorm:"index"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Matchday is one round of a season (a "week"); games attach via Game.MatchdayID.
+// Matchdays are hard-deleted so a number can be reused.
+type Matchday struct {
+	ID       int64     `gorm:"primaryKey"`
+	SeasonID int64     `gorm:"not null;uniqueIndex:uniq_season_matchday,priority:1;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	Number   int       `gorm:"not null;uniqueIndex:uniq_season_matchday,priority:2"` // 1-based order within the season
+	Date     time.Time `gorm:"type:date;not null"`
+	Name     *string   `gorm:"type:varchar(64)"`
+	VenueID  *int64    `gorm:"index;constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
+
+	// Draft matchdays are hidden from anonymous readers until published with their games.
+	Draft bool `gorm:"not null;default:false"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// PlayerGameResult is a denormalized row per (player, completed game).
+// Team games expand to one row for each of the team's two players.
+// Rows are maintained by the repositories whenever a game or its sides change;
+// never write to this table directly.
+type PlayerGameResult struct {
+	ID int64 `gorm:"primaryKey"`
+
+	PlayerID int64  `gorm:"not null;index:idx_pgr_player_ended,priority:1"`
+	GameID   int64  `gorm:"not null;index;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	SeasonID *int64 `gorm:"index"`
+	TeamID   *int64 `gorm:"index"` // nil for players-format games
+
+	MatchType string    `gorm:"type:varchar(16);not null"`
+	Side      string    `gorm:"type:char(1);not null"` // "A" | "B"
+	Color     DiscColor `gorm:"type:varchar(16);not null"`
+
+	PointsFor     int `gorm:"not null;default:0"`
+	PointsAgainst int `gorm:"not null;default:0"`
+	Twenties      int `gorm:"not null;default:0"`
+
+	WinnerSide *string `gorm:"type:char(1)"`
+	Result     string  `gorm:"type:char(1);not null"` // "W" | "L" | "T"
+
+	Location *string
+	EndedAt  *time.Time `gorm:"index:idx_pgr_player_ended,priority:2"`
+
+	CreatedAt time.Time
+}
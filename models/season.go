@@ -17,11 +17,40 @@ type Season struct {
 
 	// IANA TZ for scheduling (e.g., "America/New_York"). Defaults to America/New_York.
 	Timezone string `gorm:"not null;default:America/New_York"`
+	// TimezoneInherited marks a Timezone copied from the league (or the built-in
+	// default) rather than set on the season; it follows later league changes.
+	TimezoneInherited bool `gorm:"not null;default:false"`
 
 	// Human-readable context.
 	Description *string
 
+	// Defaults for new games, overriding the league's.
+	Defaults GameDefaults `gorm:"embedded;embeddedPrefix:default_"`
+
+	// Ordered, comma-separated standings tiebreakers (see Tiebreaker).
+	Tiebreakers string `gorm:"type:varchar(255);not null;default:'wins,point_diff,points_for'"`
+	// Seed for the coin_flip tiebreaker so a given season always flips the same way.
+	TiebreakerSeed int64 `gorm:"not null;default:0"`
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
+
+// Tiebreaker enumerates the criteria a season may use to order its standings.
+type Tiebreaker string
+
+const (
+	TiebreakWins               Tiebreaker = "wins"
+	TiebreakWinPct             Tiebreaker = "win_pct"
+	TiebreakHeadToHead         Tiebreaker = "head_to_head"
+	TiebreakPointDiff          Tiebreaker = "point_diff"
+	TiebreakPointsFor          Tiebreaker = "points_for"
+	TiebreakStrengthOfSchedule Tiebreaker = "strength_of_schedule"
+	TiebreakOppOppWinPct       Tiebreaker = "opp_opp_win_pct"
+	TiebreakAdjustedPointDiff  Tiebreaker = "adjusted_point_diff"
+	TiebreakCoinFlip           Tiebreaker = "coin_flip"
+)
+
+// DefaultTiebreakers matches the historical standings order.
+const DefaultTiebreakers = "wins,point_diff,points_for"
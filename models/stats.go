@@ -40,3 +40,85 @@ type TeamStatsRow struct {
 	BestLocation     *string `json:"bestLocation"`     // where they’ve won the most
 	BestLocationWins int64   `json:"bestLocationWins"` // wins at that location
 }
+
+// Strength of schedule and opponent-adjusted margin over a season's completed games.
+type ScheduleStrength struct {
+	OppWinPct         float64 `json:"oppWinPct"`         // average win% of opponents faced (SOS)
+	OppOppWinPct      float64 `json:"oppOppWinPct"`      // average OppWinPct of opponents faced
+	AdjustedPointDiff float64 `json:"adjustedPointDiff"` // per game: margin plus the opponent's average margin
+}
+
+// One season's slice of a player's career. SeasonID is nil for exhibition games.
+type PlayerSeasonStatsRow struct {
+	SeasonID   *int64  `json:"seasonId"`
+	SeasonName *string `json:"seasonName"`
+	LeagueID   *int64  `json:"leagueId"`
+
+	PlayerStatsRow
+}
+
+// Career stats for a player across every season, league and exhibition.
+type PlayerCareerStats struct {
+	PlayerStatsRow
+
+	Seasons []PlayerSeasonStatsRow `json:"seasons"`
+}
+
+// One season's slice of a team's career. SeasonID is nil for exhibition games.
+type TeamSeasonStatsRow struct {
+	SeasonID   *int64  `json:"seasonId"`
+	SeasonName *string `json:"seasonName"`
+	LeagueID   *int64  `json:"leagueId"`
+
+	TeamStatsRow
+}
+
+// Career stats for a team across every season, league and exhibition.
+type TeamCareerStats struct {
+	TeamStatsRow
+
+	Seasons []TeamSeasonStatsRow `json:"seasons"`
+}
+
+// Streaks and recent form for a player or team, computed from completed games ordered by EndedAt.
+type FormGuide struct {
+	Games             int64  `json:"games"`
+	CurrentStreak     string `json:"currentStreak"` // e.g. "W3", "L1", "T1"; empty when no games
+	LongestWinStreak  int64  `json:"longestWinStreak"`
+	LongestLossStreak int64  `json:"longestLossStreak"`
+	Last10            string `json:"last10"` // oldest to newest, e.g. "WWLWT"
+}
+
+// A player's record alongside one doubles partner, across every team they formed together.
+type PartnerStatsRow struct {
+	PartnerID int64 `json:"partnerId"`
+
+	Games  int64   `json:"games"`
+	Wins   int64   `json:"wins"`
+	Losses int64   `json:"losses"`
+	WinPct float64 `json:"winPct"`
+
+	PointsFor     int64 `json:"pointsFor"`
+	PointsAgainst int64 `json:"pointsAgainst"`
+	PointDiff     int64 `json:"pointDiff"`
+
+	Twenties int64 `json:"twenties"`
+}
+
+// A doubles pairing on the league-wide leaderboard. PlayerAID < PlayerBID.
+type PairingStatsRow struct {
+	PlayerAID int64 `json:"playerAId"`
+	PlayerBID int64 `json:"playerBId"`
+	TeamID    int64 `json:"teamId"` // lowest team id for the pair
+
+	Games  int64   `json:"games"`
+	Wins   int64   `json:"wins"`
+	Losses int64   `json:"losses"`
+	WinPct float64 `json:"winPct"`
+
+	PointsFor     int64 `json:"pointsFor"`
+	PointsAgainst int64 `json:"pointsAgainst"`
+	PointDiff     int64 `json:"pointDiff"`
+
+	Twenties int64 `json:"twenties"`
+}
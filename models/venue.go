@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Venue is a physical place games are played. Names are unique case-insensitively
+// (enforced in the service layer).
+type Venue struct {
+	ID         int64   `gorm:"primaryKey"`
+	Name       string  `gorm:"not null;index"`
+	Address    *string `gorm:"type:text"`
+	Timezone   string  `gorm:"not null;default:America/New_York"`
+	BoardCount int     `gorm:"not null;default:1"` // kept in sync with the venue's Board rows
+
+	Boards []Board `gorm:"foreignKey:VenueID"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// Board is one playing surface at a venue; at most one game may occupy it at a time.
+type Board struct {
+	ID      int64   `gorm:"primaryKey"`
+	VenueID int64   `gorm:"not null;uniqueIndex:uniq_venue_board;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	Number  int     `gorm:"not null;uniqueIndex:uniq_venue_board"` // 1..Venue.BoardCount
+	Name    *string `gorm:"type:varchar(64)"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
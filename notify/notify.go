@@ -0,0 +1,72 @@
+// Package notify delivers change notifications, such as a schedule being published,
+// to whoever is listening: the log by default, or a webhook when one is configured.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// EventSchedulePublished is sent when draft games and/or matchdays are published.
+const EventSchedulePublished = "schedule.published"
+
+// Event describes one change. IDs are those of the records the change touched.
+type Event struct {
+	Type        string    `json:"type"`
+	SeasonID    *int64    `json:"seasonId,omitempty"`
+	MatchdayIDs []int64   `json:"matchdayIds"`
+	GameIDs     []int64   `json:"gameIds"`
+	At          time.Time `json:"at"`
+}
+
+// Notifier delivers events. Notify must not block the caller on delivery.
+type Notifier interface {
+	Notify(ctx context.Context, e Event)
+}
+
+// Log writes each event to the structured log.
+type Log struct{}
+
+func (Log) Notify(_ context.Context, e Event) {
+	slog.Info("Change notification",
+		"type", e.Type,
+		"seasonId", e.SeasonID,
+		"matchdays", len(e.MatchdayIDs),
+		"games", len(e.GameIDs),
+	)
+}
+
+// Webhook logs each event and POSTs it as JSON to URL in the background.
+// Delivery is best effort: failures are logged, not retried.
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhook(url string) *Webhook {
+	return &Webhook{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *Webhook) Notify(ctx context.Context, e Event) {
+	Log{}.Notify(ctx, e)
+	body, err := json.Marshal(e)
+	if err != nil {
+		slog.Error("Failed to encode notification", "type", e.Type, "err", err)
+		return
+	}
+	go func() {
+		resp, err := w.Client.Post(w.URL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			slog.Error("Failed to deliver notification", "type", e.Type, "err", err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			slog.Error("Notification webhook rejected event", "type", e.Type, "status", resp.StatusCode)
+		}
+	}()
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+)
+
+type AvailabilityRepository struct {
+	db *gorm.DB
+}
+
+func NewAvailabilityRepository(db *gorm.DB) *AvailabilityRepository {
+	return &AvailabilityRepository{db: db}
+}
+
+type ListAvailabilityFilter struct {
+	SeasonID int64
+	PlayerID *int64
+	TeamID   *int64
+	Kind     *models.AvailabilityKind
+}
+
+func (r *AvailabilityRepository) Create(ctx context.Context, a *models.Availability) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *AvailabilityRepository) GetByID(ctx context.Context, id int64) (*models.Availability, error) {
+	var a models.Availability
+	if err := r.db.WithContext(ctx).First(&a, id).Error; err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *AvailabilityRepository) DeleteByID(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Delete(&models.Availability{}, id).Error
+}
+
+// List returns the season's constraints, optionally for one player or team.
+func (r *AvailabilityRepository) List(ctx context.Context, f ListAvailabilityFilter) ([]models.Availability, error) {
+	q := r.db.WithContext(ctx).Where("season_id = ?", f.SeasonID)
+	if f.PlayerID != nil {
+		q = q.Where("player_id = ?", *f.PlayerID)
+	}
+	if f.TeamID != nil {
+		q = q.Where("team_id = ?", *f.TeamID)
+	}
+	if f.Kind != nil {
+		q = q.Where("kind = ?", *f.Kind)
+	}
+	var rows []models.Availability
+	if err := q.Order("kind asc, starts_on asc nulls last, weekday asc nulls last, id asc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+)
+
+type AwardRepository struct {
+	db *gorm.DB
+}
+
+func NewAwardRepository(db *gorm.DB) *AwardRepository {
+	return &AwardRepository{db: db}
+}
+
+func (r *AwardRepository) ListSettings(ctx context.Context, seasonID int64) ([]models.SeasonAwardSetting, error) {
+	var rows []models.SeasonAwardSetting
+	if err := r.db.WithContext(ctx).
+		Where("season_id = ?", seasonID).
+		Order("award asc").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// UpsertSettings writes every setting in one transaction, keyed by (season, award).
+func (r *AwardRepository) UpsertSettings(ctx context.Context, settings []models.SeasonAwardSetting) error {
+	if len(settings) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "season_id"}, {Name: "award"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "min_games", "updated_at"}),
+	}).Create(&settings).Error
+}
+
+func (r *AwardRepository) ListBySeason(ctx context.Context, seasonID int64) ([]models.SeasonAward, error) {
+	var rows []models.SeasonAward
+	if err := r.db.WithContext(ctx).
+		Where("season_id = ?", seasonID).
+		Order("award asc").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ListByPlayer returns every locked award the player won, alone or as a partner.
+func (r *AwardRepository) ListByPlayer(ctx context.Context, playerID int64) ([]models.SeasonAward, error) {
+	var rows []models.SeasonAward
+	if err := r.db.WithContext(ctx).
+		Where("player_id = ? OR partner_id = ?", playerID, playerID).
+		Order("season_id desc, award asc").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Lock inserts or replaces the season's winner for a.Award.
+func (r *AwardRepository) Lock(ctx context.Context, a *models.SeasonAward) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "season_id"}, {Name: "award"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"player_id", "partner_id", "value", "games", "locked_by", "locked_at", "updated_at",
+		}),
+	}).Create(a).Error
+}
+
+func (r *AwardRepository) Unlock(ctx context.Context, seasonID int64, award models.AwardType) error {
+	return r.db.WithContext(ctx).
+		Where("season_id = ? AND award = ?", seasonID, award).
+		Delete(&models.SeasonAward{}).Error
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
@@ -24,8 +25,11 @@ type ListGamesFilter struct {
 	MatchType      *string    // "teams" | "players"
 	ScheduledFrom  *time.Time // filter by scheduled_at >=
 	ScheduledTo    *time.Time // filter by scheduled_at <=
+	ScheduledUntil *time.Time // filter by scheduled_at < (an exclusive end, e.g. the next local midnight)
 	TeamID         *int64     // any game where a side has this team_id
 	PlayerID       *int64     // any game where a side has this player_id
+	TemplateID     *int64     // games materialized from this recurring template
+	PublishedOnly  bool       // hide draft games
 	Offset         int
 	Limit          int
 	OrderBy        string // e.g., "scheduled_at desc", defaults to "games.id desc"
@@ -59,18 +63,28 @@ func (r *GameRepository) GetWithSides(ctx context.Context, id int64) (*models.Ga
 	return g, sides, nil
 }
 
+// UpdateFields updates the game and refreshes its player_game_results rows in one transaction.
 func (r *GameRepository) UpdateFields(ctx context.Context, id int64, fields map[string]any) (*models.Game, error) {
-	if err := r.db.WithContext(ctx).
-		Model(&models.Game{}).
-		Where("id = ?", id).
-		Updates(fields).Error; err != nil {
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Game{}).
+			Where("id = ?", id).
+			Updates(fields).Error; err != nil {
+			return err
+		}
+		return syncGameResults(tx, id)
+	}); err != nil {
 		return nil, err
 	}
 	return r.GetByID(ctx, id)
 }
 
 func (r *GameRepository) DeleteByID(ctx context.Context, id int64) error {
-	return r.db.WithContext(ctx).Delete(&models.Game{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Game{}, id).Error; err != nil {
+			return err
+		}
+		return syncGameResults(tx, id)
+	})
 }
 
 // CreateWithSides runs in a single transaction: creates the game and two sides.
@@ -85,7 +99,7 @@ func (r *GameRepository) CreateWithSides(ctx context.Context, g *models.Game, si
 		if err := tx.Create(&sides).Error; err != nil {
 			return err
 		}
-		return nil
+		return syncGameResults(tx, g.ID)
 	})
 }
 
@@ -97,37 +111,8 @@ func (r *GameRepository) List(ctx context.Context, f ListGamesFilter) ([]models.
 
 	// Base builder
 	base := r.db.WithContext(ctx).Model(&models.Game{}).Where("games.deleted_at IS NULL")
-
-	apply := func(q *gorm.DB) *gorm.DB {
-		if f.SeasonID != nil {
-			q = q.Where("games.season_id = ?", *f.SeasonID)
-		} else if f.ExhibitionOnly != nil && *f.ExhibitionOnly {
-			q = q.Where("games.season_id IS NULL")
-		}
-		if len(f.Status) > 0 {
-			q = q.Where("games.status IN ?", f.Status)
-		}
-		if f.MatchType != nil && *f.MatchType != "" {
-			q = q.Where("games.match_type = ?", *f.MatchType)
-		}
-		if f.ScheduledFrom != nil {
-			q = q.Where("games.scheduled_at >= ?", *f.ScheduledFrom)
-		}
-		if f.ScheduledTo != nil {
-			q = q.Where("games.scheduled_at <= ?", *f.ScheduledTo)
-		}
-		// Participant filters via joins
-		if f.TeamID != nil && *f.TeamID > 0 {
-			q = q.Joins(`JOIN game_sides gs_t ON gs_t.game_id = games.id AND gs_t.team_id = ? AND gs_t.deleted_at IS NULL`, *f.TeamID)
-		}
-		if f.PlayerID != nil && *f.PlayerID > 0 {
-			q = q.Joins(`JOIN game_sides gs_p ON gs_p.game_id = games.id AND gs_p.player_id = ? AND gs_p.deleted_at IS NULL`, *f.PlayerID)
-		}
-		return q
-	}
-
 	// Count with DISTINCT id (avoid join duplicates)
-	countQ := apply(base.Session(&gorm.Session{}))
+	countQ := f.apply(base.Session(&gorm.Session{}))
 	if err := countQ.Distinct("games.id").Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
@@ -147,7 +132,7 @@ func (r *GameRepository) List(ctx context.Context, f ListGamesFilter) ([]models.
 	}
 
 	// Items query (DISTINCT over full row)
-	itemsQ := apply(base.Session(&gorm.Session{}))
+	itemsQ := f.apply(base.Session(&gorm.Session{}))
 	if err := itemsQ.
 		Select("games.*").
 		Distinct().
@@ -161,16 +146,231 @@ func (r *GameRepository) List(ctx context.Context, f ListGamesFilter) ([]models.
 	return items, total, nil
 }
 
+// ListAll returns every game matching f, unpaged, soonest scheduled first.
+// Offset, Limit and OrderBy are ignored.
+func (r *GameRepository) ListAll(ctx context.Context, f ListGamesFilter) ([]models.Game, error) {
+	var items []models.Game
+	q := f.apply(r.db.WithContext(ctx).Model(&models.Game{}).Where("games.deleted_at IS NULL"))
+	if err := q.
+		Select("games.*").
+		Distinct().
+		Order("games.scheduled_at asc nulls last, games.id asc").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// apply adds the filter's predicates and participant joins to q.
+func (f ListGamesFilter) apply(q *gorm.DB) *gorm.DB {
+	if f.SeasonID != nil {
+		q = q.Where("games.season_id = ?", *f.SeasonID)
+	} else if f.ExhibitionOnly != nil && *f.ExhibitionOnly {
+		q = q.Where("games.season_id IS NULL")
+	}
+	if len(f.Status) > 0 {
+		q = q.Where("games.status IN ?", f.Status)
+	}
+	if f.MatchType != nil && *f.MatchType != "" {
+		q = q.Where("games.match_type = ?", *f.MatchType)
+	}
+	if f.ScheduledFrom != nil {
+		q = q.Where("games.scheduled_at >= ?", *f.ScheduledFrom)
+	}
+	if f.ScheduledTo != nil {
+		q = q.Where("games.scheduled_at <= ?", *f.ScheduledTo)
+	}
+	if f.ScheduledUntil != nil {
+		q = q.Where("games.scheduled_at < ?", *f.ScheduledUntil)
+	}
+	if f.PublishedOnly {
+		q = q.Where("games.draft = ?", false)
+	}
+	if f.TemplateID != nil {
+		q = q.Where("games.template_id = ?", *f.TemplateID)
+	}
+	// Participant filters via joins
+	if f.TeamID != nil && *f.TeamID > 0 {
+		q = q.Joins(`JOIN game_sides gs_t ON gs_t.game_id = games.id AND gs_t.team_id = ? AND gs_t.deleted_at IS NULL`, *f.TeamID)
+	}
+	if f.PlayerID != nil && *f.PlayerID > 0 {
+		q = q.Joins(`JOIN game_sides gs_p ON gs_p.game_id = games.id AND gs_p.player_id = ? AND gs_p.deleted_at IS NULL`, *f.PlayerID)
+	}
+	return q
+}
+
+// ListScheduledInSeason returns the season's scheduled games that have a ScheduledAt,
+// soonest first; drafts only when includeDrafts is set.
+func (r *GameRepository) ListScheduledInSeason(ctx context.Context, seasonID int64, includeDrafts bool) ([]models.Game, error) {
+	q := r.db.WithContext(ctx).
+		Where("season_id = ? AND status = ? AND scheduled_at IS NOT NULL", seasonID, "scheduled")
+	if !includeDrafts {
+		q = q.Where("draft = ?", false)
+	}
+	var items []models.Game
+	if err := q.
+		Order("scheduled_at asc, id asc").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// CalendarFilter selects the games of one feed; set exactly one field.
+type CalendarFilter struct {
+	SeasonID *int64
+	TeamID   *int64
+	PlayerID *int64 // direct player sides and sides of teams the player belongs to
+}
+
+// ListForCalendar returns every published game with a ScheduledAt matching f, in any
+// status, soonest first. Feeds are anonymous, so drafts never appear.
+func (r *GameRepository) ListForCalendar(ctx context.Context, f CalendarFilter) ([]models.Game, error) {
+	q := r.db.WithContext(ctx).Where("scheduled_at IS NOT NULL AND draft = ?", false)
+	switch {
+	case f.SeasonID != nil:
+		q = q.Where("season_id = ?", *f.SeasonID)
+	case f.TeamID != nil:
+		q = q.Where(`EXISTS (
+  SELECT 1 FROM game_sides gs
+  WHERE gs.game_id = games.id AND gs.deleted_at IS NULL AND gs.team_id = ?)`, *f.TeamID)
+	case f.PlayerID != nil:
+		q = q.Where(`EXISTS (
+  SELECT 1 FROM game_sides gs
+  LEFT JOIN teams t ON t.id = gs.team_id
+  WHERE gs.game_id = games.id AND gs.deleted_at IS NULL
+    AND (gs.player_id = @p OR t.player_a_id = @p OR t.player_b_id = @p))`, map[string]any{"p": *f.PlayerID})
+	}
+
+	var items []models.Game
+	if err := q.Order("scheduled_at asc, id asc").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ListStaleInProgress returns the season's games still in_progress that started before
+// startedBefore, oldest first.
+func (r *GameRepository) ListStaleInProgress(ctx context.Context, seasonID int64, startedBefore time.Time) ([]models.Game, error) {
+	var items []models.Game
+	if err := r.db.WithContext(ctx).
+		Where("season_id = ? AND status = ? AND started_at < ?", seasonID, "in_progress", startedBefore).
+		Order("started_at asc, id asc").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// PlayerBookingRow is one player's seat in a scheduled or in-progress game.
+// StartAt is started_at once the game is underway, else scheduled_at.
+type PlayerBookingRow struct {
+	GameID   int64     `gorm:"column:game_id"`
+	PlayerID int64     `gorm:"column:player_id"`
+	Status   string    `gorm:"column:status"`
+	StartAt  time.Time `gorm:"column:start_at"`
+	Draft    bool      `gorm:"column:draft"`
+}
+
+// ListPlayerBookings returns the scheduled/in_progress games, other than excludeGameID,
+// that seat any of playerIDs (team sides expanded to both players) and start before
+// `to`. Scheduled games must also start after `from`; in-progress games are returned
+// regardless, since they may have overrun. Callers refine the overlap.
+func (r *GameRepository) ListPlayerBookings(ctx context.Context, playerIDs []int64, from, to time.Time, excludeGameID int64) ([]PlayerBookingRow, error) {
+	if len(playerIDs) == 0 {
+		return nil, nil
+	}
+	const q = `
+SELECT DISTINCT g.id AS game_id, p.player_id, g.status,
+       COALESCE(g.started_at, g.scheduled_at) AS start_at, g.draft
+FROM games g
+JOIN game_sides gs ON gs.game_id = g.id AND gs.deleted_at IS NULL
+LEFT JOIN teams t ON t.id = gs.team_id
+CROSS JOIN LATERAL (VALUES (gs.player_id), (t.player_a_id), (t.player_b_id)) AS p(player_id)
+WHERE g.deleted_at IS NULL
+  AND g.id <> @exclude
+  AND g.status IN ('scheduled', 'in_progress')
+  AND p.player_id IN @players
+  AND COALESCE(g.started_at, g.scheduled_at) < @to
+  AND (g.status = 'in_progress' OR COALESCE(g.started_at, g.scheduled_at) > @from)
+ORDER BY start_at, g.id, p.player_id`
+
+	var rows []PlayerBookingRow
+	err := r.db.WithContext(ctx).Raw(q, map[string]any{
+		"players": playerIDs,
+		"exclude": excludeGameID,
+		"from":    from,
+		"to":      to,
+	}).Scan(&rows).Error
+	return rows, err
+}
+
 func (r *GameRepository) UpdateSideColor(
 	ctx context.Context,
 	gameID int64,
 	side string, // "A" or "B"
 	color models.DiscColor,
 ) error {
-	return r.db.WithContext(ctx).
-		Model(&models.GameSide{}).
-		Where("game_id = ? AND side = ?", gameID, side).
-		Updates(map[string]any{
-			"color": color,
-		}).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.GameSide{}).
+			Where("game_id = ? AND side = ?", gameID, side).
+			Updates(map[string]any{
+				"color": color,
+			}).Error; err != nil {
+			return err
+		}
+		return syncGameResults(tx, gameID)
+	})
+}
+
+// PublishFilter selects what PublishDrafts publishes; set exactly one field.
+type PublishFilter struct {
+	GameID     *int64
+	MatchdayID *int64 // the matchday and its games
+	SeasonID   *int64 // every matchday and game in the season
+}
+
+// PublishDrafts clears the draft flag on the matchdays and games selected by f in one
+// transaction and returns the IDs it published.
+func (r *GameRepository) PublishDrafts(ctx context.Context, f PublishFilter) (matchdayIDs, gameIDs []int64, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		mq := tx.Model(&models.Matchday{}).Where("draft = ?", true)
+		gq := tx.Model(&models.Game{}).Where("draft = ?", true)
+		switch {
+		case f.GameID != nil:
+			mq = nil
+			gq = gq.Where("id = ?", *f.GameID)
+		case f.MatchdayID != nil:
+			mq = mq.Where("id = ?", *f.MatchdayID)
+			gq = gq.Where("matchday_id = ?", *f.MatchdayID)
+		case f.SeasonID != nil:
+			mq = mq.Where("season_id = ?", *f.SeasonID)
+			gq = gq.Where("season_id = ?", *f.SeasonID)
+		default:
+			return errors.New("publish filter is empty")
+		}
+
+		if mq != nil {
+			if err := mq.Order("id").Pluck("id", &matchdayIDs).Error; err != nil {
+				return err
+			}
+			if len(matchdayIDs) > 0 {
+				if err := tx.Model(&models.Matchday{}).
+					Where("id IN ?", matchdayIDs).
+					Update("draft", false).Error; err != nil {
+					return err
+				}
+			}
+		}
+		if err := gq.Order("id").Pluck("id", &gameIDs).Error; err != nil {
+			return err
+		}
+		if len(gameIDs) > 0 {
+			return tx.Model(&models.Game{}).
+				Where("id IN ?", gameIDs).
+				Update("draft", false).Error
+		}
+		return nil
+	})
+	return matchdayIDs, gameIDs, err
 }
@@ -26,14 +26,24 @@ type ListGameSidesFilter struct {
 }
 
 func (r *GameSideRepository) Create(ctx context.Context, s *models.GameSide) error {
-	return r.db.WithContext(ctx).Create(s).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(s).Error; err != nil {
+			return err
+		}
+		return syncGameResults(tx, s.GameID)
+	})
 }
 
 func (r *GameSideRepository) BulkCreate(ctx context.Context, sides []models.GameSide) error {
 	if len(sides) == 0 {
 		return nil
 	}
-	return r.db.WithContext(ctx).Create(&sides).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&sides).Error; err != nil {
+			return err
+		}
+		return syncSidesGameResults(tx, sides)
+	})
 }
 
 func (r *GameSideRepository) GetByID(ctx context.Context, id int64) (*models.GameSide, error) {
@@ -65,34 +75,88 @@ func (r *GameSideRepository) ListByGame(ctx context.Context, gameID int64) ([]mo
 	return sides, nil
 }
 
-func (r *GameSideRepository) UpdateFields(ctx context.Context, id int64, fields map[string]any) (*models.GameSide, error) {
+// ListByGames returns the sides of every listed game, ordered by game then side.
+func (r *GameSideRepository) ListByGames(ctx context.Context, gameIDs []int64) ([]models.GameSide, error) {
+	var sides []models.GameSide
+	if len(gameIDs) == 0 {
+		return sides, nil
+	}
 	if err := r.db.WithContext(ctx).
-		Model(&models.GameSide{}).
-		Where("id = ?", id).
-		Updates(fields).Error; err != nil {
+		Where("game_id IN ? AND deleted_at IS NULL", gameIDs).
+		Order("game_id asc, side asc").
+		Find(&sides).Error; err != nil {
+		return nil, err
+	}
+	return sides, nil
+}
+
+func (r *GameSideRepository) UpdateFields(ctx context.Context, id int64, fields map[string]any) (*models.GameSide, error) {
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.GameSide{}).
+			Where("id = ?", id).
+			Updates(fields).Error; err != nil {
+			return err
+		}
+		return syncSideGameResults(tx, id)
+	}); err != nil {
 		return nil, err
 	}
 	return r.GetByID(ctx, id)
 }
 
 func (r *GameSideRepository) UpdateFieldsByGameAndSide(ctx context.Context, gameID int64, side string, fields map[string]any) (*models.GameSide, error) {
-	if err := r.db.WithContext(ctx).
-		Model(&models.GameSide{}).
-		Where("game_id = ? AND side = ?", gameID, side).
-		Updates(fields).Error; err != nil {
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.GameSide{}).
+			Where("game_id = ? AND side = ?", gameID, side).
+			Updates(fields).Error; err != nil {
+			return err
+		}
+		return syncGameResults(tx, gameID)
+	}); err != nil {
 		return nil, err
 	}
 	return r.GetByGameAndSide(ctx, gameID, side)
 }
 
 func (r *GameSideRepository) DeleteByID(ctx context.Context, id int64) error {
-	return r.db.WithContext(ctx).Delete(&models.GameSide{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.GameSide{}, id).Error; err != nil {
+			return err
+		}
+		return syncSideGameResults(tx, id)
+	})
 }
 
 func (r *GameSideRepository) DeleteByGame(ctx context.Context, gameID int64) error {
-	return r.db.WithContext(ctx).
-		Where("game_id = ?", gameID).
-		Delete(&models.GameSide{}).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("game_id = ?", gameID).
+			Delete(&models.GameSide{}).Error; err != nil {
+			return err
+		}
+		return syncGameResults(tx, gameID)
+	})
+}
+
+// syncSideGameResults refreshes the game owning side id (soft-deleted sides included).
+func syncSideGameResults(tx *gorm.DB, id int64) error {
+	return syncPlayerGameResults(tx,
+		"g.id IN (SELECT game_id FROM game_sides WHERE id = @sideID)",
+		map[string]any{"sideID": id},
+	)
+}
+
+func syncSidesGameResults(tx *gorm.DB, sides []models.GameSide) error {
+	seen := map[int64]bool{}
+	for _, s := range sides {
+		if seen[s.GameID] {
+			continue
+		}
+		seen[s.GameID] = true
+		if err := syncGameResults(tx, s.GameID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *GameSideRepository) List(ctx context.Context, f ListGameSidesFilter) ([]models.GameSide, int64, error) {
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+)
+
+type GameTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewGameTemplateRepository(db *gorm.DB) *GameTemplateRepository {
+	return &GameTemplateRepository{db: db}
+}
+
+type ListGameTemplatesFilter struct {
+	SeasonID   *int64
+	ActiveOnly bool
+	Offset     int
+	Limit      int
+}
+
+func (r *GameTemplateRepository) Create(ctx context.Context, t *models.GameTemplate) error {
+	return r.db.WithContext(ctx).Create(t).Error
+}
+
+func (r *GameTemplateRepository) GetByID(ctx context.Context, id int64) (*models.GameTemplate, error) {
+	var t models.GameTemplate
+	if err := r.db.WithContext(ctx).First(&t, id).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *GameTemplateRepository) UpdateFields(ctx context.Context, id int64, fields map[string]any) (*models.GameTemplate, error) {
+	if err := r.db.WithContext(ctx).
+		Model(&models.GameTemplate{}).
+		Where("id = ?", id).
+		Updates(fields).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+func (r *GameTemplateRepository) DeleteByID(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Delete(&models.GameTemplate{}, id).Error
+}
+
+func (r *GameTemplateRepository) List(ctx context.Context, f ListGameTemplatesFilter) ([]models.GameTemplate, int64, error) {
+	var (
+		items []models.GameTemplate
+		total int64
+	)
+
+	q := r.db.WithContext(ctx).Model(&models.GameTemplate{}).Where("deleted_at IS NULL")
+	if f.SeasonID != nil {
+		q = q.Where("season_id = ?", *f.SeasonID)
+	}
+	if f.ActiveOnly {
+		q = q.Where("active = ?", true)
+	}
+
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if f.Limit <= 0 || f.Limit > 100 {
+		f.Limit = 25
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+
+	if err := q.Order("name ASC, id ASC").Limit(f.Limit).Offset(f.Offset).Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// ListActive returns every active template, for the scheduler.
+func (r *GameTemplateRepository) ListActive(ctx context.Context) ([]models.GameTemplate, error) {
+	var items []models.GameTemplate
+	if err := r.db.WithContext(ctx).
+		Where("active = ?", true).
+		Order("id asc").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ListOccurrences returns the template's games dated on or after from, soft-deleted
+// ones included: a deleted occurrence marks a date the scheduler must not refill.
+func (r *GameTemplateRepository) ListOccurrences(ctx context.Context, templateID int64, from time.Time) ([]models.Game, error) {
+	var items []models.Game
+	if err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("template_id = ? AND occurrence_date >= ?", templateID, from).
+		Order("occurrence_date asc, id asc").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// PurgeOccurrences hard-deletes games (their sides cascade) that the template no
+// longer produces, so the dates are free to be materialized again later.
+func (r *GameTemplateRepository) PurgeOccurrences(ctx context.Context, gameIDs []int64) error {
+	if len(gameIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Unscoped().Delete(&models.Game{}, gameIDs).Error
+}
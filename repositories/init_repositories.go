@@ -1,30 +1,63 @@
 package repositories
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 )
 
 func InitializeRepositories(db *gorm.DB) (*RepositoriesCollection, error) {
 
 	return &RepositoriesCollection{
-		UserRepo:       NewUserRepository(db),
-		PlayerRepo:     NewPlayerRepository(db),
-		LeagueRepo:     NewLeagueRepository(db),
-		SeasonRepo:     NewSeasonRepository(db),
-		TeamRepo:       NewTeamRepository(db),
-		TeamSeasonRepo: NewTeamSeasonRepository(db),
-		GameRepo:       NewGameRepository(db),
-		GameSideRepo:   NewGameSideRepository(db),
+		db:               db,
+		UserRepo:         NewUserRepository(db),
+		PlayerRepo:       NewPlayerRepository(db),
+		LeagueRepo:       NewLeagueRepository(db),
+		SeasonRepo:       NewSeasonRepository(db),
+		TeamRepo:         NewTeamRepository(db),
+		TeamSeasonRepo:   NewTeamSeasonRepository(db),
+		GameRepo:         NewGameRepository(db),
+		GameSideRepo:     NewGameSideRepository(db),
+		StatsRepo:        NewStatsRepository(db),
+		PlayerResultRepo: NewPlayerGameResultRepository(db),
+		AwardRepo:        NewAwardRepository(db),
+		LeagueRecordRepo: NewLeagueRecordRepository(db),
+		AvailabilityRepo: NewAvailabilityRepository(db),
+		VenueRepo:        NewVenueRepository(db),
+		MatchdayRepo:     NewMatchdayRepository(db),
+		GameTemplateRepo: NewGameTemplateRepository(db),
 	}, nil
 }
 
 type RepositoriesCollection struct {
-	UserRepo       *UserRepository
-	PlayerRepo     *PlayerRepository
-	LeagueRepo     *LeagueRepository
-	SeasonRepo     *SeasonRepository
-	TeamRepo       *TeamRepository
-	TeamSeasonRepo *TeamSeasonRepository
-	GameRepo       *GameRepository
-	GameSideRepo   *GameSideRepository
+	db *gorm.DB
+
+	UserRepo         *UserRepository
+	PlayerRepo       *PlayerRepository
+	LeagueRepo       *LeagueRepository
+	SeasonRepo       *SeasonRepository
+	TeamRepo         *TeamRepository
+	TeamSeasonRepo   *TeamSeasonRepository
+	GameRepo         *GameRepository
+	GameSideRepo     *GameSideRepository
+	StatsRepo        *StatsRepository
+	PlayerResultRepo *PlayerGameResultRepository
+	AwardRepo        *AwardRepository
+	LeagueRecordRepo *LeagueRecordRepository
+	AvailabilityRepo *AvailabilityRepository
+	VenueRepo        *VenueRepository
+	MatchdayRepo     *MatchdayRepository
+	GameTemplateRepo *GameTemplateRepository
+}
+
+// Transaction runs fn with a collection whose repositories all share one database
+// transaction. Returning an error from fn rolls everything back.
+func (rc *RepositoriesCollection) Transaction(ctx context.Context, fn func(tx *RepositoriesCollection) error) error {
+	return rc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepos, err := InitializeRepositories(tx)
+		if err != nil {
+			return err
+		}
+		return fn(txRepos)
+	})
 }
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+)
+
+type LeagueRecordRepository struct {
+	db *gorm.DB
+}
+
+func NewLeagueRecordRepository(db *gorm.DB) *LeagueRecordRepository {
+	return &LeagueRecordRepository{db: db}
+}
+
+// ListByLeague returns every holder, current first within each record, then newest history first.
+func (r *LeagueRecordRepository) ListByLeague(ctx context.Context, leagueID int64) ([]models.LeagueRecord, error) {
+	var rows []models.LeagueRecord
+	if err := r.db.WithContext(ctx).
+		Where("league_id = ?", leagueID).
+		Order("record asc, superseded_at desc nulls first, id desc").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GetCurrent returns the current holder of a record, or gorm.ErrRecordNotFound.
+func (r *LeagueRecordRepository) GetCurrent(ctx context.Context, leagueID int64, record models.RecordType) (*models.LeagueRecord, error) {
+	var row models.LeagueRecord
+	if err := r.db.WithContext(ctx).
+		Where("league_id = ? AND record = ? AND superseded_at IS NULL", leagueID, record).
+		Order("id desc").
+		First(&row).Error; err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// Supersede moves cur (if any) into history and makes next the current holder, in one transaction.
+func (r *LeagueRecordRepository) Supersede(ctx context.Context, cur *models.LeagueRecord, next *models.LeagueRecord) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if cur != nil {
+			now := time.Now().UTC()
+			if err := tx.Model(&models.LeagueRecord{}).
+				Where("id = ?", cur.ID).
+				Update("superseded_at", &now).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(next).Error
+	})
+}
+
+func (r *LeagueRecordRepository) UpdateFields(ctx context.Context, id int64, fields map[string]any) error {
+	return r.db.WithContext(ctx).
+		Model(&models.LeagueRecord{}).
+		Where("id = ?", id).
+		Updates(fields).Error
+}
+
+func (r *LeagueRecordRepository) DeleteByID(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Delete(&models.LeagueRecord{}, id).Error
+}
+
+// RecordCandidate is the best value a league currently has for a record.
+type RecordCandidate struct {
+	Value    int64      `gorm:"column:value"`
+	PlayerID *int64     `gorm:"column:player_id"`
+	TeamID   *int64     `gorm:"column:team_id"`
+	GameID   int64      `gorm:"column:game_id"`
+	EndedAt  *time.Time `gorm:"column:ended_at"`
+}
+
+// sideRecordValue is the per-side expression each single-game record maximises.
+var sideRecordValue = map[models.RecordType]string{
+	models.RecordBiggestMargin: "gs.points - opp.points",
+	models.RecordHighestScore:  "gs.points",
+	models.RecordMostTwenties:  "gs.twenties",
+}
+
+// BestSideRecord finds the league's best single-game side for a side record
+// (biggest_margin, highest_score or most_twenties_game). Ties go to the earliest game.
+// Returns nil when no completed game has a positive value.
+func (r *LeagueRecordRepository) BestSideRecord(ctx context.Context, leagueID int64, record models.RecordType) (*RecordCandidate, error) {
+	expr, ok := sideRecordValue[record]
+	if !ok {
+		return nil, nil
+	}
+
+	sql := `
+SELECT
+  ` + expr + `   AS value,
+  gs.player_id   AS player_id,
+  gs.team_id     AS team_id,
+  g.id           AS game_id,
+  g.ended_at     AS ended_at
+FROM games g
+JOIN seasons s      ON s.id = g.season_id
+JOIN game_sides gs  ON gs.game_id = g.id AND gs.deleted_at IS NULL
+JOIN game_sides opp ON opp.game_id = g.id AND opp.side <> gs.side AND opp.deleted_at IS NULL
+WHERE
+  g.status = 'completed'
+  AND g.deleted_at IS NULL
+  AND s.league_id = @leagueID
+  AND ` + expr + ` > 0
+ORDER BY value DESC, g.ended_at ASC NULLS LAST, g.id ASC
+LIMIT 1;
+`
+	var rows []RecordCandidate
+	if err := r.db.WithContext(ctx).Raw(sql, map[string]any{"leagueID": leagueID}).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+)
+
+type MatchdayRepository struct {
+	db *gorm.DB
+}
+
+func NewMatchdayRepository(db *gorm.DB) *MatchdayRepository {
+	return &MatchdayRepository{db: db}
+}
+
+func (r *MatchdayRepository) Create(ctx context.Context, m *models.Matchday) error {
+	return r.db.WithContext(ctx).Create(m).Error
+}
+
+func (r *MatchdayRepository) GetByID(ctx context.Context, id int64) (*models.Matchday, error) {
+	var m models.Matchday
+	if err := r.db.WithContext(ctx).First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *MatchdayRepository) GetByNumber(ctx context.Context, seasonID int64, number int) (*models.Matchday, error) {
+	var m models.Matchday
+	if err := r.db.WithContext(ctx).
+		Where("season_id = ? AND number = ?", seasonID, number).
+		First(&m).Error; err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ListBySeason returns the season's matchdays in number order.
+func (r *MatchdayRepository) ListBySeason(ctx context.Context, seasonID int64) ([]models.Matchday, error) {
+	var items []models.Matchday
+	if err := r.db.WithContext(ctx).
+		Where("season_id = ?", seasonID).
+		Order("number asc").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// MaxNumber returns the highest matchday number in the season, or 0 if there are none.
+func (r *MatchdayRepository) MaxNumber(ctx context.Context, seasonID int64) (int, error) {
+	var n int
+	err := r.db.WithContext(ctx).
+		Model(&models.Matchday{}).
+		Where("season_id = ?", seasonID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&n).Error
+	return n, err
+}
+
+func (r *MatchdayRepository) UpdateFields(ctx context.Context, id int64, fields map[string]any) (*models.Matchday, error) {
+	if err := r.db.WithContext(ctx).
+		Model(&models.Matchday{}).
+		Where("id = ?", id).
+		Updates(fields).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// DeleteByID detaches the matchday's games and removes it.
+func (r *MatchdayRepository) DeleteByID(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Game{}).
+			Where("matchday_id = ?", id).
+			Update("matchday_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Matchday{}, id).Error
+	})
+}
+
+// ListGames returns the matchday's games, scheduled ones first by time.
+func (r *MatchdayRepository) ListGames(ctx context.Context, matchdayID int64) ([]models.Game, error) {
+	var items []models.Game
+	if err := r.db.WithContext(ctx).
+		Where("matchday_id = ?", matchdayID).
+		Order("scheduled_at asc nulls last, id asc").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Postpone moves the matchday to date and sets each listed game's scheduled_at,
+// all in one transaction.
+func (r *MatchdayRepository) Postpone(ctx context.Context, id int64, date time.Time, scheduled map[int64]time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Matchday{}).
+			Where("id = ?", id).
+			Update("date", date).Error; err != nil {
+			return err
+		}
+		for gameID, at := range scheduled {
+			if err := tx.Model(&models.Game{}).
+				Where("id = ?", gameID).
+				Update("scheduled_at", at).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
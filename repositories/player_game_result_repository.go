@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+)
+
+// PlayerGameResultRepository maintains the denormalized player_game_results table.
+// Game, side and team writes keep it in sync inside their own transactions;
+// Rebuild exists for backfills and repairs.
+type PlayerGameResultRepository struct {
+	db *gorm.DB
+}
+
+func NewPlayerGameResultRepository(db *gorm.DB) *PlayerGameResultRepository {
+	return &PlayerGameResultRepository{db: db}
+}
+
+// Rebuild truncates and repopulates the table from games/game_sides/teams in one
+// transaction, returning the number of rows written.
+func (r *PlayerGameResultRepository) Rebuild(ctx context.Context) (int64, error) {
+	var n int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM player_game_results").Error; err != nil {
+			return err
+		}
+		res := tx.Exec(insertPlayerGameResultsSQL+"TRUE", map[string]any{})
+		if res.Error != nil {
+			return res.Error
+		}
+		n = res.RowsAffected
+		return nil
+	})
+	return n, err
+}
+
+// SyncGame recomputes the rows for one game outside of any other write.
+func (r *PlayerGameResultRepository) SyncGame(ctx context.Context, gameID int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return syncGameResults(tx, gameID)
+	})
+}
+
+func (r *PlayerGameResultRepository) ListByGame(ctx context.Context, gameID int64) ([]models.PlayerGameResult, error) {
+	var rows []models.PlayerGameResult
+	if err := r.db.WithContext(ctx).
+		Where("game_id = ?", gameID).
+		Order("side asc, player_id asc").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// insertPlayerGameResultsSQL expands every completed, non-deleted game matching
+// the trailing predicate (appended by the caller, over games g) into one row per player.
+// Result mirrors the stats queries: an explicit winner_side wins; otherwise points decide.
+const insertPlayerGameResultsSQL = `
+INSERT INTO player_game_results (
+  player_id, game_id, season_id, team_id, match_type, side, color,
+  points_for, points_against, twenties, winner_side, result, location, ended_at, created_at
+)
+SELECT
+  p.player_id,
+  g.id,
+  g.season_id,
+  gs.team_id,
+  g.match_type,
+  gs.side,
+  gs.color,
+  gs.points,
+  opp.points,
+  gs.twenties,
+  g.winner_side,
+  CASE
+    WHEN g.winner_side IS NOT NULL THEN CASE WHEN g.winner_side = gs.side THEN 'W' ELSE 'L' END
+    WHEN gs.points > opp.points THEN 'W'
+    WHEN gs.points < opp.points THEN 'L'
+    ELSE 'T'
+  END,
+  g.location,
+  g.ended_at,
+  NOW()
+FROM games g
+JOIN game_sides gs  ON gs.game_id = g.id AND gs.deleted_at IS NULL
+JOIN game_sides opp ON opp.game_id = g.id AND opp.side <> gs.side AND opp.deleted_at IS NULL
+LEFT JOIN teams t   ON t.id = gs.team_id
+JOIN LATERAL (
+  -- players format: the side's player
+  SELECT gs.player_id AS player_id
+  WHERE g.match_type = 'players' AND gs.player_id IS NOT NULL
+  UNION ALL
+  -- teams format: expand to PlayerA and PlayerB
+  SELECT t.player_a_id WHERE g.match_type = 'teams' AND t.id IS NOT NULL
+  UNION ALL
+  SELECT t.player_b_id WHERE g.match_type = 'teams' AND t.id IS NOT NULL
+) p ON TRUE
+WHERE
+  g.status = 'completed'
+  AND g.deleted_at IS NULL
+  AND `
+
+// syncPlayerGameResults replaces the rows of every game matching scope, a predicate
+// over games g. Call it with the transaction that changed those games.
+func syncPlayerGameResults(tx *gorm.DB, scope string, args map[string]any) error {
+	if err := tx.Exec(
+		"DELETE FROM player_game_results WHERE game_id IN (SELECT g.id FROM games g WHERE "+scope+")",
+		args,
+	).Error; err != nil {
+		return err
+	}
+	return tx.Exec(insertPlayerGameResultsSQL+scope, args).Error
+}
+
+func syncGameResults(tx *gorm.DB, gameID int64) error {
+	return syncPlayerGameResults(tx, "g.id = @gameID", map[string]any{"gameID": gameID})
+}
+
+// syncTeamGameResults refreshes every game the team has played, e.g. after its roster changes.
+func syncTeamGameResults(tx *gorm.DB, teamID int64) error {
+	return syncPlayerGameResults(tx,
+		"g.id IN (SELECT game_id FROM game_sides WHERE team_id = @teamID)",
+		map[string]any{"teamID": teamID},
+	)
+}
@@ -100,6 +100,9 @@ type PlayerDuplicateGameDBRow struct {
 	RowCount int64  `gorm:"column:row_count"`
 }
 
+// ListPlayerDuplicateGames finds completed games where the player appears more than
+// once. It reads games and game_sides directly, soft-deleted sides included, because
+// the duplicates it is meant to catch never reach player_game_results.
 func (r *PlayerRepository) ListPlayerDuplicateGames(
 	ctx context.Context,
 	playerID int64,
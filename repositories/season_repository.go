@@ -46,6 +46,20 @@ func (r *SeasonRepository) UpdateFields(ctx context.Context, id int64, fields ma
 	return r.GetByID(ctx, id)
 }
 
+// SetInheritedTimezone moves the league's seasons that inherit their timezone to tz
+// and returns their IDs.
+func (r *SeasonRepository) SetInheritedTimezone(ctx context.Context, leagueID int64, tz string) ([]int64, error) {
+	var ids []int64
+	err := r.db.WithContext(ctx).Raw(`
+UPDATE seasons
+SET timezone = @tz, updated_at = NOW()
+WHERE league_id = @league
+  AND timezone_inherited
+  AND deleted_at IS NULL
+RETURNING id`, map[string]any{"league": leagueID, "tz": tz}).Scan(&ids).Error
+	return ids, err
+}
+
 func (r *SeasonRepository) DeleteByID(ctx context.Context, id int64) error {
 	return r.db.WithContext(ctx).Delete(&models.Season{}, id).Error
 }
@@ -86,81 +100,31 @@ func (r *SeasonRepository) List(ctx context.Context, f ListSeasonsFilter) ([]mod
 	return items, total, nil
 }
 
+// ListPlayerStats aggregates the season's player stats under q's filters, returning
+// the requested page and the total number of matching players.
 func (r *SeasonRepository) ListPlayerStats(
 	ctx context.Context,
-	seasonID int64,
-) ([]models.PlayerStatsRow, error) {
+	q StatsQuery,
+) ([]models.PlayerStatsRow, int64, error) {
+	b := q.filter(playerStatsSource)
 	sql := `
 WITH per_player AS (
-  -- Direct player-vs-player games
+  -- One row per player per completed game (team games already expanded)
   SELECT
-    gs.player_id                       AS player_id,
-    g.id                               AS game_id,
-    gs.side                            AS side,
-    gs.color                           AS color,
-    g.winner_side                      AS winner_side
-  FROM games g
-  JOIN game_sides gs ON gs.game_id = g.id
-  WHERE
-    g.status = 'completed'
-    AND g.match_type = 'players'
-    AND g.season_id = ?
-    AND gs.player_id IS NOT NULL
-
-  UNION ALL
-
-  -- Team games, expand to PlayerA
-  SELECT
-    t.player_a_id                      AS player_id,
-    g.id                               AS game_id,
-    gs.side                            AS side,
-    gs.color                           AS color,
-    g.winner_side                      AS winner_side
-  FROM games g
-  JOIN game_sides gs ON gs.game_id = g.id
-  JOIN teams t       ON t.id = gs.team_id
-  WHERE
-    g.status = 'completed'
-    AND g.match_type = 'teams'
-    AND g.season_id = ?
-    AND gs.team_id IS NOT NULL
-
-  UNION ALL
-
-  -- Team games, expand to PlayerB
-  SELECT
-    t.player_b_id                      AS player_id,
-    g.id                               AS game_id,
-    gs.side                            AS side,
-    gs.color                           AS color,
-    g.winner_side                      AS winner_side
-  FROM games g
-  JOIN game_sides gs ON gs.game_id = g.id
-  JOIN teams t       ON t.id = gs.team_id
+    pr.player_id,
+    pr.game_id,
+    pr.side,
+    pr.color,
+    pr.winner_side
+  FROM player_game_results pr
   WHERE
-    g.status = 'completed'
-    AND g.match_type = 'teams'
-    AND g.season_id = ?
-    AND gs.team_id IS NOT NULL
+    TRUE` + b.where() + `
 ),
 agg AS (
   SELECT
-    player_id,
-    COUNT(*) AS games,
-    SUM(CASE WHEN winner_side = side THEN 1 ELSE 0 END) AS wins,
-    SUM(CASE WHEN winner_side <> side THEN 1 ELSE 0 END) AS losses,
-
-    -- Wins by color
-    SUM(CASE WHEN winner_side = side AND color = 'white'   THEN 1 ELSE 0 END) AS white_wins,
-    SUM(CASE WHEN winner_side = side AND color = 'black'   THEN 1 ELSE 0 END) AS black_wins,
-    SUM(CASE WHEN winner_side = side AND color = 'natural' THEN 1 ELSE 0 END) AS natural_wins,
-
-    -- Games by color
-    SUM(CASE WHEN color = 'white'   THEN 1 ELSE 0 END) AS white_games,
-    SUM(CASE WHEN color = 'black'   THEN 1 ELSE 0 END) AS black_games,
-    SUM(CASE WHEN color = 'natural' THEN 1 ELSE 0 END) AS natural_games
+    player_id,` + statsAggColumns + `
   FROM per_player
-  GROUP BY player_id
+  GROUP BY player_id` + q.having(b) + `
 )
 SELECT
   player_id,
@@ -172,13 +136,8 @@ SELECT
   natural_wins,
   white_games,
   black_games,
-  natural_games,
-  CASE WHEN games = 0 THEN 0.0
-       ELSE wins::float / games::float
-  END AS win_pct
-FROM agg
-ORDER BY win_pct DESC, games DESC, player_id ASC;
-`
+  natural_games,` + statsWinPct + `
+FROM agg` + q.orderAndPage("player_id") + ";"
 
 	type row struct {
 		PlayerID     int64   `gorm:"column:player_id"`
@@ -192,17 +151,26 @@ ORDER BY win_pct DESC, games DESC, player_id ASC;
 		BlackGames   int64   `gorm:"column:black_games"`
 		NaturalGames int64   `gorm:"column:natural_games"`
 		WinPct       float64 `gorm:"column:win_pct"`
+		TotalCount   int64   `gorm:"column:total_count"`
 	}
 
 	var rows []row
 	if err := r.db.WithContext(ctx).
-		Raw(sql, seasonID, seasonID, seasonID).
+		Raw(sql, b.args).
 		Scan(&rows).Error; err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	if len(rows) == 0 && q.Offset > 0 {
+		// Past the last page: the windowed total came back with no rows to carry it.
+		q.Limit, q.Offset = 1, 0
+		_, total, err := r.ListPlayerStats(ctx, q)
+		return []models.PlayerStatsRow{}, total, err
 	}
 
+	var total int64
 	out := make([]models.PlayerStatsRow, 0, len(rows))
 	for _, x := range rows {
+		total = x.TotalCount
 		out = append(out, models.PlayerStatsRow{
 			PlayerID:     x.PlayerID,
 			Games:        x.Games,
@@ -217,60 +185,52 @@ ORDER BY win_pct DESC, games DESC, player_id ASC;
 			NaturalGames: x.NaturalGames,
 		})
 	}
-	return out, nil
+	return out, total, nil
 }
 
+// ListTeamStats aggregates the season's team stats under q's filters, returning
+// the requested page and the total number of matching teams.
 func (r *SeasonRepository) ListTeamStats(
 	ctx context.Context,
-	seasonID int64,
-) ([]models.TeamStatsRow, error) {
+	q StatsQuery,
+) ([]models.TeamStatsRow, int64, error) {
+	b := q.filter(teamStatsSource)
 	sql := `
 WITH per_team AS (
   SELECT
-    t.id                                  AS team_id,
+    gs.team_id                            AS team_id,
     g.id                                  AS game_id,
     gs.side                               AS side,
     gs.color                              AS color,
-    COALESCE(g.location, 'Unknown')       AS location,
+    COALESCE(NULLIF(TRIM(g.location), ''), 'Unknown')         AS location,
+    COALESCE(NULLIF(LOWER(TRIM(g.location)), ''), 'unknown') AS location_key,
     g.winner_side                         AS winner_side
   FROM games g
-  JOIN game_sides gs ON gs.game_id = g.id
+  JOIN game_sides gs ON gs.game_id = g.id AND gs.deleted_at IS NULL
   JOIN teams t       ON t.id = gs.team_id
   WHERE
     g.status = 'completed'
-    AND g.match_type = 'teams'
-    AND g.season_id = ?
+    AND g.deleted_at IS NULL
+    AND g.match_type = 'teams'` + b.where() + `
 ),
 agg AS (
   SELECT
-    team_id,
-    COUNT(*) AS games,
-    SUM(CASE WHEN winner_side = side THEN 1 ELSE 0 END) AS wins,
-    SUM(CASE WHEN winner_side <> side THEN 1 ELSE 0 END) AS losses,
-
-    -- Wins by color
-    SUM(CASE WHEN winner_side = side AND color = 'white'   THEN 1 ELSE 0 END) AS white_wins,
-    SUM(CASE WHEN winner_side = side AND color = 'black'   THEN 1 ELSE 0 END) AS black_wins,
-    SUM(CASE WHEN winner_side = side AND color = 'natural' THEN 1 ELSE 0 END) AS natural_wins,
-
-    -- Games by color
-    SUM(CASE WHEN color = 'white'   THEN 1 ELSE 0 END) AS white_games,
-    SUM(CASE WHEN color = 'black'   THEN 1 ELSE 0 END) AS black_games,
-    SUM(CASE WHEN color = 'natural' THEN 1 ELSE 0 END) AS natural_games
+    team_id,` + statsAggColumns + `
   FROM per_team
-  GROUP BY team_id
+  GROUP BY team_id` + q.having(b) + `
 ),
+-- Locations match case-insensitively, shown under one spelling.
 loc AS (
   SELECT
     team_id,
-    location,
+    MIN(location) AS location,
     COUNT(*) FILTER (WHERE winner_side = side) AS wins_at_location,
     ROW_NUMBER() OVER (
       PARTITION BY team_id
-      ORDER BY COUNT(*) FILTER (WHERE winner_side = side) DESC, location ASC
+      ORDER BY COUNT(*) FILTER (WHERE winner_side = side) DESC, MIN(location) ASC
     ) AS rn
   FROM per_team
-  GROUP BY team_id, location
+  GROUP BY team_id, location_key
 )
 SELECT
   a.team_id,
@@ -282,18 +242,13 @@ SELECT
   a.natural_wins,
   a.white_games,
   a.black_games,
-  a.natural_games,
-  CASE WHEN a.games = 0 THEN 0.0
-       ELSE a.wins::float / a.games::float
-  END AS win_pct,
+  a.natural_games,` + statsWinPct + `,
   l.location       AS best_location,
   COALESCE(l.wins_at_location, 0) AS best_location_wins
 FROM agg a
 LEFT JOIN loc l
   ON l.team_id = a.team_id
- AND l.rn = 1
-ORDER BY win_pct DESC, games DESC, team_id ASC;
-`
+ AND l.rn = 1` + q.orderAndPage("team_id") + ";"
 
 	type row struct {
 		TeamID           int64   `gorm:"column:team_id"`
@@ -309,17 +264,26 @@ ORDER BY win_pct DESC, games DESC, team_id ASC;
 		WinPct           float64 `gorm:"column:win_pct"`
 		BestLocation     *string `gorm:"column:best_location"`
 		BestLocationWins int64   `gorm:"column:best_location_wins"`
+		TotalCount       int64   `gorm:"column:total_count"`
 	}
 
 	var rows []row
 	if err := r.db.WithContext(ctx).
-		Raw(sql, seasonID).
+		Raw(sql, b.args).
 		Scan(&rows).Error; err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	if len(rows) == 0 && q.Offset > 0 {
+		// Past the last page: the windowed total came back with no rows to carry it.
+		q.Limit, q.Offset = 1, 0
+		_, total, err := r.ListTeamStats(ctx, q)
+		return []models.TeamStatsRow{}, total, err
 	}
 
+	var total int64
 	out := make([]models.TeamStatsRow, 0, len(rows))
 	for _, x := range rows {
+		total = x.TotalCount
 		out = append(out, models.TeamStatsRow{
 			TeamID:           x.TeamID,
 			Games:            x.Games,
@@ -336,5 +300,18 @@ ORDER BY win_pct DESC, games DESC, team_id ASC;
 			BestLocationWins: x.BestLocationWins,
 		})
 	}
-	return out, nil
+	return out, total, nil
+}
+
+// GetPreviousInLeague returns the league's season that started most recently before s,
+// or gorm.ErrRecordNotFound if s is the league's first.
+func (r *SeasonRepository) GetPreviousInLeague(ctx context.Context, s *models.Season) (*models.Season, error) {
+	var prev models.Season
+	if err := r.db.WithContext(ctx).
+		Where("league_id = ? AND id <> ? AND starts_on < ?", s.LeagueID, s.ID, s.StartsOn).
+		Order("starts_on desc, id desc").
+		First(&prev).Error; err != nil {
+		return nil, err
+	}
+	return &prev, nil
 }
@@ -3,7 +3,9 @@ package repositories
 
 import (
 	"context"
-	"fmt"
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
 )
 
 type SeasonStandingsRow struct {
@@ -17,16 +19,32 @@ type SeasonStandingsRow struct {
 	PointsAgainst int     `json:"pointsAgainst" gorm:"column:pa"`
 	PointDiff     int     `json:"pointDiff" gorm:"column:pd"`
 	WinPct        float64 `json:"winPct" gorm:"column:win_pct"`
+
+	// Filled in by the service: rank from the season's tiebreakers, and the
+	// tiebreaker that separated this row from the rows it was level with.
+	Rank      int    `json:"rank" gorm:"-"`
+	DecidedBy string `json:"decidedBy" gorm:"-"`
+
+	// Filled in by the service from completed games ordered by EndedAt.
+	Form models.FormGuide `json:"form" gorm:"-"`
+
+	// Filled in by the service from the season's team matchups.
+	models.ScheduleStrength `gorm:"-"`
 }
 
-func (r *SeasonRepository) GetStandings(ctx context.Context, seasonID int64) ([]SeasonStandingsRow, error) {
+// GetStandings aggregates team standings over the scope's games. When asOf is set,
+// only games that ended before it count.
+func (r *SeasonRepository) GetStandings(ctx context.Context, scope StatsScope, asOf *time.Time) ([]SeasonStandingsRow, error) {
 	var rows []SeasonStandingsRow
+	args := map[string]any{}
+	asOfClause := scope.and("g", args) + endedBeforeClause("g", asOf, args)
 
 	// Schema assumptions:
 	// - games(id, season_id, match_type, status)
 	// - game_sides(id, game_id, side, team_id, points)
 	// - teams(id, name)
-	// Only includes completed team-vs-team games for the given season.
+	// Only includes completed team-vs-team games in the scope.
+	// Like the form guide, an explicit winner_side decides; otherwise points do.
 	// Handles ties as 0.5 win in win%.
 	sql := `
 WITH per_team AS (
@@ -37,9 +55,17 @@ WITH per_team AS (
     t.name                                      AS team_name,
     COALESCE(gs1.points, 0)                     AS pf,
     COALESCE(gs2.points, 0)                     AS pa,
-    CASE WHEN COALESCE(gs1.points,0) > COALESCE(gs2.points,0) THEN 1 ELSE 0 END AS win,
-    CASE WHEN COALESCE(gs1.points,0) < COALESCE(gs2.points,0) THEN 1 ELSE 0 END AS loss,
-    CASE WHEN COALESCE(gs1.points,0) = COALESCE(gs2.points,0) THEN 1 ELSE 0 END AS tie
+    CASE
+      WHEN g.winner_side IS NOT NULL THEN CASE WHEN g.winner_side = gs1.side THEN 1 ELSE 0 END
+      WHEN COALESCE(gs1.points,0) > COALESCE(gs2.points,0) THEN 1 ELSE 0
+    END                                         AS win,
+    CASE
+      WHEN g.winner_side IS NOT NULL THEN CASE WHEN g.winner_side <> gs1.side THEN 1 ELSE 0 END
+      WHEN COALESCE(gs1.points,0) < COALESCE(gs2.points,0) THEN 1 ELSE 0
+    END                                         AS loss,
+    CASE
+      WHEN g.winner_side IS NULL AND COALESCE(gs1.points,0) = COALESCE(gs2.points,0) THEN 1 ELSE 0
+    END                                         AS tie
   FROM games g
   JOIN game_sides gs1 ON gs1.game_id = g.id
   JOIN game_sides gs2 ON gs2.game_id = g.id AND gs2.side <> gs1.side
@@ -47,8 +73,8 @@ WITH per_team AS (
   WHERE
     g.status = 'completed'
     AND g.match_type = 'teams'
-    AND g.season_id = @seasonID
-    AND gs1.team_id IS NOT NULL
+    AND g.deleted_at IS NULL
+    AND gs1.team_id IS NOT NULL` + asOfClause + `
 )
 SELECT
   team_id,
@@ -72,7 +98,7 @@ ORDER BY
   pf DESC,
   team_name ASC;
 `
-	if err := r.db.WithContext(ctx).Raw(sql, map[string]any{"seasonID": seasonID}).Scan(&rows).Error; err != nil {
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
 		return nil, err
 	}
 	return rows, nil
@@ -89,146 +115,58 @@ type PlayerStandingsRow struct {
 	WinPct        float64
 }
 
+// PlayerStandingsCursor points at the last row of the previous page in ranked order.
 type PlayerStandingsCursor struct {
-	Wins      int64 `json:"wins"`
-	PointDiff int64 `json:"point_diff"`
-	PlayerID  int64 `json:"player_id"`
-}
-
-type ListPlayerStandingsQuery struct {
-	SeasonID int64
-	Limit    int
-	Cursor   *PlayerStandingsCursor
+	PlayerID int64 `json:"player_id"`
 }
 
-func (r *SeasonRepository) ListPlayerStandings(ctx context.Context, q ListPlayerStandingsQuery) ([]PlayerStandingsRow, *PlayerStandingsCursor, error) {
-	if q.Limit <= 0 {
-		q.Limit = 50
-	}
-	if q.Limit > 200 {
-		q.Limit = 200
-	}
+// ListPlayerStandings returns every roster player's totals over the scope's games, unranked.
+// Ordering (and therefore pagination) is applied by the service using the season's tiebreakers.
+// When asOf is set, only games that ended before it count.
+func (r *SeasonRepository) ListPlayerStandings(ctx context.Context, scope StatsScope, asOf *time.Time) ([]PlayerStandingsRow, error) {
+	args := map[string]any{}
+	asOfClause := scope.and("pr", args) + endedBeforeClause("pr", asOf, args)
 
-	// Supports player-vs-player AND team-vs-team games.
-	// For team games, we "expand" each side into two rows (PlayerAID, PlayerBID).
-	// Roster = active memberships UNION players who actually appeared this season.
-	base := `
-WITH roster AS (
+	// Only a single season has a roster; other scopes list the players who appeared.
+	members := ""
+	if scope.Kind == ScopeSeason {
+		members = `
   SELECT DISTINCT ptm.player_id
   FROM player_team_memberships ptm
-  WHERE ptm.season_id = ? AND ptm.is_active = TRUE
+  WHERE ptm.season_id = @scopeID AND ptm.is_active = TRUE
 
   UNION
+`
+	}
 
-  SELECT DISTINCT p.player_id
-  FROM (
-    -- players games, take player_id directly
-    SELECT gs.player_id
-    FROM game_sides gs
-    JOIN games g ON g.id = gs.game_id
-    WHERE g.season_id = ?
-      AND g.status = 'completed'
-      AND g.match_type = 'players'
-      AND gs.player_id IS NOT NULL
-
-    UNION ALL
-
-    -- team games, expand Team.PlayerAID
-    SELECT t.player_a_id AS player_id
-    FROM game_sides gs
-    JOIN games g ON g.id = gs.game_id
-    JOIN teams t ON t.id = gs.team_id
-    WHERE g.season_id = ?
-      AND g.status = 'completed'
-      AND g.match_type = 'teams'
-      AND gs.team_id IS NOT NULL
-
-    UNION ALL
-
-    -- team games, expand Team.PlayerBID
-    SELECT t.player_b_id AS player_id
-    FROM game_sides gs
-    JOIN games g ON g.id = gs.game_id
-    JOIN teams t ON t.id = gs.team_id
-    WHERE g.season_id = ?
-      AND g.status = 'completed'
-      AND g.match_type = 'teams'
-      AND gs.team_id IS NOT NULL
-  ) p
-),
--- Expand game_sides to per-player rows for ALL completed games
-expanded AS (
-  -- players format
-  SELECT
-    g.id           AS game_id,
-    gs.side        AS side,
-    gs.points      AS points_for,
-    NULL           AS team_id,
-    gs.player_id   AS player_id,
-    g.winner_side  AS winner_side
-  FROM game_sides gs
-  JOIN games g ON g.id = gs.game_id
-  WHERE g.season_id = ?
-    AND g.status = 'completed'
-    AND g.match_type = 'players'
-    AND gs.player_id IS NOT NULL
-
-  UNION ALL
-
-  -- teams format -> PlayerA
-  SELECT
-    g.id           AS game_id,
-    gs.side        AS side,
-    gs.points      AS points_for,
-    gs.team_id     AS team_id,
-    t.player_a_id  AS player_id,
-    g.winner_side  AS winner_side
-  FROM game_sides gs
-  JOIN games g ON g.id = gs.game_id
-  JOIN teams t ON t.id = gs.team_id
-  WHERE g.season_id = ?
-    AND g.status = 'completed'
-    AND g.match_type = 'teams'
-    AND gs.team_id IS NOT NULL
-
-  UNION ALL
-
-  -- teams format -> PlayerB
-  SELECT
-    g.id           AS game_id,
-    gs.side        AS side,
-    gs.points      AS points_for,
-    gs.team_id     AS team_id,
-    t.player_b_id  AS player_id,
-    g.winner_side  AS winner_side
-  FROM game_sides gs
-  JOIN games g ON g.id = gs.game_id
-  JOIN teams t ON t.id = gs.team_id
-  WHERE g.season_id = ?
-    AND g.status = 'completed'
-    AND g.match_type = 'teams'
-    AND gs.team_id IS NOT NULL
+	// player_game_results already expands team sides to both players.
+	// Roster = active memberships UNION players who actually appeared in the scope.
+	base := `
+WITH roster AS (` + members + `
+  SELECT DISTINCT pr.player_id
+  FROM player_game_results pr
+  WHERE TRUE` + asOfClause + `
 ),
--- Join each per-player row with their opponent row in the same game to get points_against
+-- One row per (player, game); a player listed twice in a game counts once
 paired AS (
-  SELECT DISTINCT ON (a.player_id, a.game_id)
-    a.player_id,
-    a.game_id,
-    a.side,
-    a.points_for,
-    b.points_for AS points_against,
-    a.winner_side
-  FROM expanded a
-  JOIN expanded b ON b.game_id = a.game_id AND b.side <> a.side
-  ORDER BY a.player_id, a.game_id
+  SELECT DISTINCT ON (pr.player_id, pr.game_id)
+    pr.player_id,
+    pr.game_id,
+    pr.side,
+    pr.points_for,
+    pr.points_against,
+    pr.result
+  FROM player_game_results pr
+  WHERE TRUE` + asOfClause + `
+  ORDER BY pr.player_id, pr.game_id
 ),
 -- Aggregate per player
 agg AS (
   SELECT
     player_id,
     COUNT(*) AS games,
-    SUM(CASE WHEN winner_side = side THEN 1 ELSE 0 END) AS wins,
-    SUM(CASE WHEN winner_side <> side THEN 1 ELSE 0 END) AS losses,
+    SUM(CASE WHEN result = 'W' THEN 1 ELSE 0 END) AS wins,
+    SUM(CASE WHEN result = 'L' THEN 1 ELSE 0 END) AS losses,
     SUM(points_for)      AS points_for,
     SUM(points_against)  AS points_against
   FROM paired
@@ -263,29 +201,7 @@ SELECT
 FROM standings
 `
 
-	// Keyset pagination (wins DESC, point_diff DESC, player_id ASC)
-	pred := ""
-	args := []any{
-		q.SeasonID, q.SeasonID, q.SeasonID, q.SeasonID, // roster params
-		q.SeasonID, q.SeasonID, q.SeasonID, // expanded params
-	}
-	if q.Cursor != nil {
-		pred = `
-WHERE (wins < ?)
-   OR (wins = ? AND point_diff < ?)
-   OR (wins = ? AND point_diff = ? AND player_id > ?)
-`
-		args = append(args,
-			q.Cursor.Wins,
-			q.Cursor.Wins, q.Cursor.PointDiff,
-			q.Cursor.Wins, q.Cursor.PointDiff, q.Cursor.PlayerID,
-		)
-	}
-
-	order := `
-ORDER BY wins DESC, point_diff DESC, player_id ASC
-`
-	sql := base + pred + order + fmt.Sprintf("\nLIMIT %d", q.Limit+1)
+	sql := base + "ORDER BY player_id ASC"
 
 	type row struct {
 		PlayerID      int64   `gorm:"column:player_id"`
@@ -299,19 +215,8 @@ ORDER BY wins DESC, point_diff DESC, player_id ASC
 	}
 
 	var rows []row
-	if err := r.db.WithContext(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
-		return nil, nil, err
-	}
-
-	var next *PlayerStandingsCursor
-	if len(rows) > q.Limit {
-		last := rows[q.Limit-1]
-		next = &PlayerStandingsCursor{
-			Wins:      last.Wins,
-			PointDiff: last.PointDiff,
-			PlayerID:  last.PlayerID,
-		}
-		rows = rows[:q.Limit]
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, err
 	}
 
 	out := make([]PlayerStandingsRow, 0, len(rows))
@@ -327,5 +232,46 @@ ORDER BY wins DESC, point_diff DESC, player_id ASC
 			WinPct:        x.WinPct,
 		})
 	}
-	return out, next, nil
+	return out, nil
+}
+
+// ListRosterPlayerIDs returns the players with an active membership in the season,
+// the players ListPlayerStandings lists even before they have played.
+func (r *SeasonRepository) ListRosterPlayerIDs(ctx context.Context, seasonID int64) ([]int64, error) {
+	var ids []int64
+	err := r.db.WithContext(ctx).Raw(`
+SELECT DISTINCT ptm.player_id
+FROM player_team_memberships ptm
+WHERE ptm.season_id = @seasonID AND ptm.is_active = TRUE
+ORDER BY ptm.player_id`, map[string]any{"seasonID": seasonID}).Scan(&ids).Error
+	return ids, err
+}
+
+// endedBeforeClause renders an "as of" cutoff over alias.ended_at (games g or
+// player_game_results pr), adding its parameter to args.
+func endedBeforeClause(alias string, asOf *time.Time, args map[string]any) string {
+	if asOf == nil {
+		return ""
+	}
+	args["asOf"] = *asOf
+	return "\n    AND " + alias + ".ended_at < @asOf"
+}
+
+// GetCompletedRange returns the earliest and latest ended_at of the season's completed games.
+func (r *SeasonRepository) GetCompletedRange(ctx context.Context, seasonID int64) (*time.Time, *time.Time, error) {
+	var out struct {
+		First *time.Time `gorm:"column:first_ended"`
+		Last  *time.Time `gorm:"column:last_ended"`
+	}
+	if err := r.db.WithContext(ctx).Raw(`
+SELECT MIN(ended_at) AS first_ended, MAX(ended_at) AS last_ended
+FROM games
+WHERE season_id = ?
+  AND status = 'completed'
+  AND deleted_at IS NULL
+  AND ended_at IS NOT NULL
+`, seasonID).Scan(&out).Error; err != nil {
+		return nil, nil, err
+	}
+	return out.First, out.Last, nil
 }
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatsQuery filters, orders and pages the player/team stats aggregations.
+// Nil/zero fields do not filter.
+type StatsQuery struct {
+	Scope StatsScope
+
+	From       *time.Time // ended_at >= From
+	To         *time.Time // ended_at <= To
+	Location   *string    // case-insensitive exact match
+	Color      *string    // the participant's disc colour
+	OpponentID *int64     // player stats: an opposing player; team stats: the opposing team
+	MatchType  *string    // "teams" | "players"
+	MinGames   int
+
+	SortBy string // a StatsSortColumns key; default winPct
+	Desc   bool
+	Limit  int // 0 returns every row
+	Offset int
+}
+
+// StatsSortColumns maps the API sort keys to aggregate columns.
+var StatsSortColumns = map[string]string{
+	"games":        "games",
+	"wins":         "wins",
+	"losses":       "losses",
+	"winPct":       "win_pct",
+	"whiteWins":    "white_wins",
+	"blackWins":    "black_wins",
+	"naturalWins":  "natural_wins",
+	"whiteGames":   "white_games",
+	"blackGames":   "black_games",
+	"naturalGames": "natural_games",
+}
+
+// statsSource names the columns of a per-participant stats source, so one filter
+// set can be rendered over player_game_results or games/game_sides.
+type statsSource struct {
+	season, game, endedAt, location, matchType, color string
+	opponent                                          string // EXISTS predicate over @opponentID
+}
+
+var playerStatsSource = statsSource{
+	season:    "pr.season_id",
+	game:      "pr.game_id",
+	endedAt:   "pr.ended_at",
+	location:  "pr.location",
+	matchType: "pr.match_type",
+	color:     "pr.color",
+	opponent: `EXISTS (
+      SELECT 1 FROM player_game_results opp
+      WHERE opp.game_id = pr.game_id AND opp.side <> pr.side AND opp.player_id = @opponentID)`,
+}
+
+var teamStatsSource = statsSource{
+	season:    "g.season_id",
+	game:      "g.id",
+	endedAt:   "g.ended_at",
+	location:  "g.location",
+	matchType: "g.match_type",
+	color:     "gs.color",
+	opponent: `EXISTS (
+      SELECT 1 FROM game_sides opp
+      WHERE opp.game_id = g.id AND opp.side <> gs.side AND opp.team_id = @opponentID AND opp.deleted_at IS NULL)`,
+}
+
+// statsBuilder accumulates "AND ..." predicates and their named parameters.
+type statsBuilder struct {
+	preds []string
+	args  map[string]any
+}
+
+func newStatsBuilder() *statsBuilder {
+	return &statsBuilder{args: map[string]any{}}
+}
+
+// and adds pred with its named parameter (name may be empty for a bare predicate).
+func (b *statsBuilder) and(pred, name string, value any) {
+	b.preds = append(b.preds, pred)
+	if name != "" {
+		b.args[name] = value
+	}
+}
+
+func (b *statsBuilder) where() string {
+	if len(b.preds) == 0 {
+		return ""
+	}
+	return "\n    AND " + strings.Join(b.preds, "\n    AND ")
+}
+
+// filter renders q's row filters over src.
+func (q StatsQuery) filter(src statsSource) *statsBuilder {
+	b := newStatsBuilder()
+	if pred := q.Scope.predicate(src.season, b.args); pred != "" {
+		b.and(pred, "", nil)
+	}
+	if pred := q.Scope.matchdayPredicate(src.game, b.args); pred != "" {
+		b.and(pred, "", nil)
+	}
+	if q.From != nil {
+		b.and(src.endedAt+" >= @from", "from", *q.From)
+	}
+	if q.To != nil {
+		b.and(src.endedAt+" <= @to", "to", *q.To)
+	}
+	if q.Location != nil && strings.TrimSpace(*q.Location) != "" {
+		b.and("LOWER(TRIM("+src.location+")) = @location", "location", strings.ToLower(strings.TrimSpace(*q.Location)))
+	}
+	if q.Color != nil && *q.Color != "" {
+		b.and(src.color+" = @color", "color", *q.Color)
+	}
+	if q.MatchType != nil && *q.MatchType != "" {
+		b.and(src.matchType+" = @matchType", "matchType", *q.MatchType)
+	}
+	if q.OpponentID != nil {
+		b.and(src.opponent, "opponentID", *q.OpponentID)
+	}
+	return b
+}
+
+// having renders the minimum-games filter over the aggregate.
+func (q StatsQuery) having(b *statsBuilder) string {
+	if q.MinGames <= 0 {
+		return ""
+	}
+	b.args["minGames"] = q.MinGames
+	return "\n  HAVING COUNT(*) >= @minGames"
+}
+
+// orderAndPage renders ORDER BY (ties broken by games then idCol) and LIMIT/OFFSET.
+// Unknown sort keys fall back to winPct, which the service validates against first.
+func (q StatsQuery) orderAndPage(idCol string) string {
+	col, ok := StatsSortColumns[q.SortBy]
+	if !ok {
+		col = "win_pct"
+	}
+	dir := "ASC"
+	if q.Desc {
+		dir = "DESC"
+	}
+	out := "\nORDER BY " + col + " " + dir
+	if col != "games" {
+		out += ", games DESC"
+	}
+	out += ", " + idCol + " ASC"
+	if q.Limit > 0 {
+		out += "\nLIMIT " + strconv.Itoa(q.Limit) + " OFFSET " + strconv.Itoa(max(q.Offset, 0))
+	}
+	return out
+}
+
+// statsAggColumns aggregates a per-participant CTE (side, color, winner_side) into
+// the shared stats columns.
+const statsAggColumns = `
+    COUNT(*) AS games,
+    SUM(CASE WHEN winner_side = side THEN 1 ELSE 0 END) AS wins,
+    SUM(CASE WHEN winner_side <> side THEN 1 ELSE 0 END) AS losses,
+
+    -- Wins by color
+    SUM(CASE WHEN winner_side = side AND color = 'white'   THEN 1 ELSE 0 END) AS white_wins,
+    SUM(CASE WHEN winner_side = side AND color = 'black'   THEN 1 ELSE 0 END) AS black_wins,
+    SUM(CASE WHEN winner_side = side AND color = 'natural' THEN 1 ELSE 0 END) AS natural_wins,
+
+    -- Games by color
+    SUM(CASE WHEN color = 'white'   THEN 1 ELSE 0 END) AS white_games,
+    SUM(CASE WHEN color = 'black'   THEN 1 ELSE 0 END) AS black_games,
+    SUM(CASE WHEN color = 'natural' THEN 1 ELSE 0 END) AS natural_games`
+
+// statsWinPct is selected from the aggregate alongside COUNT(*) OVER () so one query
+// returns both the page and the filtered total.
+const statsWinPct = `
+  CASE WHEN games = 0 THEN 0.0
+       ELSE wins::float / games::float
+  END AS win_pct,
+  COUNT(*) OVER () AS total_count`
@@ -0,0 +1,923 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+)
+
+// StatsRepository holds the cross-season stats queries. Per-season stats
+// and standings still live on SeasonRepository.
+type StatsRepository struct {
+	db *gorm.DB
+}
+
+func NewStatsRepository(db *gorm.DB) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+type CareerStatsFilter struct {
+	LeagueID          *int64     // only games in seasons of this league
+	SeasonID          *int64     // only games in this season
+	EndedFrom         *time.Time // filter by ended_at >=
+	EndedTo           *time.Time // filter by ended_at <=
+	MatchType         *string    // "teams" | "players"
+	Location          *string    // case-insensitive exact match, ignoring surrounding spaces
+	IncludeExhibition bool       // include games with season_id IS NULL
+}
+
+// CareerSplitRow is one (season, location) bucket of a participant's completed games.
+// The service folds these into per-season splits and career totals.
+type CareerSplitRow struct {
+	SeasonID   *int64  `gorm:"column:season_id"`
+	SeasonName *string `gorm:"column:season_name"`
+	LeagueID   *int64  `gorm:"column:league_id"`
+	Location   *string `gorm:"column:location"`
+
+	Games  int64 `gorm:"column:games"`
+	Wins   int64 `gorm:"column:wins"`
+	Losses int64 `gorm:"column:losses"`
+
+	WhiteWins   int64 `gorm:"column:white_wins"`
+	BlackWins   int64 `gorm:"column:black_wins"`
+	NaturalWins int64 `gorm:"column:natural_wins"`
+
+	WhiteGames   int64 `gorm:"column:white_games"`
+	BlackGames   int64 `gorm:"column:black_games"`
+	NaturalGames int64 `gorm:"column:natural_games"`
+}
+
+// careerAggSelect aggregates a per_participant CTE into CareerSplitRow columns.
+// Locations are grouped case-insensitively on location_key and shown under one
+// spelling, the same way the location filter matches them.
+const careerAggSelect = `
+SELECT
+  season_id,
+  season_name,
+  league_id,
+  MIN(location) AS location,
+  COUNT(*) AS games,
+  SUM(CASE WHEN winner_side = side THEN 1 ELSE 0 END) AS wins,
+  SUM(CASE WHEN winner_side <> side THEN 1 ELSE 0 END) AS losses,
+
+  -- Wins by color
+  SUM(CASE WHEN winner_side = side AND color = 'white'   THEN 1 ELSE 0 END) AS white_wins,
+  SUM(CASE WHEN winner_side = side AND color = 'black'   THEN 1 ELSE 0 END) AS black_wins,
+  SUM(CASE WHEN winner_side = side AND color = 'natural' THEN 1 ELSE 0 END) AS natural_wins,
+
+  -- Games by color
+  SUM(CASE WHEN color = 'white'   THEN 1 ELSE 0 END) AS white_games,
+  SUM(CASE WHEN color = 'black'   THEN 1 ELSE 0 END) AS black_games,
+  SUM(CASE WHEN color = 'natural' THEN 1 ELSE 0 END) AS natural_games
+FROM per_participant
+GROUP BY season_id, season_name, league_id, location_key
+ORDER BY season_id ASC NULLS LAST, MIN(location) ASC;
+`
+
+func (r *StatsRepository) ListPlayerCareerSplits(
+	ctx context.Context,
+	playerID int64,
+	f CareerStatsFilter,
+) ([]CareerSplitRow, error) {
+	args := map[string]any{"playerID": playerID}
+	where := f.where("pr", args)
+
+	sql := `
+WITH per_participant AS (
+  SELECT
+    pr.season_id                       AS season_id,
+    s.name                             AS season_name,
+    s.league_id                        AS league_id,
+    COALESCE(NULLIF(TRIM(pr.location), ''), 'Unknown') AS location,
+    COALESCE(NULLIF(LOWER(TRIM(pr.location)), ''), 'unknown') AS location_key,
+    pr.side                            AS side,
+    pr.color                           AS color,
+    pr.winner_side                     AS winner_side
+  FROM player_game_results pr
+  LEFT JOIN seasons s ON s.id = pr.season_id
+  WHERE
+    pr.player_id = @playerID` + where + `
+)` + careerAggSelect
+
+	var rows []CareerSplitRow
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *StatsRepository) ListTeamCareerSplits(
+	ctx context.Context,
+	teamID int64,
+	f CareerStatsFilter,
+) ([]CareerSplitRow, error) {
+	args := map[string]any{"teamID": teamID}
+	where := f.where("g", args)
+
+	sql := `
+WITH per_participant AS (
+  SELECT
+    g.season_id                        AS season_id,
+    s.name                             AS season_name,
+    s.league_id                        AS league_id,
+    COALESCE(NULLIF(TRIM(g.location), ''), 'Unknown') AS location,
+    COALESCE(NULLIF(LOWER(TRIM(g.location)), ''), 'unknown') AS location_key,
+    gs.side                            AS side,
+    gs.color                           AS color,
+    g.winner_side                      AS winner_side
+  FROM games g
+  JOIN game_sides gs   ON gs.game_id = g.id AND gs.deleted_at IS NULL
+  LEFT JOIN seasons s  ON s.id = g.season_id
+  WHERE
+    g.status = 'completed'
+    AND g.deleted_at IS NULL
+    AND g.match_type = 'teams'
+    AND gs.team_id = @teamID` + where + `
+)` + careerAggSelect
+
+	var rows []CareerSplitRow
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// where renders the filter as extra "AND ..." predicates over alias (games g or
+// player_game_results pr) and seasons s, adding its named parameters to args.
+func (f CareerStatsFilter) where(alias string, args map[string]any) string {
+	var b strings.Builder
+	if f.SeasonID != nil {
+		b.WriteString("\n    AND " + alias + ".season_id = @seasonID")
+		args["seasonID"] = *f.SeasonID
+	}
+	if f.LeagueID != nil {
+		b.WriteString("\n    AND s.league_id = @leagueID")
+		args["leagueID"] = *f.LeagueID
+	}
+	if f.SeasonID == nil && f.LeagueID == nil && !f.IncludeExhibition {
+		b.WriteString("\n    AND " + alias + ".season_id IS NOT NULL")
+	}
+	if f.EndedFrom != nil {
+		b.WriteString("\n    AND " + alias + ".ended_at >= @endedFrom")
+		args["endedFrom"] = *f.EndedFrom
+	}
+	if f.EndedTo != nil {
+		b.WriteString("\n    AND " + alias + ".ended_at <= @endedTo")
+		args["endedTo"] = *f.EndedTo
+	}
+	if f.MatchType != nil && *f.MatchType != "" {
+		b.WriteString("\n    AND " + alias + ".match_type = @matchType")
+		args["matchType"] = *f.MatchType
+	}
+	if f.Location != nil && strings.TrimSpace(*f.Location) != "" {
+		b.WriteString("\n    AND LOWER(TRIM(" + alias + ".location)) = @location")
+		args["location"] = strings.ToLower(strings.TrimSpace(*f.Location))
+	}
+	return b.String()
+}
+
+// ResultRow is a participant's outcome in one completed game: "W", "L" or "T".
+type ResultRow struct {
+	ParticipantID int64      `gorm:"column:participant_id"`
+	GameID        int64      `gorm:"column:game_id"`
+	SeasonID      *int64     `gorm:"column:season_id"`
+	EndedAt       *time.Time `gorm:"column:ended_at"`
+	Result        string     `gorm:"column:result"`
+}
+
+// resultSelect labels each per_participant row. An explicit winner_side wins;
+// otherwise points decide, with equal points counting as a tie.
+const resultSelect = `
+SELECT
+  participant_id,
+  game_id,
+  season_id,
+  ended_at,
+  CASE
+    WHEN winner_side IS NOT NULL THEN CASE WHEN winner_side = side THEN 'W' ELSE 'L' END
+    WHEN pf > pa THEN 'W'
+    WHEN pf < pa THEN 'L'
+    ELSE 'T'
+  END AS result
+FROM per_participant
+`
+
+type ResultsFilter struct {
+	SeasonID       *int64     // nil: every season and exhibition
+	LeagueID       *int64     // only games in seasons of this league
+	ExhibitionOnly bool       // only games with no season
+	ParticipantID  *int64     // nil: every player/team
+	EndedBefore    *time.Time // only games with ended_at < EndedBefore
+	// ThroughMatchday (with SeasonID) keeps games on the season's matchdays 1..N.
+	ThroughMatchday *int
+}
+
+// where renders the filter over alias (games g or player_game_results pr).
+func (f ResultsFilter) where(alias string, args map[string]any) string {
+	where := ""
+	if f.SeasonID != nil {
+		where += "\n    AND " + alias + ".season_id = @seasonID"
+		args["seasonID"] = *f.SeasonID
+	}
+	if f.LeagueID != nil {
+		where += "\n    AND " + alias + ".season_id IN (SELECT id FROM seasons WHERE league_id = @leagueID)"
+		args["leagueID"] = *f.LeagueID
+	}
+	if f.ExhibitionOnly {
+		where += "\n    AND " + alias + ".season_id IS NULL"
+	}
+	if f.SeasonID != nil && f.ThroughMatchday != nil {
+		scope := StatsScope{Kind: ScopeSeason, ID: *f.SeasonID, ThroughMatchday: f.ThroughMatchday}
+		where += "\n    AND " + scope.matchdayPredicate(gameIDColumn(alias), args)
+	}
+	return where + endedBeforeClause(alias, f.EndedBefore, args)
+}
+
+// ListPlayerResults returns completed-game results per player, ordered by player then EndedAt.
+func (r *StatsRepository) ListPlayerResults(ctx context.Context, f ResultsFilter) ([]ResultRow, error) {
+	args := map[string]any{}
+	where := f.where("pr", args)
+	if f.ParticipantID != nil {
+		where += "\n    AND pr.player_id = @participantID"
+		args["participantID"] = *f.ParticipantID
+	}
+
+	sql := `
+SELECT
+  pr.player_id   AS participant_id,
+  pr.game_id     AS game_id,
+  pr.season_id   AS season_id,
+  pr.ended_at    AS ended_at,
+  pr.result      AS result
+FROM player_game_results pr
+WHERE
+  TRUE` + where + `
+ORDER BY participant_id, ended_at ASC NULLS FIRST, game_id ASC;`
+
+	var rows []ResultRow
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ListTeamResults returns completed-game results per team, ordered by team then EndedAt.
+func (r *StatsRepository) ListTeamResults(ctx context.Context, f ResultsFilter) ([]ResultRow, error) {
+	args := map[string]any{}
+	where := f.where("g", args)
+	if f.ParticipantID != nil {
+		where += "\n    AND gs.team_id = @teamID"
+		args["teamID"] = *f.ParticipantID
+	}
+
+	sql := `
+WITH per_participant AS (
+  SELECT
+    gs.team_id     AS participant_id,
+    g.id           AS game_id,
+    g.season_id    AS season_id,
+    g.ended_at     AS ended_at,
+    gs.side        AS side,
+    gs.points      AS pf,
+    opp.points     AS pa,
+    g.winner_side  AS winner_side
+  FROM games g
+  JOIN game_sides gs  ON gs.game_id = g.id AND gs.deleted_at IS NULL
+  JOIN game_sides opp ON opp.game_id = g.id AND opp.side <> gs.side AND opp.deleted_at IS NULL
+  WHERE
+    g.status = 'completed'
+    AND g.deleted_at IS NULL
+    AND g.match_type = 'teams'
+    AND gs.team_id IS NOT NULL` + where + `
+)` + resultSelect + "ORDER BY participant_id, ended_at ASC NULLS FIRST, game_id ASC;"
+
+	var rows []ResultRow
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GamePointsRow is a participant's line from one completed game, with the timezone
+// the game was played in (the game's own, falling back to its season's).
+type GamePointsRow struct {
+	GameID        int64      `gorm:"column:game_id"`
+	EndedAt       *time.Time `gorm:"column:ended_at"`
+	Timezone      string     `gorm:"column:timezone"`
+	PointsFor     int64      `gorm:"column:points_for"`
+	PointsAgainst int64      `gorm:"column:points_against"`
+	Twenties      int64      `gorm:"column:twenties"`
+	Result        string     `gorm:"column:result"`
+}
+
+// ListPlayerGamePoints returns the player's completed games oldest first.
+func (r *StatsRepository) ListPlayerGamePoints(ctx context.Context, playerID int64, f CareerStatsFilter) ([]GamePointsRow, error) {
+	args := map[string]any{"playerID": playerID}
+	where := f.where("pr", args)
+
+	sql := `
+SELECT
+  pr.game_id                                         AS game_id,
+  pr.ended_at                                        AS ended_at,
+  COALESCE(NULLIF(g.timezone, ''), s.timezone, 'UTC') AS timezone,
+  pr.points_for                                      AS points_for,
+  pr.points_against                                  AS points_against,
+  pr.twenties                                        AS twenties,
+  pr.result                                          AS result
+FROM player_game_results pr
+JOIN games g        ON g.id = pr.game_id
+LEFT JOIN seasons s ON s.id = pr.season_id
+WHERE
+  pr.player_id = @playerID` + where + `
+ORDER BY pr.ended_at ASC NULLS FIRST, pr.game_id ASC;`
+
+	var rows []GamePointsRow
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ListTeamGamePoints returns the team's completed games oldest first.
+func (r *StatsRepository) ListTeamGamePoints(ctx context.Context, teamID int64, f CareerStatsFilter) ([]GamePointsRow, error) {
+	args := map[string]any{"teamID": teamID}
+	where := f.where("g", args)
+
+	sql := `
+WITH per_participant AS (
+  SELECT
+    g.id                                                AS game_id,
+    g.ended_at                                          AS ended_at,
+    COALESCE(NULLIF(g.timezone, ''), s.timezone, 'UTC') AS timezone,
+    gs.side                                             AS side,
+    gs.points                                           AS pf,
+    opp.points                                          AS pa,
+    gs.twenties                                         AS twenties,
+    g.winner_side                                       AS winner_side
+  FROM games g
+  JOIN game_sides gs  ON gs.game_id = g.id AND gs.deleted_at IS NULL
+  JOIN game_sides opp ON opp.game_id = g.id AND opp.side <> gs.side AND opp.deleted_at IS NULL
+  LEFT JOIN seasons s ON s.id = g.season_id
+  WHERE
+    g.status = 'completed'
+    AND g.deleted_at IS NULL
+    AND g.match_type = 'teams'
+    AND gs.team_id = @teamID` + where + `
+)
+SELECT
+  game_id,
+  ended_at,
+  timezone,
+  pf       AS points_for,
+  pa       AS points_against,
+  twenties,
+  CASE
+    WHEN winner_side IS NOT NULL THEN CASE WHEN winner_side = side THEN 'W' ELSE 'L' END
+    WHEN pf > pa THEN 'W'
+    WHEN pf < pa THEN 'L'
+    ELSE 'T'
+  END AS result
+FROM per_participant
+ORDER BY ended_at ASC NULLS FIRST, game_id ASC;`
+
+	var rows []GamePointsRow
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GameDurationRow is one completed game's wall-clock length. Each game yields one
+// "game" row, straight from games, plus a row per player (team games expand to both
+// players) and per team for the participant splits.
+type GameDurationRow struct {
+	GameID          int64   `gorm:"column:game_id"`
+	MatchType       string  `gorm:"column:match_type"`
+	Location        string  `gorm:"column:location"` // lower-cased and trimmed; "Unknown" when empty
+	DurationSeconds float64 `gorm:"column:duration_seconds"`
+	Kind            string  `gorm:"column:kind"`           // "game" | "player" | "team"
+	ParticipantID   int64   `gorm:"column:participant_id"` // 0 on "game" rows
+}
+
+// ListSeasonGameDurations returns game and participant rows for the season's completed
+// games that have both StartedAt and EndedAt, ordered by game. Games with a
+// non-positive duration are skipped.
+func (r *StatsRepository) ListSeasonGameDurations(ctx context.Context, seasonID int64) ([]GameDurationRow, error) {
+	sql := `
+WITH timed AS (
+  SELECT
+    g.id                                           AS game_id,
+    g.match_type                                   AS match_type,
+    COALESCE(NULLIF(LOWER(TRIM(g.location)), ''), 'Unknown') AS location,
+    EXTRACT(EPOCH FROM (g.ended_at - g.started_at)) AS duration_seconds
+  FROM games g
+  WHERE
+    g.season_id = @seasonID
+    AND g.status = 'completed'
+    AND g.deleted_at IS NULL
+    AND g.started_at IS NOT NULL
+    AND g.ended_at > g.started_at
+)
+SELECT t.*, 'game' AS kind, 0::bigint AS participant_id
+FROM timed t
+
+UNION ALL
+
+SELECT t.*, 'player' AS kind, pr.player_id AS participant_id
+FROM timed t
+JOIN player_game_results pr ON pr.game_id = t.game_id
+
+UNION ALL
+
+SELECT t.*, 'team' AS kind, gs.team_id AS participant_id
+FROM timed t
+JOIN game_sides gs ON gs.game_id = t.game_id AND gs.deleted_at IS NULL
+WHERE gs.team_id IS NOT NULL
+
+ORDER BY game_id, kind, participant_id;`
+
+	var rows []GameDurationRow
+	if err := r.db.WithContext(ctx).Raw(sql, map[string]any{"seasonID": seasonID}).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// SideOutcomeRow is one completed game's colours and decisive side ("A", "B", or nil for a tie).
+type SideOutcomeRow struct {
+	GameID int64   `gorm:"column:game_id"`
+	ColorA string  `gorm:"column:color_a"`
+	ColorB string  `gorm:"column:color_b"`
+	Winner *string `gorm:"column:winner"`
+}
+
+// ListSideOutcomes returns every completed game matching f (season and/or league),
+// ordered by game. An explicit winner_side wins; otherwise points decide.
+func (r *StatsRepository) ListSideOutcomes(ctx context.Context, f ResultsFilter) ([]SideOutcomeRow, error) {
+	args := map[string]any{}
+	where := f.where("g", args)
+
+	sql := `
+SELECT
+  g.id     AS game_id,
+  a.color  AS color_a,
+  b.color  AS color_b,
+  CASE
+    WHEN g.winner_side IS NOT NULL THEN g.winner_side
+    WHEN a.points > b.points THEN 'A'
+    WHEN a.points < b.points THEN 'B'
+    ELSE NULL
+  END      AS winner
+FROM games g
+JOIN game_sides a ON a.game_id = g.id AND a.side = 'A' AND a.deleted_at IS NULL
+JOIN game_sides b ON b.game_id = g.id AND b.side = 'B' AND b.deleted_at IS NULL
+WHERE
+  g.status = 'completed'
+  AND g.deleted_at IS NULL` + where + `
+ORDER BY g.id;`
+
+	var rows []SideOutcomeRow
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ListPlayerPartners aggregates every completed team game the player has played, grouped by partner.
+func (r *StatsRepository) ListPlayerPartners(ctx context.Context, playerID int64) ([]models.PartnerStatsRow, error) {
+	sql := `
+WITH per_team AS (
+  -- Partner = the other player credited with the same team side
+  SELECT
+    mate.player_id          AS partner_id,
+    me.side                 AS side,
+    me.points_for           AS pf,
+    me.points_against       AS pa,
+    me.twenties             AS twenties,
+    me.winner_side          AS winner_side
+  FROM player_game_results me
+  JOIN player_game_results mate
+    ON mate.game_id = me.game_id
+   AND mate.side = me.side
+   AND mate.player_id <> me.player_id
+  WHERE
+    me.match_type = 'teams'
+    AND me.player_id = @playerID
+),
+agg AS (
+  SELECT
+    partner_id,
+    COUNT(*) AS games,
+    SUM(CASE WHEN winner_side = side THEN 1 ELSE 0 END) AS wins,
+    SUM(CASE WHEN winner_side <> side THEN 1 ELSE 0 END) AS losses,
+    SUM(pf)       AS points_for,
+    SUM(pa)       AS points_against,
+    SUM(twenties) AS twenties
+  FROM per_team
+  GROUP BY partner_id
+)
+SELECT
+  partner_id,
+  games,
+  wins,
+  losses,
+  points_for,
+  points_against,
+  points_for - points_against AS point_diff,
+  twenties,
+  CASE WHEN games = 0 THEN 0.0
+       ELSE wins::float / games::float
+  END AS win_pct
+FROM agg
+ORDER BY win_pct DESC, games DESC, partner_id ASC;
+`
+
+	type row struct {
+		PartnerID     int64   `gorm:"column:partner_id"`
+		Games         int64   `gorm:"column:games"`
+		Wins          int64   `gorm:"column:wins"`
+		Losses        int64   `gorm:"column:losses"`
+		PointsFor     int64   `gorm:"column:points_for"`
+		PointsAgainst int64   `gorm:"column:points_against"`
+		PointDiff     int64   `gorm:"column:point_diff"`
+		Twenties      int64   `gorm:"column:twenties"`
+		WinPct        float64 `gorm:"column:win_pct"`
+	}
+
+	var rows []row
+	if err := r.db.WithContext(ctx).Raw(sql, map[string]any{"playerID": playerID}).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]models.PartnerStatsRow, 0, len(rows))
+	for _, x := range rows {
+		out = append(out, models.PartnerStatsRow{
+			PartnerID:     x.PartnerID,
+			Games:         x.Games,
+			Wins:          x.Wins,
+			Losses:        x.Losses,
+			WinPct:        x.WinPct,
+			PointsFor:     x.PointsFor,
+			PointsAgainst: x.PointsAgainst,
+			PointDiff:     x.PointDiff,
+			Twenties:      x.Twenties,
+		})
+	}
+	return out, nil
+}
+
+type ListPairingsQuery struct {
+	LeagueID int64
+	MinGames int
+	Limit    int
+}
+
+// ListPairings ranks doubles pairs across every season of a league.
+func (r *StatsRepository) ListPairings(ctx context.Context, q ListPairingsQuery) ([]models.PairingStatsRow, error) {
+	if q.MinGames < 1 {
+		q.MinGames = 1
+	}
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 25
+	}
+
+	sql := `
+WITH per_pair AS (
+  SELECT
+    t.player_a_id           AS player_a_id,
+    t.player_b_id           AS player_b_id,
+    t.id                    AS team_id,
+    gs.side                 AS side,
+    gs.points               AS pf,
+    opp.points              AS pa,
+    gs.twenties             AS twenties,
+    g.winner_side           AS winner_side
+  FROM games g
+  JOIN seasons s      ON s.id = g.season_id
+  JOIN game_sides gs  ON gs.game_id = g.id AND gs.deleted_at IS NULL
+  JOIN game_sides opp ON opp.game_id = g.id AND opp.side <> gs.side AND opp.deleted_at IS NULL
+  JOIN teams t        ON t.id = gs.team_id
+  WHERE
+    g.status = 'completed'
+    AND g.deleted_at IS NULL
+    AND g.match_type = 'teams'
+    AND s.league_id = @leagueID
+),
+agg AS (
+  SELECT
+    player_a_id,
+    player_b_id,
+    MIN(team_id) AS team_id,
+    COUNT(*) AS games,
+    SUM(CASE WHEN winner_side = side THEN 1 ELSE 0 END) AS wins,
+    SUM(CASE WHEN winner_side <> side THEN 1 ELSE 0 END) AS losses,
+    SUM(pf)       AS points_for,
+    SUM(pa)       AS points_against,
+    SUM(twenties) AS twenties
+  FROM per_pair
+  GROUP BY player_a_id, player_b_id
+  HAVING COUNT(*) >= @minGames
+)
+SELECT
+  player_a_id,
+  player_b_id,
+  team_id,
+  games,
+  wins,
+  losses,
+  points_for,
+  points_against,
+  points_for - points_against AS point_diff,
+  twenties,
+  wins::float / games::float AS win_pct
+FROM agg
+ORDER BY win_pct DESC, point_diff DESC, games DESC, player_a_id ASC, player_b_id ASC
+LIMIT @limit;
+`
+
+	type row struct {
+		PlayerAID     int64   `gorm:"column:player_a_id"`
+		PlayerBID     int64   `gorm:"column:player_b_id"`
+		TeamID        int64   `gorm:"column:team_id"`
+		Games         int64   `gorm:"column:games"`
+		Wins          int64   `gorm:"column:wins"`
+		Losses        int64   `gorm:"column:losses"`
+		PointsFor     int64   `gorm:"column:points_for"`
+		PointsAgainst int64   `gorm:"column:points_against"`
+		PointDiff     int64   `gorm:"column:point_diff"`
+		Twenties      int64   `gorm:"column:twenties"`
+		WinPct        float64 `gorm:"column:win_pct"`
+	}
+
+	var rows []row
+	if err := r.db.WithContext(ctx).Raw(sql, map[string]any{
+		"leagueID": q.LeagueID,
+		"minGames": q.MinGames,
+		"limit":    q.Limit,
+	}).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]models.PairingStatsRow, 0, len(rows))
+	for _, x := range rows {
+		out = append(out, models.PairingStatsRow{
+			PlayerAID:     x.PlayerAID,
+			PlayerBID:     x.PlayerBID,
+			TeamID:        x.TeamID,
+			Games:         x.Games,
+			Wins:          x.Wins,
+			Losses:        x.Losses,
+			WinPct:        x.WinPct,
+			PointsFor:     x.PointsFor,
+			PointsAgainst: x.PointsAgainst,
+			PointDiff:     x.PointDiff,
+			Twenties:      x.Twenties,
+		})
+	}
+	return out, nil
+}
+
+// MatchupRow is one participant's result against one opponent in a completed game.
+// Team games expand to one row per (player, opposing player) for player matchups.
+type MatchupRow struct {
+	ParticipantID int64  `gorm:"column:participant_id"`
+	OpponentID    int64  `gorm:"column:opponent_id"`
+	GameID        int64  `gorm:"column:game_id"`
+	PointsFor     int64  `gorm:"column:pf"`
+	PointsAgainst int64  `gorm:"column:pa"`
+	Result        string `gorm:"column:result"`
+
+	EndedAt *time.Time `gorm:"column:ended_at"`
+}
+
+// matchupSelect pairs each per_side row with the opposing side(s) of the same game.
+const matchupSelect = `
+SELECT
+  a.participant_id,
+  b.participant_id AS opponent_id,
+  a.game_id,
+  a.points         AS pf,
+  b.points         AS pa,
+  a.ended_at,
+  CASE
+    WHEN a.winner_side IS NOT NULL THEN CASE WHEN a.winner_side = a.side THEN 'W' ELSE 'L' END
+    WHEN a.points > b.points THEN 'W'
+    WHEN a.points < b.points THEN 'L'
+    ELSE 'T'
+  END AS result
+FROM per_side a
+JOIN per_side b ON b.game_id = a.game_id AND b.side <> a.side
+ORDER BY a.participant_id, a.game_id;
+`
+
+// ListTeamMatchups returns team-vs-team results for the scope's completed games,
+// optionally only those that ended before asOf.
+func (r *StatsRepository) ListTeamMatchups(ctx context.Context, scope StatsScope, asOf *time.Time) ([]MatchupRow, error) {
+	args := map[string]any{}
+	asOfClause := scope.and("g", args) + endedBeforeClause("g", asOf, args)
+
+	sql := `
+WITH per_side AS (
+  SELECT
+    gs.team_id     AS participant_id,
+    g.id           AS game_id,
+    gs.side        AS side,
+    gs.points      AS points,
+    g.winner_side  AS winner_side,
+    g.ended_at     AS ended_at
+  FROM games g
+  JOIN game_sides gs ON gs.game_id = g.id AND gs.deleted_at IS NULL
+  WHERE
+    g.status = 'completed'
+    AND g.deleted_at IS NULL
+    AND g.match_type = 'teams'
+    AND gs.team_id IS NOT NULL` + asOfClause + `
+)` + matchupSelect
+
+	var rows []MatchupRow
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ListPlayerMatchups returns player-vs-player results for the scope's completed games,
+// expanding team sides to both of their players; optionally only those that ended before asOf.
+func (r *StatsRepository) ListPlayerMatchups(ctx context.Context, scope StatsScope, asOf *time.Time) ([]MatchupRow, error) {
+	args := map[string]any{}
+	asOfClause := scope.and("pr", args) + endedBeforeClause("pr", asOf, args)
+
+	sql := `
+WITH per_side AS (
+  SELECT
+    pr.player_id   AS participant_id,
+    pr.game_id     AS game_id,
+    pr.side        AS side,
+    pr.points_for  AS points,
+    pr.winner_side AS winner_side,
+    pr.ended_at    AS ended_at
+  FROM player_game_results pr
+  WHERE
+    TRUE` + asOfClause + `
+)` + matchupSelect
+
+	var rows []MatchupRow
+	if err := r.db.WithContext(ctx).Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// PlayerTotalsRow is a player's raw totals over one season's completed games.
+type PlayerTotalsRow struct {
+	PlayerID      int64 `gorm:"column:player_id"`
+	Games         int64 `gorm:"column:games"`
+	Wins          int64 `gorm:"column:wins"`
+	PointsFor     int64 `gorm:"column:points_for"`
+	PointsAgainst int64 `gorm:"column:points_against"`
+	Twenties      int64 `gorm:"column:twenties"`
+}
+
+// ListSeasonPlayerTotals aggregates player_game_results for one season. In team games
+// both players are credited with their side's twenties.
+func (r *StatsRepository) ListSeasonPlayerTotals(ctx context.Context, seasonID int64) ([]PlayerTotalsRow, error) {
+	sql := `
+SELECT
+  pr.player_id,
+  COUNT(DISTINCT pr.game_id)                          AS games,
+  COUNT(DISTINCT CASE WHEN pr.result = 'W' THEN pr.game_id END) AS wins,
+  COALESCE(SUM(pr.points_for), 0)                     AS points_for,
+  COALESCE(SUM(pr.points_against), 0)                 AS points_against,
+  COALESCE(SUM(pr.twenties), 0)                       AS twenties
+FROM player_game_results pr
+WHERE pr.season_id = @seasonID
+GROUP BY pr.player_id
+ORDER BY pr.player_id;
+`
+	var rows []PlayerTotalsRow
+	if err := r.db.WithContext(ctx).Raw(sql, map[string]any{"seasonID": seasonID}).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ListSeasonPairings aggregates every doubles pair's completed team games in one season.
+// Pairs are keyed with PlayerAID < PlayerBID.
+func (r *StatsRepository) ListSeasonPairings(ctx context.Context, seasonID int64) ([]models.PairingStatsRow, error) {
+	sql := `
+WITH per_pair AS (
+  SELECT
+    a.player_id       AS player_a_id,
+    b.player_id       AS player_b_id,
+    a.team_id         AS team_id,
+    a.result          AS result,
+    a.points_for      AS pf,
+    a.points_against  AS pa,
+    a.twenties        AS twenties
+  FROM player_game_results a
+  JOIN player_game_results b
+    ON b.game_id = a.game_id
+   AND b.side = a.side
+   AND b.player_id > a.player_id
+  WHERE
+    a.season_id = @seasonID
+    AND a.match_type = 'teams'
+)
+SELECT
+  player_a_id,
+  player_b_id,
+  MIN(team_id)                                   AS team_id,
+  COUNT(*)                                       AS games,
+  SUM(CASE WHEN result = 'W' THEN 1 ELSE 0 END)  AS wins,
+  SUM(CASE WHEN result = 'L' THEN 1 ELSE 0 END)  AS losses,
+  SUM(pf)                                        AS points_for,
+  SUM(pa)                                        AS points_against,
+  SUM(pf) - SUM(pa)                              AS point_diff,
+  SUM(twenties)                                  AS twenties,
+  SUM(CASE WHEN result = 'W' THEN 1 ELSE 0 END)::float / COUNT(*)::float AS win_pct
+FROM per_pair
+GROUP BY player_a_id, player_b_id
+ORDER BY player_a_id, player_b_id;
+`
+	type row struct {
+		PlayerAID     int64   `gorm:"column:player_a_id"`
+		PlayerBID     int64   `gorm:"column:player_b_id"`
+		TeamID        int64   `gorm:"column:team_id"`
+		Games         int64   `gorm:"column:games"`
+		Wins          int64   `gorm:"column:wins"`
+		Losses        int64   `gorm:"column:losses"`
+		PointsFor     int64   `gorm:"column:points_for"`
+		PointsAgainst int64   `gorm:"column:points_against"`
+		PointDiff     int64   `gorm:"column:point_diff"`
+		Twenties      int64   `gorm:"column:twenties"`
+		WinPct        float64 `gorm:"column:win_pct"`
+	}
+
+	var rows []row
+	if err := r.db.WithContext(ctx).Raw(sql, map[string]any{"seasonID": seasonID}).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]models.PairingStatsRow, 0, len(rows))
+	for _, x := range rows {
+		out = append(out, models.PairingStatsRow{
+			PlayerAID:     x.PlayerAID,
+			PlayerBID:     x.PlayerBID,
+			TeamID:        x.TeamID,
+			Games:         x.Games,
+			Wins:          x.Wins,
+			Losses:        x.Losses,
+			WinPct:        x.WinPct,
+			PointsFor:     x.PointsFor,
+			PointsAgainst: x.PointsAgainst,
+			PointDiff:     x.PointDiff,
+			Twenties:      x.Twenties,
+		})
+	}
+	return out, nil
+}
+
+// ListSeasonIDsForTeam returns the seasons the team is linked to or has played in,
+// whose cached standings and stats show its name.
+func (r *StatsRepository) ListSeasonIDsForTeam(ctx context.Context, teamID int64) ([]int64, error) {
+	return r.seasonIDs(ctx, `
+SELECT ts.season_id FROM team_seasons ts WHERE ts.team_id = @id
+UNION
+SELECT g.season_id
+FROM games g
+JOIN game_sides gs ON gs.game_id = g.id
+WHERE gs.team_id = @id AND g.season_id IS NOT NULL`, teamID)
+}
+
+// ListSeasonIDsForPlayer returns the seasons the player is rostered in, has played
+// in, or has a team linked to.
+func (r *StatsRepository) ListSeasonIDsForPlayer(ctx context.Context, playerID int64) ([]int64, error) {
+	return r.seasonIDs(ctx, `
+SELECT ptm.season_id FROM player_team_memberships ptm WHERE ptm.player_id = @id
+UNION
+SELECT pr.season_id FROM player_game_results pr WHERE pr.player_id = @id AND pr.season_id IS NOT NULL
+UNION
+SELECT ts.season_id
+FROM team_seasons ts
+JOIN teams t ON t.id = ts.team_id
+WHERE t.player_a_id = @id OR t.player_b_id = @id`, playerID)
+}
+
+// ListSeasonIDsForVenue returns the seasons with games at the venue.
+func (r *StatsRepository) ListSeasonIDsForVenue(ctx context.Context, venueID int64) ([]int64, error) {
+	return r.seasonIDs(ctx, `
+SELECT DISTINCT g.season_id FROM games g
+WHERE g.venue_id = @id AND g.season_id IS NOT NULL`, venueID)
+}
+
+func (r *StatsRepository) seasonIDs(ctx context.Context, sql string, id int64) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Raw(sql, map[string]any{"id": id}).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,91 @@
+package repositories
+
+// ScopeKind selects which games a stats or standings query covers.
+type ScopeKind string
+
+const (
+	ScopeSeason     ScopeKind = "season"     // one season
+	ScopeLeague     ScopeKind = "league"     // every season of one league
+	ScopeExhibition ScopeKind = "exhibition" // games with no season
+	ScopeGlobal     ScopeKind = "global"     // everything
+)
+
+// StatsScope is a ScopeKind plus the season or league it refers to.
+type StatsScope struct {
+	Kind ScopeKind
+	ID   int64 // season ID for ScopeSeason, league ID for ScopeLeague; unused otherwise
+
+	// ThroughMatchday limits a season scope to games on matchdays 1..N.
+	ThroughMatchday *int
+}
+
+func SeasonScope(seasonID int64) StatsScope {
+	return StatsScope{Kind: ScopeSeason, ID: seasonID}
+}
+
+// predicate renders the scope over a season_id column, adding @scopeID to args.
+// Global scope returns "" (no predicate).
+func (s StatsScope) predicate(col string, args map[string]any) string {
+	switch s.Kind {
+	case ScopeSeason:
+		args["scopeID"] = s.ID
+		return col + " = @scopeID"
+	case ScopeLeague:
+		args["scopeID"] = s.ID
+		return col + " IN (SELECT id FROM seasons WHERE league_id = @scopeID)"
+	case ScopeExhibition:
+		return col + " IS NULL"
+	default:
+		return ""
+	}
+}
+
+// matchdayPredicate renders ThroughMatchday over a game ID column, or "" when unset.
+func (s StatsScope) matchdayPredicate(gameCol string, args map[string]any) string {
+	if s.Kind != ScopeSeason || s.ThroughMatchday == nil {
+		return ""
+	}
+	args["scopeID"] = s.ID
+	args["throughMatchday"] = *s.ThroughMatchday
+	return gameCol + ` IN (
+      SELECT mg.id FROM games mg
+      JOIN matchdays md ON md.id = mg.matchday_id
+      WHERE md.season_id = @scopeID AND md.number <= @throughMatchday)`
+}
+
+// and renders the scope over alias (games g or player_game_results pr) as
+// "AND ..." lines, or "" for global scope.
+func (s StatsScope) and(alias string, args map[string]any) string {
+	out := ""
+	if pred := s.predicate(alias+".season_id", args); pred != "" {
+		out += "\n    AND " + pred
+	}
+	if pred := s.matchdayPredicate(gameIDColumn(alias), args); pred != "" {
+		out += "\n    AND " + pred
+	}
+	return out
+}
+
+// gameIDColumn is the game ID column of alias: g.id for games, <alias>.game_id otherwise.
+func gameIDColumn(alias string) string {
+	if alias == "g" {
+		return "g.id"
+	}
+	return alias + ".game_id"
+}
+
+// Results returns the ResultsFilter covering the same games.
+func (s StatsScope) Results() ResultsFilter {
+	switch s.Kind {
+	case ScopeSeason:
+		id := s.ID
+		return ResultsFilter{SeasonID: &id, ThroughMatchday: s.ThroughMatchday}
+	case ScopeLeague:
+		id := s.ID
+		return ResultsFilter{LeagueID: &id}
+	case ScopeExhibition:
+		return ResultsFilter{ExhibitionOnly: true}
+	default:
+		return ResultsFilter{}
+	}
+}
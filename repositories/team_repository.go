@@ -73,10 +73,22 @@ func (r *TeamRepository) UpdateFields(ctx context.Context, id int64, fields map[
 			fields["player_b_id"] = b
 		}
 	}
-	if err := r.db.WithContext(ctx).
-		Model(&models.Team{}).
-		Where("id = ?", id).
-		Updates(fields).Error; err != nil {
+	_, rosterChanged := fields["player_a_id"]
+	if _, ok := fields["player_b_id"]; ok {
+		rosterChanged = true
+	}
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Team{}).
+			Where("id = ?", id).
+			Updates(fields).Error; err != nil {
+			return err
+		}
+		// player_game_results attributes team games to the team's players
+		if rosterChanged {
+			return syncTeamGameResults(tx, id)
+		}
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 	return r.GetByID(ctx, id)
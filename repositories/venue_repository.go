@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+)
+
+type VenueRepository struct {
+	db *gorm.DB
+}
+
+func NewVenueRepository(db *gorm.DB) *VenueRepository {
+	return &VenueRepository{db: db}
+}
+
+type ListVenuesFilter struct {
+	Search string
+	Offset int
+	Limit  int
+}
+
+// Create inserts the venue and boards numbered 1..BoardCount in one transaction.
+func (r *VenueRepository) Create(ctx context.Context, v *models.Venue) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Boards").Create(v).Error; err != nil {
+			return err
+		}
+		return addBoards(tx, v.ID, 1, v.BoardCount)
+	})
+}
+
+// GetByID loads the venue with its boards in number order.
+func (r *VenueRepository) GetByID(ctx context.Context, id int64) (*models.Venue, error) {
+	var v models.Venue
+	if err := r.db.WithContext(ctx).
+		Preload("Boards", func(db *gorm.DB) *gorm.DB { return db.Order("number ASC") }).
+		First(&v, id).Error; err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// GetByName matches case-insensitively, ignoring surrounding whitespace.
+func (r *VenueRepository) GetByName(ctx context.Context, name string) (*models.Venue, error) {
+	var v models.Venue
+	if err := r.db.WithContext(ctx).
+		Where("LOWER(name) = ?", strings.ToLower(strings.TrimSpace(name))).
+		First(&v).Error; err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *VenueRepository) GetBoard(ctx context.Context, id int64) (*models.Board, error) {
+	var b models.Board
+	if err := r.db.WithContext(ctx).First(&b, id).Error; err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Update applies fields and, when boardCount is non-nil, adds or removes trailing
+// boards to match. Games on a removed board keep the venue and lose the board.
+// A rename is copied to the location of the venue's games and player results, so
+// location-based stats keep grouping by the venue.
+func (r *VenueRepository) Update(ctx context.Context, id int64, fields map[string]any, boardCount *int) (*models.Venue, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cur models.Venue
+		if err := tx.First(&cur, id).Error; err != nil {
+			return err
+		}
+		if boardCount != nil {
+			fields["board_count"] = *boardCount
+		}
+		if len(fields) > 0 {
+			if err := tx.Model(&models.Venue{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+				return err
+			}
+		}
+		if name, ok := fields["name"].(string); ok && name != cur.Name {
+			if err := tx.Exec(`UPDATE games SET location = ? WHERE venue_id = ?`, name, id).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`
+UPDATE player_game_results pr SET location = ?
+FROM games g WHERE g.id = pr.game_id AND g.venue_id = ?`, name, id).Error; err != nil {
+				return err
+			}
+		}
+		if boardCount == nil || *boardCount == cur.BoardCount {
+			return nil
+		}
+		if *boardCount > cur.BoardCount {
+			return addBoards(tx, id, cur.BoardCount+1, *boardCount)
+		}
+		if err := tx.Exec(`
+UPDATE games SET board_id = NULL
+WHERE board_id IN (SELECT id FROM boards WHERE venue_id = ? AND number > ?)`, id, *boardCount).Error; err != nil {
+			return err
+		}
+		return tx.Where("venue_id = ? AND number > ?", id, *boardCount).Delete(&models.Board{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+func (r *VenueRepository) UpdateBoard(ctx context.Context, id int64, fields map[string]any) (*models.Board, error) {
+	if err := r.db.WithContext(ctx).Model(&models.Board{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+		return nil, err
+	}
+	return r.GetBoard(ctx, id)
+}
+
+// DeleteByID soft-deletes the venue and unlinks its games. The games and their player
+// results keep the venue name as their location, so location stats are unchanged.
+func (r *VenueRepository) DeleteByID(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`UPDATE games SET venue_id = NULL, board_id = NULL WHERE venue_id = ?`, id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Venue{}, id).Error
+	})
+}
+
+func (r *VenueRepository) List(ctx context.Context, f ListVenuesFilter) ([]models.Venue, int64, error) {
+	var (
+		items []models.Venue
+		total int64
+	)
+
+	q := r.db.WithContext(ctx).Model(&models.Venue{}).Where("deleted_at IS NULL")
+
+	if s := strings.TrimSpace(f.Search); s != "" {
+		ilike := "%" + strings.ToLower(s) + "%"
+		q = q.Where("LOWER(name) LIKE ?", ilike)
+	}
+
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if f.Limit <= 0 || f.Limit > 100 {
+		f.Limit = 25
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+
+	if err := q.Order("name ASC, id ASC").Limit(f.Limit).Offset(f.Offset).Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// VenueBookingRow is a scheduled or in-progress game at a venue.
+// StartAt is started_at once the game is underway, else scheduled_at.
+type VenueBookingRow struct {
+	GameID  int64     `gorm:"column:game_id"`
+	BoardID *int64    `gorm:"column:board_id"`
+	Status  string    `gorm:"column:status"`
+	StartAt time.Time `gorm:"column:start_at"`
+	Draft   bool      `gorm:"column:draft"`
+}
+
+// ListVenueBookings mirrors GameRepository.ListPlayerBookings for a venue: games other
+// than excludeGameID starting before `to`, scheduled ones only if they start after `from`.
+func (r *VenueRepository) ListVenueBookings(ctx context.Context, venueID int64, from, to time.Time, excludeGameID int64) ([]VenueBookingRow, error) {
+	const q = `
+SELECT g.id AS game_id, g.board_id, g.status,
+       COALESCE(g.started_at, g.scheduled_at) AS start_at, g.draft
+FROM games g
+WHERE g.deleted_at IS NULL
+  AND g.venue_id = @venue
+  AND g.id <> @exclude
+  AND g.status IN ('scheduled', 'in_progress')
+  AND COALESCE(g.started_at, g.scheduled_at) < @to
+  AND (g.status = 'in_progress' OR COALESCE(g.started_at, g.scheduled_at) > @from)
+ORDER BY start_at, g.id`
+
+	var rows []VenueBookingRow
+	err := r.db.WithContext(ctx).Raw(q, map[string]any{
+		"venue":   venueID,
+		"exclude": excludeGameID,
+		"from":    from,
+		"to":      to,
+	}).Scan(&rows).Error
+	return rows, err
+}
+
+func addBoards(tx *gorm.DB, venueID int64, from, to int) error {
+	if to < from {
+		return nil
+	}
+	boards := make([]models.Board, 0, to-from+1)
+	for n := from; n <= to; n++ {
+		boards = append(boards, models.Board{VenueID: venueID, Number: n})
+	}
+	return tx.Create(&boards).Error
+}
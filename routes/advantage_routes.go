@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/handlers"
+)
+
+// Public colour/side advantage routes (no auth)
+func RegisterAdvantagePublicRoutes(rg *gin.RouterGroup, h *handlers.AdvantageHandler) {
+	// GET /api/v1/seasons/:seasonId/stats/advantage
+	rg.GET("/seasons/:seasonId/stats/advantage", h.SeasonAdvantage)
+
+	// GET /api/v1/leagues/:id/stats/advantage
+	rg.GET("/leagues/:id/stats/advantage", h.LeagueAdvantage)
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/handlers"
+)
+
+// Public availability routes (no auth)
+func RegisterAvailabilityPublicRoutes(rg *gin.RouterGroup, h *handlers.AvailabilityHandler) {
+	// GET /api/v1/seasons/:seasonId/availability
+	rg.GET("/seasons/:seasonId/availability", h.List)
+
+	// GET /api/v1/seasons/:seasonId/conflicts
+	rg.GET("/seasons/:seasonId/conflicts", h.SeasonConflicts)
+}
+
+// Protected availability routes (require auth)
+func RegisterAvailabilityProtectedRoutes(rg *gin.RouterGroup, h *handlers.AvailabilityHandler) {
+	// POST /api/v1/seasons/:seasonId/availability
+	rg.POST("/seasons/:seasonId/availability", h.Create)
+
+	// DELETE /api/v1/seasons/:seasonId/availability/:availabilityId
+	rg.DELETE("/seasons/:seasonId/availability/:availabilityId", h.Delete)
+}
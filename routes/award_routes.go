@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/handlers"
+)
+
+// Public award routes (no auth)
+func RegisterAwardPublicRoutes(rg *gin.RouterGroup, h *handlers.AwardHandler) {
+	g := rg.Group("/seasons")
+
+	// GET /api/v1/seasons/:seasonId/awards
+	g.GET("/:seasonId/awards", h.SeasonAwards)
+}
+
+// Protected award routes (auth required)
+func RegisterAwardProtectedRoutes(rg *gin.RouterGroup, h *handlers.AwardHandler) {
+	g := rg.Group("/seasons")
+	g.PUT("/:seasonId/awards/settings", h.UpdateSettings) // body: {"awards":[{"award","enabled","minGames"}]}
+	g.POST("/:seasonId/awards/:award/lock", h.Lock)       // body (optional): {"playerId","partnerId"}
+	g.DELETE("/:seasonId/awards/:award/lock", h.Unlock)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/handlers"
+)
+
+// Public iCalendar feeds (no auth, so calendar apps can subscribe)
+func RegisterCalendarPublicRoutes(rg *gin.RouterGroup, h *handlers.CalendarHandler) {
+	// GET /api/v1/seasons/:seasonId/calendar.ics
+	rg.GET("/seasons/:seasonId/calendar.ics", h.Season)
+
+	// GET /api/v1/teams/:id/calendar.ics
+	rg.GET("/teams/:id/calendar.ics", h.Team)
+
+	// GET /api/v1/players/:id/calendar.ics
+	rg.GET("/players/:id/calendar.ics", h.Player)
+}
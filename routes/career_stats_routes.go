@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/handlers"
+)
+
+// Public Career Stats routes (no auth)
+func RegisterCareerStatsPublicRoutes(rg *gin.RouterGroup, h *handlers.CareerStatsHandler) {
+	// GET /api/v1/players/:id/stats
+	rg.GET("/players/:id/stats", h.PlayerCareerStats)
+
+	// GET /api/v1/teams/:id/stats
+	rg.GET("/teams/:id/stats", h.TeamCareerStats)
+
+	// GET /api/v1/players/:id/form
+	rg.GET("/players/:id/form", h.PlayerForm)
+
+	// GET /api/v1/teams/:id/form
+	rg.GET("/teams/:id/form", h.TeamForm)
+
+	// GET /api/v1/players/:id/timeseries?metric=&bucket=&window=
+	rg.GET("/players/:id/timeseries", h.PlayerTimeseries)
+
+	// GET /api/v1/teams/:id/timeseries?metric=&bucket=&window=
+	rg.GET("/teams/:id/timeseries", h.TeamTimeseries)
+
+	// GET /api/v1/players/:id/partners
+	rg.GET("/players/:id/partners", h.PlayerPartners)
+
+	// GET /api/v1/leagues/:id/pairings?minGames=&limit=
+	rg.GET("/leagues/:id/pairings", h.LeaguePairings)
+}
@@ -19,5 +19,7 @@ func RegisterGameProtectedRoutes(rg *gin.RouterGroup, h *handlers.GameHandler) {
 	g.POST("", h.Create)    // POST /api/v1/games
 	g.PUT("/:id", h.Update) // PUT /api/v1/games/:id
 	g.DELETE("/:id", h.Delete)
-	g.POST("/:id/complete", h.Complete) // POST /api/v1/games/:id/complete
+	g.POST("/:id/complete", h.Complete)          // POST /api/v1/games/:id/complete
+	g.POST("/:id/publish", h.Publish)            // POST /api/v1/games/:id/publish
+	g.POST("/bulk-reschedule", h.BulkReschedule) // POST /api/v1/games/bulk-reschedule
 }
@@ -15,7 +15,9 @@ func RegisterGameSidePublicRoutes(rg *gin.RouterGroup, h *handlers.GameSideHandl
 func RegisterGameSideProtectedRoutes(rg *gin.RouterGroup, h *handlers.GameSideHandler) {
 	g := rg.Group("/games")
 	// :id is game id; :side is "A" or "B"
-	g.PUT("/:id/sides/:side/color", h.SetColor)        // PUT /api/v1/games/:id/sides/:side/color
-	g.POST("/:id/sides/:side/points/add", h.AddPoints) // POST /api/v1/games/:id/sides/:side/points/add
-	g.PUT("/:id/sides/:side/points", h.SetPoints)      // PUT /api/v1/games/:id/sides/:side/points
+	g.PUT("/:id/sides/:side/color", h.SetColor)             // PUT /api/v1/games/:id/sides/:side/color
+	g.POST("/:id/sides/:side/points/add", h.AddPoints)      // POST /api/v1/games/:id/sides/:side/points/add
+	g.PUT("/:id/sides/:side/points", h.SetPoints)           // PUT /api/v1/games/:id/sides/:side/points
+	g.PUT("/:id/sides/:side/twenties", h.SetTwenties)       // PUT /api/v1/games/:id/sides/:side/twenties
+	g.PUT("/:id/sides/:side/participant", h.SetParticipant) // PUT /api/v1/games/:id/sides/:side/participant
 }
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/handlers"
+)
+
+// Public recurring game template routes (no auth)
+func RegisterGameTemplatePublicRoutes(rg *gin.RouterGroup, h *handlers.GameTemplateHandler) {
+	g := rg.Group("/game-templates")
+	g.GET("", h.List)
+	g.GET("/:id", h.Get)
+}
+
+// Protected recurring game template routes (auth required): CRUD + materialize now
+func RegisterGameTemplateProtectedRoutes(rg *gin.RouterGroup, h *handlers.GameTemplateHandler) {
+	g := rg.Group("/game-templates")
+	g.POST("", h.Create)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+	g.POST("/:id/materialize", h.Materialize)
+}
@@ -5,11 +5,12 @@ import (
 	"github.com/matt-j-deasy/betty-crokers-api/handlers"
 )
 
-// Public League routes (no auth): GET collection + GET by id
+// Public League routes (no auth): GET collection + GET by id + records book
 func RegisterLeaguePublicRoutes(rg *gin.RouterGroup, h *handlers.LeagueHandler) {
 	g := rg.Group("/leagues")
 	g.GET("", h.List)
 	g.GET("/:id", h.Get)
+	g.GET("/:id/records", h.Records)
 }
 
 // Protected League routes (auth required): create/update/delete
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/handlers"
+)
+
+// Public matchday routes (no auth): list, fixtures/results, standings through a matchday
+func RegisterMatchdayPublicRoutes(rg *gin.RouterGroup, h *handlers.MatchdayHandler) {
+	g := rg.Group("/seasons/:seasonId/matchdays")
+	g.GET("", h.List)
+	g.GET("/:number", h.Get)
+	g.GET("/:number/standings", h.Standings)
+}
+
+// Protected matchday routes (auth required): create/update/delete + bulk postpone + publish
+func RegisterMatchdayProtectedRoutes(rg *gin.RouterGroup, h *handlers.MatchdayHandler) {
+	g := rg.Group("/seasons/:seasonId/matchdays")
+	g.POST("", h.Create)
+	g.PUT("/:number", h.Update)
+	g.DELETE("/:number", h.Delete)
+	g.POST("/:number/postpone", h.Postpone)
+	g.POST("/:number/publish", h.Publish)
+}
@@ -24,6 +24,9 @@ func SetupRouter(handlers *handlers.HandlersCollection, cfg config.Environment)
 }
 
 func registerRoutes(apiV1 *gin.RouterGroup, cfg config.Environment, handlers *handlers.HandlersCollection) {
+	// Identify callers with a token so public reads can include drafts for admins
+	apiV1.Use(middleware.OptionalAuthMiddleware(cfg))
+
 	// Public
 	apiV1.GET("/health", handlers.HealthCheckHandler.HealthCheck)
 	RegisterPlayerPublicRoutes(apiV1, handlers.PlayerHandler)
@@ -34,6 +37,14 @@ func registerRoutes(apiV1 *gin.RouterGroup, cfg config.Environment, handlers *ha
 	RegisterGamePublicRoutes(apiV1, handlers.GameHandler)
 	RegisterGameSidePublicRoutes(apiV1, handlers.GameSideHandler)
 	RegisterSeasonStatsPublicRoutes(apiV1, handlers.SeasonStatsHandler)
+	RegisterCareerStatsPublicRoutes(apiV1, handlers.CareerStatsHandler)
+	RegisterAwardPublicRoutes(apiV1, handlers.AwardHandler)
+	RegisterAdvantagePublicRoutes(apiV1, handlers.AdvantageHandler)
+	RegisterAvailabilityPublicRoutes(apiV1, handlers.AvailabilityHandler)
+	RegisterVenuePublicRoutes(apiV1, handlers.VenueHandler)
+	RegisterCalendarPublicRoutes(apiV1, handlers.CalendarHandler)
+	RegisterMatchdayPublicRoutes(apiV1, handlers.MatchdayHandler)
+	RegisterGameTemplatePublicRoutes(apiV1, handlers.GameTemplateHandler)
 
 	// Auth
 	RegisterAuthRoutes(apiV1, handlers.AuthHandler)
@@ -50,4 +61,9 @@ func registerRoutes(apiV1 *gin.RouterGroup, cfg config.Environment, handlers *ha
 	RegisterTeamSeasonProtectedRoutes(protected, handlers.TeamSeasonHandler)
 	RegisterGameProtectedRoutes(protected, handlers.GameHandler)
 	RegisterGameSideProtectedRoutes(protected, handlers.GameSideHandler)
+	RegisterAwardProtectedRoutes(protected, handlers.AwardHandler)
+	RegisterAvailabilityProtectedRoutes(protected, handlers.AvailabilityHandler)
+	RegisterVenueProtectedRoutes(protected, handlers.VenueHandler)
+	RegisterMatchdayProtectedRoutes(protected, handlers.MatchdayHandler)
+	RegisterGameTemplateProtectedRoutes(protected, handlers.GameTemplateHandler)
 }
@@ -9,10 +9,11 @@ import (
 func RegisterSeasonPublicRoutes(rg *gin.RouterGroup, h *handlers.SeasonHandler) {
 	g := rg.Group("/seasons")
 
-	g.GET("", h.List)          // GET /api/v1/seasons?q=&page=&size=&leagueId=
-	g.GET("/:seasonId", h.Get) // GET /api/v1/seasons/:seasonId
-	g.GET("/:seasonId/standings", h.Standings)
-	g.GET("/:seasonId/standings/players", h.ListPlayerStandings)
+	g.GET("", h.List)                                            // GET /api/v1/seasons?q=&page=&size=&leagueId=
+	g.GET("/:seasonId", h.Get)                                   // GET /api/v1/seasons/:seasonId
+	g.GET("/:seasonId/standings", h.Standings)                   // ?asOf=RFC3339
+	g.GET("/:seasonId/standings/players", h.ListPlayerStandings) // ?asOf=RFC3339&limit=&cursor=
+	g.GET("/:seasonId/standings/progression", h.RankProgression) // ?type=teams|players
 }
 
 // Protected Season routes (auth required)
@@ -21,4 +22,6 @@ func RegisterSeasonProtectedRoutes(rg *gin.RouterGroup, h *handlers.SeasonHandle
 	g.POST("", h.Create)             // POST /api/v1/seasons
 	g.PUT("/:seasonId", h.Update)    // PUT /api/v1/seasons/:seasonId
 	g.DELETE("/:seasonId", h.Delete) // DELETE /api/v1/seasons/:seasonId
+
+	g.POST("/:seasonId/schedule/publish", h.PublishSchedule) // publish all draft matchdays and games
 }
@@ -14,4 +14,14 @@ func RegisterSeasonStatsPublicRoutes(rg *gin.RouterGroup, h *handlers.SeasonStat
 
 	// GET /api/v1/seasons/:seasonId/stats/teams
 	g.GET("/:seasonId/stats/teams", h.ListSeasonTeamStats)
+
+	// GET /api/v1/seasons/:seasonId/stats/duration?staleAfterMinutes=
+	g.GET("/:seasonId/stats/duration", h.SeasonDurationStats)
+
+	// Scoped stats: ?scope=season|league|exhibition|global&seasonId=&leagueId=
+	s := rg.Group("/stats")
+	s.GET("/players", h.ScopedPlayerStats)
+	s.GET("/teams", h.ScopedTeamStats)
+	s.GET("/standings", h.ScopedStandings)
+	s.GET("/standings/players", h.ScopedPlayerStandings)
 }
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/matt-j-deasy/betty-crokers-api/handlers"
+)
+
+// Public Venue routes (no auth): GET collection + GET by id (with boards)
+func RegisterVenuePublicRoutes(rg *gin.RouterGroup, h *handlers.VenueHandler) {
+	g := rg.Group("/venues")
+	g.GET("", h.List)
+	g.GET("/:id", h.Get)
+}
+
+// Protected Venue routes (auth required): create/update/delete + board names
+func RegisterVenueProtectedRoutes(rg *gin.RouterGroup, h *handlers.VenueHandler) {
+	g := rg.Group("/venues")
+	g.POST("", h.Create)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+	g.PUT("/:id/boards/:boardId", h.RenameBoard)
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"math"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+// AdvantageService answers whether disc colour or side A/B confers an edge.
+type AdvantageService struct {
+	repos *repositories.RepositoriesCollection
+}
+
+func NewAdvantageService(repos *repositories.RepositoriesCollection) *AdvantageService {
+	return &AdvantageService{repos: repos}
+}
+
+// significanceLevel is the p-value below which a difference is flagged as significant.
+const significanceLevel = 0.05
+
+// colorOrder fixes the display order and names each matchup "first vs second".
+var colorOrder = []models.DiscColor{models.DiscWhite, models.DiscBlack, models.DiscNatural}
+
+// -------- DTOs
+
+// ChiSquare is a Pearson chi-square test result.
+type ChiSquare struct {
+	Statistic   float64 `json:"statistic"`
+	DF          int     `json:"df"`
+	PValue      float64 `json:"pValue"`
+	Significant bool    `json:"significant"` // PValue < 0.05
+}
+
+// OutcomeSplit counts results for sides sharing a colour or a side label.
+// WinPct counts ties as half a win.
+type OutcomeSplit struct {
+	Key    string  `json:"key"`
+	Games  int64   `json:"games"`
+	Wins   int64   `json:"wins"`
+	Losses int64   `json:"losses"`
+	Ties   int64   `json:"ties"`
+	WinPct float64 `json:"winPct"`
+}
+
+// MatchupSplit is every game between two colours, from First's point of view.
+// Test is nil for same-colour matchups or when no game was decisive.
+type MatchupSplit struct {
+	Matchup     string     `json:"matchup"` // e.g. "white vs black"
+	First       string     `json:"first"`
+	Second      string     `json:"second"`
+	Games       int64      `json:"games"`
+	FirstWins   int64      `json:"firstWins"`
+	SecondWins  int64      `json:"secondWins"`
+	Ties        int64      `json:"ties"`
+	FirstWinPct float64    `json:"firstWinPct"`
+	Test        *ChiSquare `json:"test"`
+}
+
+type AdvantageAnalysis struct {
+	SeasonID *int64 `json:"seasonId,omitempty"`
+	LeagueID *int64 `json:"leagueId,omitempty"`
+	Games    int64  `json:"games"`
+
+	// ByColor counts each side of mixed-colour games; a same-colour game would add a
+	// win and a loss to one colour and says nothing about it. ColorTest is white's
+	// wins against black's over decisive white-vs-black games, one per game.
+	ByColor   []OutcomeSplit `json:"byColor"`
+	ColorTest *ChiSquare     `json:"colorTest"`
+
+	// BySide tests side A's wins against an even split over decisive games.
+	BySide   []OutcomeSplit `json:"bySide"`
+	SideTest *ChiSquare     `json:"sideTest"`
+
+	ByMatchup []MatchupSplit `json:"byMatchup"`
+}
+
+// -------- Operations
+
+func (s *AdvantageService) GetSeasonAdvantage(ctx context.Context, seasonID int64) (*AdvantageAnalysis, error) {
+	if _, err := s.repos.SeasonRepo.GetByID(ctx, seasonID); err != nil {
+		return nil, err
+	}
+	rows, err := s.repos.StatsRepo.ListSideOutcomes(ctx, repositories.ResultsFilter{SeasonID: &seasonID})
+	if err != nil {
+		return nil, err
+	}
+	out := buildAdvantage(rows)
+	out.SeasonID = &seasonID
+	return out, nil
+}
+
+func (s *AdvantageService) GetLeagueAdvantage(ctx context.Context, leagueID int64) (*AdvantageAnalysis, error) {
+	if _, err := s.repos.LeagueRepo.GetByID(ctx, leagueID); err != nil {
+		return nil, err
+	}
+	rows, err := s.repos.StatsRepo.ListSideOutcomes(ctx, repositories.ResultsFilter{LeagueID: &leagueID})
+	if err != nil {
+		return nil, err
+	}
+	out := buildAdvantage(rows)
+	out.LeagueID = &leagueID
+	return out, nil
+}
+
+// -------- Internal
+
+func buildAdvantage(rows []repositories.SideOutcomeRow) *AdvantageAnalysis {
+	out := &AdvantageAnalysis{Games: int64(len(rows))}
+
+	byColor := map[string]*OutcomeSplit{}
+	bySide := map[string]*OutcomeSplit{"A": {Key: "A"}, "B": {Key: "B"}}
+	byMatchup := map[string]*MatchupSplit{}
+
+	record := func(sp *OutcomeSplit, side string, winner *string) {
+		sp.Games++
+		switch {
+		case winner == nil:
+			sp.Ties++
+		case *winner == side:
+			sp.Wins++
+		default:
+			sp.Losses++
+		}
+	}
+
+	for _, r := range rows {
+		for _, sd := range []struct{ side, color string }{{"A", r.ColorA}, {"B", r.ColorB}} {
+			record(bySide[sd.side], sd.side, r.Winner)
+			if r.ColorA == r.ColorB {
+				continue
+			}
+			sp, ok := byColor[sd.color]
+			if !ok {
+				sp = &OutcomeSplit{Key: sd.color}
+				byColor[sd.color] = sp
+			}
+			record(sp, sd.side, r.Winner)
+		}
+
+		// Orient the matchup so First is the earlier colour in colorOrder.
+		first, second, firstSide := r.ColorA, r.ColorB, "A"
+		if colorRank(second) < colorRank(first) {
+			first, second, firstSide = second, first, "B"
+		}
+		key := first + " vs " + second
+		m, ok := byMatchup[key]
+		if !ok {
+			m = &MatchupSplit{Matchup: key, First: first, Second: second}
+			byMatchup[key] = m
+		}
+		m.Games++
+		switch {
+		case r.Winner == nil:
+			m.Ties++
+		case *r.Winner == firstSide:
+			m.FirstWins++
+		default:
+			m.SecondWins++
+		}
+	}
+
+	out.ByColor = []OutcomeSplit{}
+	for _, c := range colorOrder {
+		if sp, ok := byColor[string(c)]; ok {
+			out.ByColor = append(out.ByColor, finishSplit(sp))
+		}
+	}
+
+	out.BySide = []OutcomeSplit{finishSplit(bySide["A"]), finishSplit(bySide["B"])}
+	out.SideTest = chiSquareEvenSplit(bySide["A"].Wins, bySide["B"].Wins)
+
+	out.ByMatchup = []MatchupSplit{}
+	for i, a := range colorOrder {
+		for _, b := range colorOrder[i:] {
+			m, ok := byMatchup[string(a)+" vs "+string(b)]
+			if !ok {
+				continue
+			}
+			m.FirstWinPct = tieHalfPct(m.FirstWins, m.Ties, m.Games)
+			if m.First != m.Second {
+				m.Test = chiSquareEvenSplit(m.FirstWins, m.SecondWins)
+			}
+			if a == models.DiscWhite && b == models.DiscBlack {
+				out.ColorTest = m.Test
+			}
+			out.ByMatchup = append(out.ByMatchup, *m)
+		}
+	}
+	return out
+}
+
+func colorRank(c string) int {
+	for i, oc := range colorOrder {
+		if string(oc) == c {
+			return i
+		}
+	}
+	return len(colorOrder)
+}
+
+func finishSplit(sp *OutcomeSplit) OutcomeSplit {
+	sp.WinPct = tieHalfPct(sp.Wins, sp.Ties, sp.Games)
+	return *sp
+}
+
+func tieHalfPct(wins, ties, games int64) float64 {
+	if games == 0 {
+		return 0
+	}
+	return (float64(wins) + 0.5*float64(ties)) / float64(games)
+}
+
+// chiSquareEvenSplit tests two win counts against a 50/50 expectation (df = 1).
+func chiSquareEvenSplit(a, b int64) *ChiSquare {
+	n := float64(a + b)
+	if n == 0 {
+		return nil
+	}
+	exp := n / 2
+	stat := (float64(a)-exp)*(float64(a)-exp)/exp + (float64(b)-exp)*(float64(b)-exp)/exp
+	return newChiSquare(stat, 1)
+}
+
+func newChiSquare(stat float64, df int) *ChiSquare {
+	p := chiSquarePValue(stat, df)
+	return &ChiSquare{
+		Statistic:   math.Round(stat*10000) / 10000,
+		DF:          df,
+		PValue:      math.Round(p*10000) / 10000,
+		Significant: p < significanceLevel,
+	}
+}
+
+// chiSquarePValue is the upper tail P(X >= x) for a chi-square with df degrees of
+// freedom, i.e. the regularized upper incomplete gamma Q(df/2, x/2).
+func chiSquarePValue(x float64, df int) float64 {
+	if x <= 0 {
+		return 1
+	}
+	a, z := float64(df)/2, x/2
+	lg, _ := math.Lgamma(a)
+
+	if z < a+1 {
+		// Series for P(a, z); Q = 1 - P.
+		sum, term := 1/a, 1/a
+		for n := 1; n < 500; n++ {
+			term *= z / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-14 {
+				break
+			}
+		}
+		return 1 - sum*math.Exp(-z+a*math.Log(z)-lg)
+	}
+
+	// Continued fraction for Q(a, z) (modified Lentz).
+	const tiny = 1e-300
+	b := z + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < 500; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < 1e-14 {
+			break
+		}
+	}
+	return math.Exp(-z+a*math.Log(z)-lg) * h
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+func TestChiSquarePValue(t *testing.T) {
+	tests := []struct {
+		x    float64
+		df   int
+		want float64
+	}{
+		{0, 1, 1},
+		{-1, 1, 1},
+		{1, 1, 0.3173},
+		{3.8415, 1, 0.05},  // series branch
+		{6.6349, 1, 0.01},  // continued-fraction branch
+		{5.9915, 2, 0.05},  // df = 2 is exactly exp(-x/2)
+		{18.307, 10, 0.05}, // series branch, larger df
+		{30, 3, 1.38e-6},
+	}
+	for _, tt := range tests {
+		got := chiSquarePValue(tt.x, tt.df)
+		if math.Abs(got-tt.want) > 1e-4*math.Max(tt.want, 0.01) {
+			t.Errorf("chiSquarePValue(%v, %d) = %.6g, want %.6g", tt.x, tt.df, got, tt.want)
+		}
+	}
+}
+
+func TestChiSquareEvenSplit(t *testing.T) {
+	tests := []struct {
+		name            string
+		a, b            int64
+		wantNil         bool
+		wantStat        float64
+		wantSignificant bool
+	}{
+		{name: "no decisive games", wantNil: true},
+		{name: "even", a: 10, b: 10, wantStat: 0},
+		{name: "order does not matter", a: 4, b: 16, wantStat: 7.2, wantSignificant: true},
+		{name: "lopsided", a: 16, b: 4, wantStat: 7.2, wantSignificant: true},
+		{name: "not enough to call", a: 12, b: 8, wantStat: 0.8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := chiSquareEvenSplit(tt.a, tt.b)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("got %+v, want nil", *got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("got nil")
+			}
+			if got.DF != 1 || got.Statistic != tt.wantStat || got.Significant != tt.wantSignificant {
+				t.Errorf("got %+v, want statistic %v significant %v", *got, tt.wantStat, tt.wantSignificant)
+			}
+		})
+	}
+}
+
+func TestBuildAdvantageColor(t *testing.T) {
+	a, b := "A", "B"
+	var rows []repositories.SideOutcomeRow
+	// 15 white-vs-black games: white wins 12, black wins 2, one tie.
+	for i := 0; i < 12; i++ {
+		rows = append(rows, repositories.SideOutcomeRow{ColorA: "white", ColorB: "black", Winner: &a})
+	}
+	rows = append(rows,
+		repositories.SideOutcomeRow{ColorA: "black", ColorB: "white", Winner: &a},
+		repositories.SideOutcomeRow{ColorA: "black", ColorB: "white", Winner: &a},
+		repositories.SideOutcomeRow{ColorA: "white", ColorB: "black"},
+	)
+	// Same-colour games must not move the colour splits or the test.
+	for i := 0; i < 20; i++ {
+		rows = append(rows, repositories.SideOutcomeRow{ColorA: "black", ColorB: "black", Winner: &b})
+	}
+
+	out := buildAdvantage(rows)
+
+	if out.Games != 35 {
+		t.Errorf("Games = %d, want 35", out.Games)
+	}
+	if len(out.ByColor) != 2 {
+		t.Fatalf("ByColor = %+v, want white and black", out.ByColor)
+	}
+	white, black := out.ByColor[0], out.ByColor[1]
+	if white.Key != "white" || white.Games != 15 || white.Wins != 12 || white.Losses != 2 || white.Ties != 1 {
+		t.Errorf("white = %+v", white)
+	}
+	if black.Key != "black" || black.Games != 15 || black.Wins != 2 || black.Losses != 12 || black.Ties != 1 {
+		t.Errorf("black = %+v", black)
+	}
+
+	// One observation per decisive game: 12 vs 2 gives (12-7)²/7 * 2.
+	want := chiSquareEvenSplit(12, 2)
+	if out.ColorTest == nil || *out.ColorTest != *want {
+		t.Errorf("ColorTest = %+v, want %+v", out.ColorTest, *want)
+	}
+
+	// Side A won 14 and side B 20; same-colour games still count towards sides.
+	if out.SideTest == nil || *out.SideTest != *chiSquareEvenSplit(14, 20) {
+		t.Errorf("SideTest = %+v", out.SideTest)
+	}
+}
@@ -0,0 +1,535 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+type AvailabilityService struct {
+	repos        *repositories.RepositoriesCollection
+	gameDuration time.Duration // expected length of a game, for double-booking
+}
+
+func NewAvailabilityService(repos *repositories.RepositoriesCollection, gameDuration time.Duration) *AvailabilityService {
+	return &AvailabilityService{repos: repos, gameDuration: gameDuration}
+}
+
+// -------- DTOs
+
+type CreateAvailabilityInput struct {
+	SeasonID  int64                   `json:"-"`
+	PlayerID  *int64                  `json:"playerId,omitempty"`
+	TeamID    *int64                  `json:"teamId,omitempty"`
+	Kind      models.AvailabilityKind `json:"kind"`                // "blackout" | "preferred"
+	StartsOn  *string                 `json:"startsOn,omitempty"`  // blackout: "YYYY-MM-DD"
+	EndsOn    *string                 `json:"endsOn,omitempty"`    // blackout: "YYYY-MM-DD"; defaults to StartsOn
+	Weekday   *int                    `json:"weekday,omitempty"`   // preferred: 0 = Sunday ... 6 = Saturday
+	StartTime *string                 `json:"startTime,omitempty"` // preferred: "HH:MM"
+	EndTime   *string                 `json:"endTime,omitempty"`   // preferred: "HH:MM"
+	Note      *string                 `json:"note,omitempty"`
+}
+
+const (
+	ConflictBlackout         = "blackout"
+	ConflictOutsidePreferred = "outside_preferred"
+	ConflictDoubleBooked     = "double_booked"
+	ConflictVenueFull        = "venue_full"
+	ConflictBoardBooked      = "board_booked" // cannot be overridden with allowConflicts
+)
+
+// ScheduleConflict is one constraint a scheduled game violates.
+type ScheduleConflict struct {
+	GameID         *int64 `json:"gameId,omitempty"` // nil while the game is being created
+	Kind           string `json:"kind"`
+	PlayerID       *int64 `json:"playerId,omitempty"`
+	TeamID         *int64 `json:"teamId,omitempty"`
+	AvailabilityID *int64 `json:"availabilityId,omitempty"`
+	// ConflictingGameID is the overlapping game for double_booked/board_booked conflicts.
+	ConflictingGameID *int64 `json:"conflictingGameId,omitempty"`
+	Message           string `json:"message"`
+
+	conflictingDraft bool // the conflicting game is unpublished
+}
+
+// ScheduleConflictError rejects a create/reschedule that violates constraints.
+// Callers may retry with AllowConflicts to schedule anyway, except over a board_booked.
+type ScheduleConflictError struct {
+	Conflicts []ScheduleConflict
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("scheduledAt has %d scheduling conflict(s)", len(e.Conflicts))
+}
+
+// blockingConflicts returns the conflicts that reject a write: all of them, or with
+// allow set only the ones allowConflicts cannot override.
+func blockingConflicts(conflicts []ScheduleConflict, allow bool) []ScheduleConflict {
+	if !allow {
+		return conflicts
+	}
+	var hard []ScheduleConflict
+	for _, c := range conflicts {
+		if c.Kind == ConflictBoardBooked {
+			hard = append(hard, c)
+		}
+	}
+	return hard
+}
+
+// withRepos returns a copy of the service reading through repos, e.g. a transaction.
+func (s *AvailabilityService) withRepos(repos *repositories.RepositoriesCollection) *AvailabilityService {
+	cp := *s
+	cp.repos = repos
+	return &cp
+}
+
+// -------- CRUD
+
+func (s *AvailabilityService) Create(ctx context.Context, in CreateAvailabilityInput) (*models.Availability, error) {
+	if _, err := s.repos.SeasonRepo.GetByID(ctx, in.SeasonID); err != nil {
+		return nil, err
+	}
+	if (in.PlayerID == nil) == (in.TeamID == nil) {
+		return nil, errors.New("exactly one of playerId or teamId is required")
+	}
+	if in.PlayerID != nil {
+		if _, err := s.repos.PlayerRepo.GetByID(ctx, *in.PlayerID); err != nil {
+			return nil, errors.New("player not found")
+		}
+	}
+	if in.TeamID != nil {
+		if _, err := s.repos.TeamRepo.GetByID(ctx, *in.TeamID); err != nil {
+			return nil, errors.New("team not found")
+		}
+	}
+
+	a := &models.Availability{
+		SeasonID: in.SeasonID,
+		PlayerID: in.PlayerID,
+		TeamID:   in.TeamID,
+		Kind:     models.AvailabilityKind(strings.ToLower(string(in.Kind))),
+		Note:     in.Note,
+	}
+	switch a.Kind {
+	case models.AvailabilityBlackout:
+		if in.StartsOn == nil {
+			return nil, errors.New("blackout requires startsOn")
+		}
+		start, err := parseYMD(*in.StartsOn)
+		if err != nil {
+			return nil, errors.New("startsOn must be YYYY-MM-DD")
+		}
+		end := start
+		if in.EndsOn != nil && *in.EndsOn != "" {
+			if end, err = parseYMD(*in.EndsOn); err != nil {
+				return nil, errors.New("endsOn must be YYYY-MM-DD")
+			}
+		}
+		if end.Before(start) {
+			return nil, errors.New("endsOn must be on or after startsOn")
+		}
+		a.StartsOn, a.EndsOn = &start, &end
+	case models.AvailabilityPreferred:
+		if in.Weekday == nil && in.StartTime == nil && in.EndTime == nil {
+			return nil, errors.New("preferred requires a weekday and/or a time window")
+		}
+		if in.Weekday != nil && (*in.Weekday < 0 || *in.Weekday > 6) {
+			return nil, errors.New("weekday must be 0 (Sunday) to 6 (Saturday)")
+		}
+		for _, t := range []*string{in.StartTime, in.EndTime} {
+			if t != nil {
+				if _, err := time.Parse("15:04", *t); err != nil {
+					return nil, errors.New("startTime/endTime must be HH:MM")
+				}
+			}
+		}
+		if in.StartTime != nil && in.EndTime != nil && *in.EndTime <= *in.StartTime {
+			return nil, errors.New("endTime must be after startTime")
+		}
+		a.Weekday, a.StartTime, a.EndTime = in.Weekday, in.StartTime, in.EndTime
+	default:
+		return nil, errors.New("kind must be 'blackout' or 'preferred'")
+	}
+
+	if err := s.repos.AvailabilityRepo.Create(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *AvailabilityService) List(ctx context.Context, f repositories.ListAvailabilityFilter) ([]models.Availability, error) {
+	if _, err := s.repos.SeasonRepo.GetByID(ctx, f.SeasonID); err != nil {
+		return nil, err
+	}
+	return s.repos.AvailabilityRepo.List(ctx, f)
+}
+
+// Delete removes a constraint; it must belong to seasonID.
+func (s *AvailabilityService) Delete(ctx context.Context, seasonID, id int64) error {
+	a, err := s.repos.AvailabilityRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if a.SeasonID != seasonID {
+		return errors.New("availability does not belong to this season")
+	}
+	return s.repos.AvailabilityRepo.DeleteByID(ctx, id)
+}
+
+// -------- Conflicts
+
+// SeasonConflicts lists every constraint violated by the season's scheduled games:
+// availability, double bookings and venue/board capacity, as CheckGame would report
+// them. Draft games, including ones a listed game clashes with, are only considered
+// when includeDrafts is set.
+func (s *AvailabilityService) SeasonConflicts(ctx context.Context, seasonID int64, includeDrafts bool) ([]ScheduleConflict, error) {
+	season, err := s.repos.SeasonRepo.GetByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	games, err := s.repos.GameRepo.ListScheduledInSeason(ctx, seasonID, includeDrafts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(games))
+	for _, g := range games {
+		ids = append(ids, g.ID)
+	}
+	sides, err := s.repos.GameSideRepo.ListByGames(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byGame := map[int64][]models.GameSide{}
+	for _, sd := range sides {
+		byGame[sd.GameID] = append(byGame[sd.GameID], sd)
+	}
+
+	chk, err := s.newChecker(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+	out := []ScheduleConflict{}
+	seen := map[string]bool{}
+	for _, g := range games {
+		cs, err := chk.check(ctx, &g, byGame[g.ID])
+		if err != nil {
+			return nil, err
+		}
+		booked, err := s.doubleBookings(ctx, &g, byGame[g.ID])
+		if err != nil {
+			return nil, err
+		}
+		capacity, err := s.venueCapacity(ctx, &g)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range append(append(cs, booked...), capacity...) {
+			if c.conflictingDraft && !includeDrafts {
+				continue
+			}
+			// Both games of a clash are listed; report the pair once.
+			if key, ok := conflictPairKey(c); ok {
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// conflictPairKey identifies a conflict between two games regardless of which of
+// them reported it; ok is false for conflicts that involve a single game.
+func conflictPairKey(c ScheduleConflict) (string, bool) {
+	if c.GameID == nil || c.ConflictingGameID == nil {
+		return "", false
+	}
+	lo, hi := *c.GameID, *c.ConflictingGameID
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	var playerID int64
+	if c.PlayerID != nil {
+		playerID = *c.PlayerID
+	}
+	return fmt.Sprintf("%s:%d:%d:%d", c.Kind, playerID, lo, hi), true
+}
+
+// CheckGame returns the conflicts a game scheduled at game.ScheduledAt with these
+// sides would have: season availability (season games only), players already
+// booked in an overlapping game, and venue/board capacity. Unscheduled games never conflict.
+func (s *AvailabilityService) CheckGame(ctx context.Context, game *models.Game, sides []models.GameSide) ([]ScheduleConflict, error) {
+	if game.ScheduledAt == nil {
+		return nil, nil
+	}
+	var out []ScheduleConflict
+	if game.SeasonID != nil {
+		season, err := s.repos.SeasonRepo.GetByID(ctx, *game.SeasonID)
+		if err != nil {
+			return nil, err
+		}
+		chk, err := s.newChecker(ctx, season)
+		if err != nil {
+			return nil, err
+		}
+		if out, err = chk.check(ctx, game, sides); err != nil {
+			return nil, err
+		}
+	}
+	booked, err := s.doubleBookings(ctx, game, sides)
+	if err != nil {
+		return nil, err
+	}
+	capacity, err := s.venueCapacity(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(out, booked...), capacity...), nil
+}
+
+// venueCapacity reports the game's board already being occupied by an overlapping game,
+// or, when no board is assigned, every board at the venue being in use.
+func (s *AvailabilityService) venueCapacity(ctx context.Context, game *models.Game) ([]ScheduleConflict, error) {
+	if game.VenueID == nil {
+		return nil, nil
+	}
+	venue, err := s.repos.VenueRepo.GetByID(ctx, *game.VenueID)
+	if err != nil {
+		return nil, err
+	}
+	start := *game.ScheduledAt
+	end := start.Add(s.gameDuration)
+	rows, err := s.repos.VenueRepo.ListVenueBookings(ctx, venue.ID, start.Add(-s.gameDuration), end, game.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	gameID := optionalGameID(game)
+	now := time.Now()
+	var out []ScheduleConflict
+	overlapping := 0
+	for _, r := range rows {
+		if !s.overlaps(start, end, r.StartAt, r.Status, now) {
+			continue
+		}
+		overlapping++
+		if game.BoardID != nil && r.BoardID != nil && *r.BoardID == *game.BoardID {
+			otherID := r.GameID
+			out = append(out, ScheduleConflict{
+				GameID: gameID, Kind: ConflictBoardBooked, ConflictingGameID: &otherID, conflictingDraft: r.Draft,
+				Message: fmt.Sprintf("board %d at %s is already used by game %d (%s, starting %s)",
+					boardNumber(venue, *game.BoardID), venue.Name, otherID, r.Status, r.StartAt.UTC().Format(time.RFC3339)),
+			})
+		}
+	}
+	if game.BoardID == nil && overlapping >= venue.BoardCount {
+		out = append(out, ScheduleConflict{
+			GameID: gameID, Kind: ConflictVenueFull,
+			Message: fmt.Sprintf("all %d board(s) at %s are in use", venue.BoardCount, venue.Name),
+		})
+	}
+	return out, nil
+}
+
+// overlaps tests [start, end) against another game's expected window. An in-progress
+// game is treated as running until at least now.
+func (s *AvailabilityService) overlaps(start, end, otherStart time.Time, otherStatus string, now time.Time) bool {
+	otherEnd := otherStart.Add(s.gameDuration)
+	if otherStatus == "in_progress" && otherEnd.Before(now) {
+		otherEnd = now
+	}
+	return otherStart.Before(end) && start.Before(otherEnd)
+}
+
+func boardNumber(v *models.Venue, boardID int64) int {
+	for _, b := range v.Boards {
+		if b.ID == boardID {
+			return b.Number
+		}
+	}
+	return 0
+}
+
+func optionalGameID(game *models.Game) *int64 {
+	if game.ID == 0 {
+		return nil
+	}
+	id := game.ID
+	return &id
+}
+
+// doubleBookings finds players of game already seated in another scheduled or
+// in-progress game whose expected window overlaps [ScheduledAt, ScheduledAt+gameDuration).
+func (s *AvailabilityService) doubleBookings(ctx context.Context, game *models.Game, sides []models.GameSide) ([]ScheduleConflict, error) {
+	players := []int64{}
+	for _, sd := range sides {
+		if sd.PlayerID != nil {
+			players = append(players, *sd.PlayerID)
+		}
+		if sd.TeamID != nil {
+			team, err := s.repos.TeamRepo.GetByID(ctx, *sd.TeamID)
+			if err != nil {
+				return nil, err
+			}
+			players = append(players, team.PlayerAID, team.PlayerBID)
+		}
+	}
+
+	start := *game.ScheduledAt
+	end := start.Add(s.gameDuration)
+	rows, err := s.repos.GameRepo.ListPlayerBookings(ctx, players, start.Add(-s.gameDuration), end, game.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	gameID := optionalGameID(game)
+	now := time.Now()
+	var out []ScheduleConflict
+	for _, r := range rows {
+		if !s.overlaps(start, end, r.StartAt, r.Status, now) {
+			continue
+		}
+		playerID, otherID := r.PlayerID, r.GameID
+		out = append(out, ScheduleConflict{
+			GameID: gameID, Kind: ConflictDoubleBooked, PlayerID: &playerID, ConflictingGameID: &otherID, conflictingDraft: r.Draft,
+			Message: fmt.Sprintf("player %d is already in game %d (%s, starting %s)",
+				playerID, otherID, r.Status, r.StartAt.UTC().Format(time.RFC3339)),
+		})
+	}
+	return out, nil
+}
+
+// availabilityChecker evaluates games against one season's constraints.
+type availabilityChecker struct {
+	repos     *repositories.RepositoriesCollection
+	loc       *time.Location
+	byPlayer  map[int64][]models.Availability
+	byTeam    map[int64][]models.Availability
+	teamCache map[int64]*models.Team
+}
+
+func (s *AvailabilityService) newChecker(ctx context.Context, season *models.Season) (*availabilityChecker, error) {
+	rows, err := s.repos.AvailabilityRepo.List(ctx, repositories.ListAvailabilityFilter{SeasonID: season.ID})
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(season.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	chk := &availabilityChecker{
+		repos:     s.repos,
+		loc:       loc,
+		byPlayer:  map[int64][]models.Availability{},
+		byTeam:    map[int64][]models.Availability{},
+		teamCache: map[int64]*models.Team{},
+	}
+	for _, a := range rows {
+		if a.PlayerID != nil {
+			chk.byPlayer[*a.PlayerID] = append(chk.byPlayer[*a.PlayerID], a)
+		} else if a.TeamID != nil {
+			chk.byTeam[*a.TeamID] = append(chk.byTeam[*a.TeamID], a)
+		}
+	}
+	return chk, nil
+}
+
+func (c *availabilityChecker) check(ctx context.Context, game *models.Game, sides []models.GameSide) ([]ScheduleConflict, error) {
+	if game.ScheduledAt == nil {
+		return nil, nil
+	}
+	gameID := optionalGameID(game)
+	local := game.ScheduledAt.In(c.loc)
+
+	var out []ScheduleConflict
+	evaluate := func(rows []models.Availability, playerID, teamID *int64, who string) {
+		var preferred int
+		var matched bool
+		for _, a := range rows {
+			switch a.Kind {
+			case models.AvailabilityBlackout:
+				if inBlackout(a, local) {
+					id := a.ID
+					out = append(out, ScheduleConflict{
+						GameID: gameID, Kind: ConflictBlackout, PlayerID: playerID, TeamID: teamID, AvailabilityID: &id,
+						Message: fmt.Sprintf("%s is unavailable on %s", who, local.Format("2006-01-02")),
+					})
+				}
+			case models.AvailabilityPreferred:
+				preferred++
+				matched = matched || inPreferred(a, local)
+			}
+		}
+		if preferred > 0 && !matched {
+			out = append(out, ScheduleConflict{
+				GameID: gameID, Kind: ConflictOutsidePreferred, PlayerID: playerID, TeamID: teamID,
+				Message: fmt.Sprintf("%s prefers other days/times than %s", who, local.Format("Mon 15:04")),
+			})
+		}
+	}
+
+	for _, sd := range sides {
+		players := []int64{}
+		if sd.TeamID != nil {
+			teamID := *sd.TeamID
+			evaluate(c.byTeam[teamID], nil, &teamID, fmt.Sprintf("team %d", teamID))
+			team, err := c.team(ctx, teamID)
+			if err != nil {
+				return nil, err
+			}
+			players = append(players, team.PlayerAID, team.PlayerBID)
+		}
+		if sd.PlayerID != nil {
+			players = append(players, *sd.PlayerID)
+		}
+		for _, pid := range players {
+			pid := pid
+			evaluate(c.byPlayer[pid], &pid, nil, fmt.Sprintf("player %d", pid))
+		}
+	}
+	return out, nil
+}
+
+func (c *availabilityChecker) team(ctx context.Context, id int64) (*models.Team, error) {
+	if t, ok := c.teamCache[id]; ok {
+		return t, nil
+	}
+	t, err := c.repos.TeamRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.teamCache[id] = t
+	return t, nil
+}
+
+// inBlackout compares calendar dates; blackout dates are stored as midnight UTC.
+func inBlackout(a models.Availability, local time.Time) bool {
+	if a.StartsOn == nil || a.EndsOn == nil {
+		return false
+	}
+	day := local.Format("2006-01-02")
+	return day >= a.StartsOn.UTC().Format("2006-01-02") && day <= a.EndsOn.UTC().Format("2006-01-02")
+}
+
+// inPreferred matches the start time against the window, inclusive of both ends.
+func inPreferred(a models.Availability, local time.Time) bool {
+	if a.Weekday != nil && time.Weekday(*a.Weekday) != local.Weekday() {
+		return false
+	}
+	hhmm := local.Format("15:04")
+	if a.StartTime != nil && hhmm < *a.StartTime {
+		return false
+	}
+	if a.EndTime != nil && hhmm > *a.EndTime {
+		return false
+	}
+	return true
+}
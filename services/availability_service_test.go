@@ -0,0 +1,56 @@
+package services
+
+import "testing"
+
+func TestConflictPairKey(t *testing.T) {
+	id := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name   string
+		a, b   ScheduleConflict
+		wantOK bool
+		same   bool
+	}{
+		{
+			name:   "double booking seen from both games",
+			a:      ScheduleConflict{GameID: id(1), ConflictingGameID: id(2), Kind: ConflictDoubleBooked, PlayerID: id(9)},
+			b:      ScheduleConflict{GameID: id(2), ConflictingGameID: id(1), Kind: ConflictDoubleBooked, PlayerID: id(9)},
+			wantOK: true, same: true,
+		},
+		{
+			name:   "different players in the same clash",
+			a:      ScheduleConflict{GameID: id(1), ConflictingGameID: id(2), Kind: ConflictDoubleBooked, PlayerID: id(9)},
+			b:      ScheduleConflict{GameID: id(2), ConflictingGameID: id(1), Kind: ConflictDoubleBooked, PlayerID: id(8)},
+			wantOK: true,
+		},
+		{
+			name:   "board clash seen from both games",
+			a:      ScheduleConflict{GameID: id(3), ConflictingGameID: id(4), Kind: ConflictBoardBooked},
+			b:      ScheduleConflict{GameID: id(4), ConflictingGameID: id(3), Kind: ConflictBoardBooked},
+			wantOK: true, same: true,
+		},
+		{
+			name:   "same games, different kinds",
+			a:      ScheduleConflict{GameID: id(3), ConflictingGameID: id(4), Kind: ConflictBoardBooked},
+			b:      ScheduleConflict{GameID: id(3), ConflictingGameID: id(4), Kind: ConflictDoubleBooked, PlayerID: id(9)},
+			wantOK: true,
+		},
+		{
+			name: "single-game conflicts have no pair",
+			a:    ScheduleConflict{GameID: id(5), Kind: ConflictVenueFull},
+			b:    ScheduleConflict{GameID: id(5), Kind: ConflictBlackout, AvailabilityID: id(1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ka, okA := conflictPairKey(tt.a)
+			kb, okB := conflictPairKey(tt.b)
+			if okA != tt.wantOK || okB != tt.wantOK {
+				t.Fatalf("ok = %v, %v; want %v", okA, okB, tt.wantOK)
+			}
+			if tt.wantOK && (ka == kb) != tt.same {
+				t.Errorf("keys %q and %q: same = %v, want %v", ka, kb, ka == kb, tt.same)
+			}
+		})
+	}
+}
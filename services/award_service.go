@@ -0,0 +1,407 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+type AwardService struct {
+	repos *repositories.RepositoriesCollection
+}
+
+func NewAwardService(repos *repositories.RepositoriesCollection) *AwardService {
+	return &AwardService{repos: repos}
+}
+
+// awardOrder is the display order and the full set of supported awards.
+var awardOrder = []models.AwardType{
+	models.AwardBestWinPct,
+	models.AwardMostTwenties,
+	models.AwardBestPointDiff,
+	models.AwardMostImproved,
+	models.AwardIronPlayer,
+	models.AwardBestPartnership,
+}
+
+// defaultAwardMinGames applies when a season has no setting row for the award.
+var defaultAwardMinGames = map[models.AwardType]int{
+	models.AwardBestWinPct:      5,
+	models.AwardMostTwenties:    1,
+	models.AwardBestPointDiff:   5,
+	models.AwardMostImproved:    5, // in both seasons
+	models.AwardIronPlayer:      1,
+	models.AwardBestPartnership: 5, // games together
+}
+
+const awardLeadersShown = 3
+
+// -------- DTOs
+
+type AwardCandidate struct {
+	PlayerID  int64   `json:"playerId"`
+	PartnerID *int64  `json:"partnerId,omitempty"` // best_partnership only
+	Value     float64 `json:"value"`
+	Games     int64   `json:"games"`
+}
+
+type AwardResult struct {
+	Award    models.AwardType `json:"award"`
+	Enabled  bool             `json:"enabled"`
+	MinGames int              `json:"minGames"`
+	Leaders  []AwardCandidate `json:"leaders"`          // best first; empty when nobody qualifies
+	Locked   *LockedAward     `json:"locked,omitempty"` // admin-confirmed winner, if any
+}
+
+type LockedAward struct {
+	SeasonID  int64            `json:"seasonId"`
+	Award     models.AwardType `json:"award"`
+	PlayerID  int64            `json:"playerId"`
+	PartnerID *int64           `json:"partnerId,omitempty"`
+	Value     float64          `json:"value"`
+	Games     int64            `json:"games"`
+	LockedBy  *string          `json:"lockedBy,omitempty"`
+	LockedAt  time.Time        `json:"lockedAt"`
+}
+
+type SeasonAwards struct {
+	SeasonID int64         `json:"seasonId"`
+	Awards   []AwardResult `json:"awards"`
+}
+
+type AwardSettingInput struct {
+	Award    string `json:"award"`
+	Enabled  *bool  `json:"enabled,omitempty"`
+	MinGames *int   `json:"minGames,omitempty"`
+}
+
+type LockAwardInput struct {
+	PlayerID  *int64  `json:"playerId,omitempty"`  // nil: lock the current computed leader
+	PartnerID *int64  `json:"partnerId,omitempty"` // best_partnership only
+	LockedBy  *string `json:"-"`                   // set from the authenticated user
+}
+
+// -------- Operations
+
+// GetSeasonAwards computes every award for the season alongside any locked winners.
+func (s *AwardService) GetSeasonAwards(ctx context.Context, seasonID int64) (*SeasonAwards, error) {
+	season, err := s.repos.SeasonRepo.GetByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settingsFor(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	locked, err := s.repos.AwardRepo.ListBySeason(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	lockedBy := map[models.AwardType]models.SeasonAward{}
+	for _, a := range locked {
+		lockedBy[a.Award] = a
+	}
+
+	ranked, err := s.rankAll(ctx, season, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SeasonAwards{SeasonID: seasonID, Awards: make([]AwardResult, 0, len(awardOrder))}
+	for _, award := range awardOrder {
+		st := settings[award]
+		res := AwardResult{Award: award, Enabled: st.Enabled, MinGames: st.MinGames, Leaders: []AwardCandidate{}}
+		if st.Enabled {
+			leaders := ranked[award]
+			if len(leaders) > awardLeadersShown {
+				leaders = leaders[:awardLeadersShown]
+			}
+			res.Leaders = append(res.Leaders, leaders...)
+		}
+		if a, ok := lockedBy[award]; ok {
+			res.Locked = toLockedAward(a)
+		}
+		out.Awards = append(out.Awards, res)
+	}
+	return out, nil
+}
+
+// AwardInputError rejects an award request the caller can correct, such as an
+// unknown award or a player who does not qualify.
+type AwardInputError struct {
+	msg string
+}
+
+func (e *AwardInputError) Error() string { return e.msg }
+
+func awardInputError(msg string) error { return &AwardInputError{msg: msg} }
+
+// UpdateSettings enables/disables awards and sets their minimum-games thresholds.
+func (s *AwardService) UpdateSettings(ctx context.Context, seasonID int64, in []AwardSettingInput) (*SeasonAwards, error) {
+	if _, err := s.repos.SeasonRepo.GetByID(ctx, seasonID); err != nil {
+		return nil, err
+	}
+	current, err := s.settingsFor(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]models.SeasonAwardSetting, 0, len(in))
+	seen := map[models.AwardType]bool{}
+	for _, x := range in {
+		award, err := parseAwardType(x.Award)
+		if err != nil {
+			return nil, err
+		}
+		if seen[award] {
+			return nil, awardInputError("duplicate award: " + string(award))
+		}
+		seen[award] = true
+
+		st := current[award]
+		if x.Enabled != nil {
+			st.Enabled = *x.Enabled
+		}
+		if x.MinGames != nil {
+			if *x.MinGames < 1 {
+				return nil, awardInputError("minGames must be >= 1")
+			}
+			st.MinGames = *x.MinGames
+		}
+		rows = append(rows, models.SeasonAwardSetting{
+			SeasonID: seasonID,
+			Award:    award,
+			Enabled:  st.Enabled,
+			MinGames: st.MinGames,
+		})
+	}
+	if err := s.repos.AwardRepo.UpsertSettings(ctx, rows); err != nil {
+		return nil, err
+	}
+	return s.GetSeasonAwards(ctx, seasonID)
+}
+
+// LockAward stores the season's winner for an award. Without an explicit player it
+// locks the current computed leader; an explicit player must currently qualify.
+func (s *AwardService) LockAward(ctx context.Context, seasonID int64, awardName string, in LockAwardInput) (*LockedAward, error) {
+	award, err := parseAwardType(awardName)
+	if err != nil {
+		return nil, err
+	}
+	season, err := s.repos.SeasonRepo.GetByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settingsFor(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings[award].Enabled {
+		return nil, awardInputError("award is disabled for this season")
+	}
+	if in.PartnerID != nil && award != models.AwardBestPartnership {
+		return nil, awardInputError("partnerId only applies to best_partnership")
+	}
+
+	ranked, err := s.rankAll(ctx, season, settings)
+	if err != nil {
+		return nil, err
+	}
+	candidates := ranked[award]
+	if len(candidates) == 0 {
+		return nil, awardInputError("no player qualifies for this award")
+	}
+
+	pick := candidates[0]
+	if in.PlayerID != nil {
+		found := false
+		for _, c := range candidates {
+			if c.matches(*in.PlayerID, in.PartnerID) {
+				pick, found = c, true
+				break
+			}
+		}
+		if !found {
+			return nil, awardInputError("player does not qualify for this award")
+		}
+	}
+
+	row := &models.SeasonAward{
+		SeasonID:  seasonID,
+		Award:     award,
+		PlayerID:  pick.PlayerID,
+		PartnerID: pick.PartnerID,
+		Value:     pick.Value,
+		Games:     pick.Games,
+		LockedBy:  in.LockedBy,
+		LockedAt:  time.Now().UTC(),
+	}
+	if err := s.repos.AwardRepo.Lock(ctx, row); err != nil {
+		return nil, err
+	}
+	return toLockedAward(*row), nil
+}
+
+func (s *AwardService) UnlockAward(ctx context.Context, seasonID int64, awardName string) error {
+	award, err := parseAwardType(awardName)
+	if err != nil {
+		return err
+	}
+	if _, err := s.repos.SeasonRepo.GetByID(ctx, seasonID); err != nil {
+		return err
+	}
+	return s.repos.AwardRepo.Unlock(ctx, seasonID, award)
+}
+
+// ListPlayerAwards returns the player's locked awards, newest season first.
+func (s *AwardService) ListPlayerAwards(ctx context.Context, playerID int64) ([]LockedAward, error) {
+	rows, err := s.repos.AwardRepo.ListByPlayer(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LockedAward, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, *toLockedAward(r))
+	}
+	return out, nil
+}
+
+// -------- Internal
+
+type awardSetting struct {
+	Enabled  bool
+	MinGames int
+}
+
+func (s *AwardService) settingsFor(ctx context.Context, seasonID int64) (map[models.AwardType]awardSetting, error) {
+	out := make(map[models.AwardType]awardSetting, len(awardOrder))
+	for _, a := range awardOrder {
+		out[a] = awardSetting{Enabled: true, MinGames: defaultAwardMinGames[a]}
+	}
+	rows, err := s.repos.AwardRepo.ListSettings(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		if _, ok := out[r.Award]; ok {
+			out[r.Award] = awardSetting{Enabled: r.Enabled, MinGames: r.MinGames}
+		}
+	}
+	return out, nil
+}
+
+// rankAll returns every qualifying candidate per award, best first.
+func (s *AwardService) rankAll(ctx context.Context, season *models.Season, settings map[models.AwardType]awardSetting) (map[models.AwardType][]AwardCandidate, error) {
+	totals, err := s.repos.StatsRepo.ListSeasonPlayerTotals(ctx, season.ID)
+	if err != nil {
+		return nil, err
+	}
+	pairs, err := s.repos.StatsRepo.ListSeasonPairings(ctx, season.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Most improved compares against the league's previous season, if there is one.
+	var prevTotals []repositories.PlayerTotalsRow
+	prev, err := s.repos.SeasonRepo.GetPreviousInLeague(ctx, season)
+	switch {
+	case err == nil:
+		if prevTotals, err = s.repos.StatsRepo.ListSeasonPlayerTotals(ctx, prev.ID); err != nil {
+			return nil, err
+		}
+	case !utils.IsNotFound(err):
+		return nil, err
+	}
+
+	out := map[models.AwardType][]AwardCandidate{}
+	add := func(award models.AwardType, c AwardCandidate) {
+		if c.Games >= int64(settings[award].MinGames) {
+			out[award] = append(out[award], c)
+		}
+	}
+
+	prevByPlayer := make(map[int64]repositories.PlayerTotalsRow, len(prevTotals))
+	for _, p := range prevTotals {
+		prevByPlayer[p.PlayerID] = p
+	}
+
+	for _, t := range totals {
+		add(models.AwardBestWinPct, AwardCandidate{PlayerID: t.PlayerID, Value: winPct(t.Wins, t.Games), Games: t.Games})
+		add(models.AwardMostTwenties, AwardCandidate{PlayerID: t.PlayerID, Value: float64(t.Twenties), Games: t.Games})
+		add(models.AwardBestPointDiff, AwardCandidate{PlayerID: t.PlayerID, Value: float64(t.PointsFor - t.PointsAgainst), Games: t.Games})
+		add(models.AwardIronPlayer, AwardCandidate{PlayerID: t.PlayerID, Value: float64(t.Games), Games: t.Games})
+
+		if p, ok := prevByPlayer[t.PlayerID]; ok && p.Games >= int64(settings[models.AwardMostImproved].MinGames) {
+			delta := winPct(t.Wins, t.Games) - winPct(p.Wins, p.Games)
+			add(models.AwardMostImproved, AwardCandidate{PlayerID: t.PlayerID, Value: delta, Games: t.Games})
+		}
+	}
+	for _, p := range pairs {
+		partner := p.PlayerBID
+		add(models.AwardBestPartnership, AwardCandidate{PlayerID: p.PlayerAID, PartnerID: &partner, Value: p.WinPct, Games: p.Games})
+	}
+
+	// Higher value wins; more games, then lower ids, break ties so the order is stable.
+	for _, cs := range out {
+		sort.SliceStable(cs, func(i, j int) bool {
+			if cs[i].Value != cs[j].Value {
+				return cs[i].Value > cs[j].Value
+			}
+			if cs[i].Games != cs[j].Games {
+				return cs[i].Games > cs[j].Games
+			}
+			if cs[i].PlayerID != cs[j].PlayerID {
+				return cs[i].PlayerID < cs[j].PlayerID
+			}
+			return cs[i].partnerKey() < cs[j].partnerKey()
+		})
+	}
+	return out, nil
+}
+
+func (c AwardCandidate) partnerKey() int64 {
+	if c.PartnerID == nil {
+		return 0
+	}
+	return *c.PartnerID
+}
+
+// matches reports whether the candidate is playerID (and partnerID, for pairs, in either order).
+func (c AwardCandidate) matches(playerID int64, partnerID *int64) bool {
+	if c.PartnerID == nil {
+		return c.PlayerID == playerID && partnerID == nil
+	}
+	if partnerID == nil {
+		return false
+	}
+	return (c.PlayerID == playerID && *c.PartnerID == *partnerID) ||
+		(c.PlayerID == *partnerID && *c.PartnerID == playerID)
+}
+
+func parseAwardType(s string) (models.AwardType, error) {
+	a := models.AwardType(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range awardOrder {
+		if a == known {
+			return a, nil
+		}
+	}
+	return "", awardInputError("invalid award: " + s)
+}
+
+func toLockedAward(a models.SeasonAward) *LockedAward {
+	return &LockedAward{
+		SeasonID:  a.SeasonID,
+		Award:     a.Award,
+		PlayerID:  a.PlayerID,
+		PartnerID: a.PartnerID,
+		Value:     a.Value,
+		Games:     a.Games,
+		LockedBy:  a.LockedBy,
+		LockedAt:  a.LockedAt,
+	}
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/ical"
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+const calendarProdID = "-//Betty Crokers//League Calendar//EN"
+
+// CalendarService builds subscribable iCalendar feeds of scheduled games.
+type CalendarService struct {
+	repos        *repositories.RepositoriesCollection
+	gameDuration time.Duration // event length for games that haven't ended
+}
+
+func NewCalendarService(repos *repositories.RepositoriesCollection, gameDuration time.Duration) *CalendarService {
+	return &CalendarService{repos: repos, gameDuration: gameDuration}
+}
+
+func (s *CalendarService) SeasonCalendar(ctx context.Context, seasonID int64) ([]byte, error) {
+	season, err := s.repos.SeasonRepo.GetByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	return s.build(ctx, season.Name, repositories.CalendarFilter{SeasonID: &seasonID})
+}
+
+func (s *CalendarService) TeamCalendar(ctx context.Context, teamID int64) ([]byte, error) {
+	team, err := s.repos.TeamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return s.build(ctx, team.Name, repositories.CalendarFilter{TeamID: &teamID})
+}
+
+func (s *CalendarService) PlayerCalendar(ctx context.Context, playerID int64) ([]byte, error) {
+	player, err := s.repos.PlayerRepo.GetByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.build(ctx, player.Nickname, repositories.CalendarFilter{PlayerID: &playerID})
+}
+
+func (s *CalendarService) build(ctx context.Context, name string, f repositories.CalendarFilter) ([]byte, error) {
+	games, err := s.repos.GameRepo.ListForCalendar(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(games))
+	for _, g := range games {
+		ids = append(ids, g.ID)
+	}
+	sides, err := s.repos.GameSideRepo.ListByGames(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byGame := map[int64]map[string]models.GameSide{}
+	for _, sd := range sides {
+		if byGame[sd.GameID] == nil {
+			byGame[sd.GameID] = map[string]models.GameSide{}
+		}
+		byGame[sd.GameID][sd.Side] = sd
+	}
+
+	names := participantNames{repos: s.repos, teams: map[int64]string{}, players: map[int64]string{}}
+	cal := ical.Calendar{ProdID: calendarProdID, Name: name + " – Betty Crokers"}
+	for _, g := range games {
+		a, b := byGame[g.ID]["A"], byGame[g.ID]["B"]
+		nameA, err := names.side(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		nameB, err := names.side(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		cal.Events = append(cal.Events, s.event(g, nameA, nameB, a, b))
+	}
+	return cal.Encode(), nil
+}
+
+// event maps a game to a VEVENT. The UID is derived from the game ID only, and the
+// sequence grows with UpdatedAt, so a reschedule replaces the existing event.
+func (s *CalendarService) event(g models.Game, nameA, nameB string, a, b models.GameSide) ical.Event {
+	start := *g.ScheduledAt
+	end := start.Add(s.gameDuration)
+	if g.Status == "completed" && g.StartedAt != nil && g.EndedAt != nil && g.EndedAt.After(*g.StartedAt) {
+		start, end = *g.StartedAt, *g.EndedAt
+	}
+
+	e := ical.Event{
+		UID:          fmt.Sprintf("game-%d@betty-crokers", g.ID),
+		Sequence:     int(g.UpdatedAt.Unix() - g.CreatedAt.Unix()),
+		Stamp:        g.UpdatedAt,
+		LastModified: g.UpdatedAt,
+		Start:        start,
+		End:          end,
+		TZID:         g.Timezone,
+		Summary:      nameA + " vs " + nameB,
+		Status:       ical.StatusConfirmed,
+	}
+	if e.Sequence < 0 {
+		e.Sequence = 0
+	}
+	if g.Location != nil {
+		e.Location = *g.Location
+	}
+
+	var desc []string
+	if g.Description != nil && *g.Description != "" {
+		desc = append(desc, *g.Description)
+	}
+	switch g.Status {
+	case "canceled":
+		e.Status = ical.StatusCancelled
+		e.Summary = "Canceled: " + e.Summary
+	case "completed":
+		desc = append(desc, fmt.Sprintf("Final: %s %d – %d %s", nameA, a.Points, b.Points, nameB))
+	case "in_progress":
+		desc = append(desc, "In progress")
+	}
+	e.Description = strings.Join(desc, "\n")
+	return e
+}
+
+// participantNames resolves side display names, caching lookups across a feed.
+type participantNames struct {
+	repos   *repositories.RepositoriesCollection
+	teams   map[int64]string
+	players map[int64]string
+}
+
+func (n *participantNames) side(ctx context.Context, sd models.GameSide) (string, error) {
+	switch {
+	case sd.TeamID != nil:
+		if v, ok := n.teams[*sd.TeamID]; ok {
+			return v, nil
+		}
+		t, err := n.repos.TeamRepo.GetByID(ctx, *sd.TeamID)
+		if err != nil {
+			return "", err
+		}
+		n.teams[t.ID] = t.Name
+		return t.Name, nil
+	case sd.PlayerID != nil:
+		if v, ok := n.players[*sd.PlayerID]; ok {
+			return v, nil
+		}
+		p, err := n.repos.PlayerRepo.GetByID(ctx, *sd.PlayerID)
+		if err != nil {
+			return "", err
+		}
+		n.players[p.ID] = p.Nickname
+		return p.Nickname, nil
+	}
+	return "TBD", nil
+}
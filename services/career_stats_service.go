@@ -0,0 +1,543 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+type CareerStatsService struct {
+	repos *repositories.RepositoriesCollection
+}
+
+func NewCareerStatsService(repos *repositories.RepositoriesCollection) *CareerStatsService {
+	return &CareerStatsService{repos: repos}
+}
+
+type CareerStatsOptions struct {
+	LeagueID          *int64
+	SeasonID          *int64
+	From              *time.Time // by EndedAt
+	To                *time.Time // by EndedAt
+	MatchType         *string    // "teams" | "players"
+	Location          *string
+	IncludeExhibition bool
+}
+
+func (o CareerStatsOptions) filter() repositories.CareerStatsFilter {
+	return repositories.CareerStatsFilter{
+		LeagueID:          o.LeagueID,
+		SeasonID:          o.SeasonID,
+		EndedFrom:         o.From,
+		EndedTo:           o.To,
+		MatchType:         o.MatchType,
+		Location:          o.Location,
+		IncludeExhibition: o.IncludeExhibition,
+	}
+}
+
+func (s *CareerStatsService) GetPlayerCareerStats(ctx context.Context, playerID int64, opts CareerStatsOptions) (*models.PlayerCareerStats, error) {
+	if _, err := s.repos.PlayerRepo.GetByID(ctx, playerID); err != nil {
+		return nil, err
+	}
+	if opts.From != nil && opts.To != nil && opts.To.Before(*opts.From) {
+		return nil, errors.New("to must be on or after from")
+	}
+
+	rows, err := s.repos.StatsRepo.ListPlayerCareerSplits(ctx, playerID, opts.filter())
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.PlayerCareerStats{
+		PlayerStatsRow: models.PlayerStatsRow{PlayerID: playerID},
+		Seasons:        []models.PlayerSeasonStatsRow{},
+	}
+	idx := map[int64]int{} // season id (0 = exhibition) -> index in out.Seasons
+	for _, r := range rows {
+		key := seasonKey(r.SeasonID)
+		i, ok := idx[key]
+		if !ok {
+			out.Seasons = append(out.Seasons, models.PlayerSeasonStatsRow{
+				SeasonID:       r.SeasonID,
+				SeasonName:     r.SeasonName,
+				LeagueID:       r.LeagueID,
+				PlayerStatsRow: models.PlayerStatsRow{PlayerID: playerID},
+			})
+			i = len(out.Seasons) - 1
+			idx[key] = i
+		}
+		addSplitToPlayerRow(&out.Seasons[i].PlayerStatsRow, r)
+		addSplitToPlayerRow(&out.PlayerStatsRow, r)
+	}
+
+	for i := range out.Seasons {
+		out.Seasons[i].WinPct = winPct(out.Seasons[i].Wins, out.Seasons[i].Games)
+	}
+	out.WinPct = winPct(out.Wins, out.Games)
+	return out, nil
+}
+
+func (s *CareerStatsService) GetTeamCareerStats(ctx context.Context, teamID int64, opts CareerStatsOptions) (*models.TeamCareerStats, error) {
+	if _, err := s.repos.TeamRepo.GetByID(ctx, teamID); err != nil {
+		return nil, err
+	}
+	if opts.From != nil && opts.To != nil && opts.To.Before(*opts.From) {
+		return nil, errors.New("to must be on or after from")
+	}
+
+	rows, err := s.repos.StatsRepo.ListTeamCareerSplits(ctx, teamID, opts.filter())
+	if err != nil {
+		return nil, err
+	}
+	return buildTeamCareerStats(teamID, rows), nil
+}
+
+// buildTeamCareerStats folds per-season, per-location splits into season rows
+// and a career total.
+func buildTeamCareerStats(teamID int64, rows []repositories.CareerSplitRow) *models.TeamCareerStats {
+	out := &models.TeamCareerStats{
+		TeamStatsRow: models.TeamStatsRow{TeamID: teamID},
+		Seasons:      []models.TeamSeasonStatsRow{},
+	}
+	idx := map[int64]int{}
+	seasonLocWins := map[int64]locationWins{}
+	careerLocWins := locationWins{}
+	for _, r := range rows {
+		key := seasonKey(r.SeasonID)
+		i, ok := idx[key]
+		if !ok {
+			out.Seasons = append(out.Seasons, models.TeamSeasonStatsRow{
+				SeasonID:     r.SeasonID,
+				SeasonName:   r.SeasonName,
+				LeagueID:     r.LeagueID,
+				TeamStatsRow: models.TeamStatsRow{TeamID: teamID},
+			})
+			i = len(out.Seasons) - 1
+			idx[key] = i
+			seasonLocWins[key] = locationWins{}
+		}
+		addSplitToTeamRow(&out.Seasons[i].TeamStatsRow, r)
+		addSplitToTeamRow(&out.TeamStatsRow, r)
+
+		if r.Location != nil {
+			seasonLocWins[key].add(*r.Location, r.Wins)
+			careerLocWins.add(*r.Location, r.Wins)
+		}
+	}
+
+	for i := range out.Seasons {
+		row := &out.Seasons[i].TeamStatsRow
+		row.WinPct = winPct(row.Wins, row.Games)
+		row.BestLocation, row.BestLocationWins = seasonLocWins[seasonKey(out.Seasons[i].SeasonID)].best()
+	}
+	out.WinPct = winPct(out.Wins, out.Games)
+	out.BestLocation, out.BestLocationWins = careerLocWins.best()
+	return out
+}
+
+// --- helpers ---
+
+func seasonKey(seasonID *int64) int64 {
+	if seasonID == nil {
+		return 0
+	}
+	return *seasonID
+}
+
+func winPct(wins, games int64) float64 {
+	if games == 0 {
+		return 0
+	}
+	return float64(wins) / float64(games)
+}
+
+// locationWins tallies wins per location, matching names case-insensitively
+// (each season's splits may spell a place differently) and keeping the first
+// spelling in sort order, as the split query does.
+type locationWins map[string]struct {
+	name string
+	wins int64
+}
+
+func (l locationWins) add(location string, wins int64) {
+	key := strings.ToLower(strings.TrimSpace(location))
+	e := l[key]
+	if e.name == "" || location < e.name {
+		e.name = location
+	}
+	e.wins += wins
+	l[key] = e
+}
+
+// best mirrors ListTeamStats: most wins, ties broken by location name.
+func (l locationWins) best() (*string, int64) {
+	var (
+		best     *string
+		bestWins int64
+	)
+	for _, e := range l {
+		if best == nil || e.wins > bestWins || (e.wins == bestWins && e.name < *best) {
+			name := e.name
+			best = &name
+			bestWins = e.wins
+		}
+	}
+	return best, bestWins
+}
+
+func addSplitToPlayerRow(dst *models.PlayerStatsRow, r repositories.CareerSplitRow) {
+	dst.Games += r.Games
+	dst.Wins += r.Wins
+	dst.Losses += r.Losses
+	dst.WhiteWins += r.WhiteWins
+	dst.BlackWins += r.BlackWins
+	dst.NaturalWins += r.NaturalWins
+	dst.WhiteGames += r.WhiteGames
+	dst.BlackGames += r.BlackGames
+	dst.NaturalGames += r.NaturalGames
+}
+
+func addSplitToTeamRow(dst *models.TeamStatsRow, r repositories.CareerSplitRow) {
+	dst.Games += r.Games
+	dst.Wins += r.Wins
+	dst.Losses += r.Losses
+	dst.WhiteWins += r.WhiteWins
+	dst.BlackWins += r.BlackWins
+	dst.NaturalWins += r.NaturalWins
+	dst.WhiteGames += r.WhiteGames
+	dst.BlackGames += r.BlackGames
+	dst.NaturalGames += r.NaturalGames
+}
+
+// ---------- Form / streaks
+
+type SeasonForm struct {
+	SeasonID *int64 `json:"seasonId"` // nil => exhibition
+	models.FormGuide
+}
+
+type ParticipantForm struct {
+	Career  models.FormGuide `json:"career"`
+	Seasons []SeasonForm     `json:"seasons"`
+}
+
+func (s *CareerStatsService) GetPlayerForm(ctx context.Context, playerID int64) (*ParticipantForm, error) {
+	if _, err := s.repos.PlayerRepo.GetByID(ctx, playerID); err != nil {
+		return nil, err
+	}
+	rows, err := s.repos.StatsRepo.ListPlayerResults(ctx, repositories.ResultsFilter{ParticipantID: &playerID})
+	if err != nil {
+		return nil, err
+	}
+	return buildParticipantForm(rows), nil
+}
+
+func (s *CareerStatsService) GetTeamForm(ctx context.Context, teamID int64) (*ParticipantForm, error) {
+	if _, err := s.repos.TeamRepo.GetByID(ctx, teamID); err != nil {
+		return nil, err
+	}
+	rows, err := s.repos.StatsRepo.ListTeamResults(ctx, repositories.ResultsFilter{ParticipantID: &teamID})
+	if err != nil {
+		return nil, err
+	}
+	return buildParticipantForm(rows), nil
+}
+
+// buildParticipantForm expects rows for a single participant, ordered by EndedAt.
+func buildParticipantForm(rows []repositories.ResultRow) *ParticipantForm {
+	out := &ParticipantForm{Seasons: []SeasonForm{}}
+
+	career := make([]string, 0, len(rows))
+	bySeason := map[int64][]string{}
+	var order []*int64
+	for _, r := range rows {
+		career = append(career, r.Result)
+		key := seasonKey(r.SeasonID)
+		if _, ok := bySeason[key]; !ok {
+			order = append(order, r.SeasonID)
+		}
+		bySeason[key] = append(bySeason[key], r.Result)
+	}
+
+	out.Career = computeFormGuide(career)
+	for _, sid := range order {
+		out.Seasons = append(out.Seasons, SeasonForm{
+			SeasonID:  sid,
+			FormGuide: computeFormGuide(bySeason[seasonKey(sid)]),
+		})
+	}
+	return out
+}
+
+// formByParticipant groups ordered result rows and computes each participant's form.
+func formByParticipant(rows []repositories.ResultRow) map[int64]models.FormGuide {
+	results := map[int64][]string{}
+	for _, r := range rows {
+		results[r.ParticipantID] = append(results[r.ParticipantID], r.Result)
+	}
+	out := make(map[int64]models.FormGuide, len(results))
+	for id, rs := range results {
+		out[id] = computeFormGuide(rs)
+	}
+	return out
+}
+
+// computeFormGuide takes "W"/"L"/"T" results oldest to newest.
+func computeFormGuide(results []string) models.FormGuide {
+	fg := models.FormGuide{Games: int64(len(results))}
+	if len(results) == 0 {
+		return fg
+	}
+
+	var run int64
+	for i, r := range results {
+		if i > 0 && r == results[i-1] {
+			run++
+		} else {
+			run = 1
+		}
+		switch r {
+		case "W":
+			if run > fg.LongestWinStreak {
+				fg.LongestWinStreak = run
+			}
+		case "L":
+			if run > fg.LongestLossStreak {
+				fg.LongestLossStreak = run
+			}
+		}
+	}
+	fg.CurrentStreak = results[len(results)-1] + strconv.FormatInt(run, 10)
+
+	start := len(results) - 10
+	if start < 0 {
+		start = 0
+	}
+	fg.Last10 = strings.Join(results[start:], "")
+	return fg
+}
+
+// ---------- Partners / pairings
+
+func (s *CareerStatsService) GetPlayerPartners(ctx context.Context, playerID int64) ([]models.PartnerStatsRow, error) {
+	if _, err := s.repos.PlayerRepo.GetByID(ctx, playerID); err != nil {
+		return nil, err
+	}
+	return s.repos.StatsRepo.ListPlayerPartners(ctx, playerID)
+}
+
+// ListLeaguePairings returns the league's best doubles pairings with at least minGames together.
+func (s *CareerStatsService) ListLeaguePairings(ctx context.Context, leagueID int64, minGames int, limit int) ([]models.PairingStatsRow, error) {
+	if _, err := s.repos.LeagueRepo.GetByID(ctx, leagueID); err != nil {
+		return nil, err
+	}
+	return s.repos.StatsRepo.ListPairings(ctx, repositories.ListPairingsQuery{
+		LeagueID: leagueID,
+		MinGames: minGames,
+		Limit:    limit,
+	})
+}
+
+// ---------- Timeseries
+
+const (
+	MetricWinPct    = "winPct"
+	MetricPointDiff = "pointDiff"
+	MetricTwenties  = "twenties"
+
+	BucketWeek  = "week"
+	BucketMonth = "month"
+
+	MaxTimeseriesWindow = 100
+)
+
+type TimeseriesOptions struct {
+	CareerStatsOptions
+	Metric string // winPct | pointDiff | twenties
+	Bucket string // week | month
+	Window int    // rolling window in games; 0 disables the rolling series
+}
+
+// TimeseriesPoint is one calendar bucket, labelled in the games' local time
+// ("2006-01" for months, the Monday "2006-01-02" for weeks).
+type TimeseriesPoint struct {
+	Period string    `json:"period"`
+	Start  time.Time `json:"start"`
+	Games  int64     `json:"games"`
+	Value  float64   `json:"value"`
+}
+
+// RollingPoint is the metric over the Window games ending with GameID.
+type RollingPoint struct {
+	GameID  int64     `json:"gameId"`
+	EndedAt time.Time `json:"endedAt"`
+	Games   int64     `json:"games"` // < Window until enough games have been played
+	Value   float64   `json:"value"`
+}
+
+type Timeseries struct {
+	Metric  string            `json:"metric"`
+	Bucket  string            `json:"bucket"`
+	Window  int               `json:"window,omitempty"`
+	Points  []TimeseriesPoint `json:"points"`
+	Rolling []RollingPoint    `json:"rolling,omitempty"`
+}
+
+func (s *CareerStatsService) GetPlayerTimeseries(ctx context.Context, playerID int64, opts TimeseriesOptions) (*Timeseries, error) {
+	if _, err := s.repos.PlayerRepo.GetByID(ctx, playerID); err != nil {
+		return nil, err
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	rows, err := s.repos.StatsRepo.ListPlayerGamePoints(ctx, playerID, opts.filter())
+	if err != nil {
+		return nil, err
+	}
+	return buildTimeseries(rows, opts), nil
+}
+
+func (s *CareerStatsService) GetTeamTimeseries(ctx context.Context, teamID int64, opts TimeseriesOptions) (*Timeseries, error) {
+	if _, err := s.repos.TeamRepo.GetByID(ctx, teamID); err != nil {
+		return nil, err
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	rows, err := s.repos.StatsRepo.ListTeamGamePoints(ctx, teamID, opts.filter())
+	if err != nil {
+		return nil, err
+	}
+	return buildTimeseries(rows, opts), nil
+}
+
+func (o TimeseriesOptions) validate() error {
+	switch o.Metric {
+	case MetricWinPct, MetricPointDiff, MetricTwenties:
+	default:
+		return errors.New("metric must be 'winPct', 'pointDiff' or 'twenties'")
+	}
+	if o.Bucket != BucketWeek && o.Bucket != BucketMonth {
+		return errors.New("bucket must be 'week' or 'month'")
+	}
+	if o.Window < 0 || o.Window > MaxTimeseriesWindow {
+		return errors.New("window must be between 0 and " + strconv.Itoa(MaxTimeseriesWindow))
+	}
+	if o.From != nil && o.To != nil && o.To.Before(*o.From) {
+		return errors.New("to must be on or after from")
+	}
+	return nil
+}
+
+// seriesAcc accumulates one metric over a run of games.
+type seriesAcc struct {
+	games, wins, ties, diff, twenties int64
+}
+
+func (a *seriesAcc) add(r repositories.GamePointsRow, sign int64) {
+	a.games += sign
+	a.diff += sign * (r.PointsFor - r.PointsAgainst)
+	a.twenties += sign * r.Twenties
+	switch r.Result {
+	case "W":
+		a.wins += sign
+	case "T":
+		a.ties += sign
+	}
+}
+
+// value reports the metric; ties count as half a win, as in season standings.
+func (a *seriesAcc) value(metric string) float64 {
+	switch metric {
+	case MetricPointDiff:
+		return float64(a.diff)
+	case MetricTwenties:
+		return float64(a.twenties)
+	default:
+		if a.games == 0 {
+			return 0
+		}
+		return (float64(a.wins) + 0.5*float64(a.ties)) / float64(a.games)
+	}
+}
+
+// buildTimeseries expects rows oldest first. Games without an EndedAt have no place
+// on a time axis and are skipped.
+func buildTimeseries(rows []repositories.GamePointsRow, opts TimeseriesOptions) *Timeseries {
+	out := &Timeseries{Metric: opts.Metric, Bucket: opts.Bucket, Window: opts.Window, Points: []TimeseriesPoint{}}
+
+	locs := map[string]*time.Location{}
+	var (
+		cur     *TimeseriesPoint
+		acc     seriesAcc
+		rolling seriesAcc
+		window  []repositories.GamePointsRow
+	)
+	flush := func() {
+		if cur != nil {
+			cur.Games = acc.games
+			cur.Value = acc.value(opts.Metric)
+			out.Points = append(out.Points, *cur)
+		}
+	}
+
+	for _, r := range rows {
+		if r.EndedAt == nil {
+			continue
+		}
+		loc, ok := locs[r.Timezone]
+		if !ok {
+			var err error
+			if loc, err = time.LoadLocation(r.Timezone); err != nil {
+				loc = time.UTC
+			}
+			locs[r.Timezone] = loc
+		}
+
+		// Labels sort chronologically; a game that lands in an earlier bucket only
+		// because its timezone differs stays in the current one.
+		start, label := bucketStart(r.EndedAt.In(loc), opts.Bucket)
+		if cur == nil || label > cur.Period {
+			flush()
+			cur = &TimeseriesPoint{Period: label, Start: start}
+			acc = seriesAcc{}
+		}
+		acc.add(r, 1)
+
+		if opts.Window > 0 {
+			window = append(window, r)
+			rolling.add(r, 1)
+			if len(window) > opts.Window {
+				rolling.add(window[0], -1)
+				window = window[1:]
+			}
+			out.Rolling = append(out.Rolling, RollingPoint{
+				GameID:  r.GameID,
+				EndedAt: *r.EndedAt,
+				Games:   rolling.games,
+				Value:   rolling.value(opts.Metric),
+			})
+		}
+	}
+	flush()
+	return out
+}
+
+// bucketStart returns the local midnight starting t's bucket and its label.
+// Weeks start on Monday.
+func bucketStart(t time.Time, bucket string) (time.Time, string) {
+	y, m, d := t.Date()
+	if bucket == BucketMonth {
+		start := time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+		return start, start.Format("2006-01")
+	}
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	start := time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+	return start, start.Format("2006-01-02")
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+func TestBuildTeamCareerStatsBestLocation(t *testing.T) {
+	id := func(v int64) *int64 { return &v }
+	str := func(s string) *string { return &s }
+	split := func(season int64, loc string, wins int64) repositories.CareerSplitRow {
+		return repositories.CareerSplitRow{SeasonID: id(season), Location: str(loc), Games: wins, Wins: wins}
+	}
+
+	tests := []struct {
+		name           string
+		rows           []repositories.CareerSplitRow
+		wantSeasonBest []string // per season, in row order
+		wantBest       string
+		wantBestWins   int64
+	}{
+		{
+			name: "one spelling per season",
+			rows: []repositories.CareerSplitRow{
+				split(1, "Legion Hall", 3),
+				split(1, "Elks Lodge", 2),
+			},
+			wantSeasonBest: []string{"Legion Hall"},
+			wantBest:       "Legion Hall",
+			wantBestWins:   3,
+		},
+		{
+			// Each season's split has its own spelling; together they outweigh Elks Lodge.
+			name: "case variants across seasons are one place",
+			rows: []repositories.CareerSplitRow{
+				split(1, "Legion Hall", 2),
+				split(1, "Elks Lodge", 3),
+				split(2, "legion hall", 2),
+			},
+			wantSeasonBest: []string{"Elks Lodge", "legion hall"},
+			wantBest:       "Legion Hall",
+			wantBestWins:   4,
+		},
+		{
+			name: "case variants within a season",
+			rows: []repositories.CareerSplitRow{
+				split(1, "LEGION HALL", 1),
+				split(1, "Legion Hall", 1),
+				split(1, "Elks Lodge", 1),
+			},
+			wantSeasonBest: []string{"LEGION HALL"},
+			wantBest:       "LEGION HALL",
+			wantBestWins:   2,
+		},
+		{
+			name: "ties go to the first name",
+			rows: []repositories.CareerSplitRow{
+				split(1, "Moose Lodge", 2),
+				split(1, "Elks Lodge", 2),
+			},
+			wantSeasonBest: []string{"Elks Lodge"},
+			wantBest:       "Elks Lodge",
+			wantBestWins:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := buildTeamCareerStats(7, tt.rows)
+			if out.BestLocation == nil || *out.BestLocation != tt.wantBest || out.BestLocationWins != tt.wantBestWins {
+				t.Errorf("best = %v (%d), want %q (%d)", out.BestLocation, out.BestLocationWins, tt.wantBest, tt.wantBestWins)
+			}
+			if len(out.Seasons) != len(tt.wantSeasonBest) {
+				t.Fatalf("got %d seasons, want %d", len(out.Seasons), len(tt.wantSeasonBest))
+			}
+			for i, want := range tt.wantSeasonBest {
+				if got := out.Seasons[i].BestLocation; got == nil || *got != want {
+					t.Errorf("season %d best = %v, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+/* =========================
+   Bulk reschedule
+========================= */
+
+// BulkRescheduleInput selects games with the list filters (paging ignored) and applies
+// one change to all of them: a time shift or a new local date, and/or a new venue or
+// location.
+type BulkRescheduleInput struct {
+	Filter         ListGamesOptions
+	ShiftMinutes   *int    // move ScheduledAt by this many minutes
+	Date           *string // "YYYY-MM-DD": same local kick-off time on this date (game timezone)
+	VenueID        *int64  // new venue (clears the board)
+	Location       *string // new free-text location (clears venue and board); "" clears
+	DryRun         bool
+	AllowConflicts bool
+}
+
+type GameSlot struct {
+	ScheduledAt *time.Time `json:"scheduledAt"`
+	Location    *string    `json:"location"`
+	VenueID     *int64     `json:"venueId"`
+	BoardID     *int64     `json:"boardId"`
+}
+
+type BulkGameChange struct {
+	GameID int64    `json:"gameId"`
+	Before GameSlot `json:"before"`
+	After  GameSlot `json:"after"`
+}
+
+type BulkSkip struct {
+	GameID int64  `json:"gameId"`
+	Reason string `json:"reason"`
+}
+
+// BulkRescheduleResult summarises the operation. Applied is false for dry runs and
+// for runs rejected by conflicts.
+type BulkRescheduleResult struct {
+	DryRun    bool               `json:"dryRun"`
+	Applied   bool               `json:"applied"`
+	Matched   int                `json:"matched"`
+	Changed   int                `json:"changed"`
+	Changes   []BulkGameChange   `json:"changes"`
+	Skipped   []BulkSkip         `json:"skipped"`
+	Conflicts []ScheduleConflict `json:"conflicts"`
+}
+
+// errBulkRollback aborts the bulk transaction without it being reported as a failure.
+var errBulkRollback = errors.New("bulk reschedule rolled back")
+
+// bulkRescheduleStatuses are the statuses a bulk reschedule may touch.
+var bulkRescheduleStatuses = map[string]bool{"scheduled": true, "canceled": true}
+
+func (in BulkRescheduleInput) validate() error {
+	f := in.Filter
+	if f.SeasonID == nil && (f.ExhibitionOnly == nil || !*f.ExhibitionOnly) && f.TeamID == nil &&
+		f.PlayerID == nil && f.ScheduledFrom == nil && f.ScheduledTo == nil {
+		return errors.New("filter must include a season, exhibitionOnly, team, player or date range")
+	}
+	for _, st := range f.Status {
+		if !bulkRescheduleStatuses[st] {
+			return errors.New("only scheduled or canceled games can be rescheduled")
+		}
+	}
+	if in.ShiftMinutes != nil && in.Date != nil {
+		return errors.New("use either shiftMinutes or date, not both")
+	}
+	if in.VenueID != nil && in.Location != nil {
+		return errors.New("use either venueId or location, not both")
+	}
+	if in.ShiftMinutes == nil && in.Date == nil && in.VenueID == nil && in.Location == nil {
+		return errors.New("nothing to change: give shiftMinutes, date, venueId or location")
+	}
+	if in.ShiftMinutes != nil && *in.ShiftMinutes == 0 {
+		return errors.New("shiftMinutes must be non-zero")
+	}
+	return nil
+}
+
+// BulkReschedule applies the change to every matching game in one transaction, then
+// checks each moved game for scheduling conflicts against the post-change state, so
+// games moved together are checked against each other. Blocking conflicts roll the
+// whole batch back and are returned as a *ScheduleConflictError alongside the summary;
+// a dry run always rolls back.
+func (s *GameService) BulkReschedule(ctx context.Context, in BulkRescheduleInput) (*BulkRescheduleResult, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+	if len(in.Filter.Status) == 0 {
+		in.Filter.Status = []string{"scheduled"}
+	}
+
+	var date *time.Time
+	if in.Date != nil {
+		d, err := parseYMD(*in.Date)
+		if err != nil {
+			return nil, errors.New("date must be YYYY-MM-DD")
+		}
+		date = &d
+	}
+	var venue *models.Venue
+	if in.VenueID != nil {
+		v, _, err := resolveVenueBoard(ctx, s.repos, in.VenueID, nil)
+		if err != nil {
+			return nil, err
+		}
+		venue = v
+	}
+	var location *string
+	if in.Location != nil {
+		if l := strings.TrimSpace(*in.Location); l != "" {
+			location = &l
+		}
+	}
+
+	out := &BulkRescheduleResult{
+		DryRun:    in.DryRun,
+		Changes:   []BulkGameChange{},
+		Skipped:   []BulkSkip{},
+		Conflicts: []ScheduleConflict{},
+	}
+	var conflictErr error
+	seasons := map[int64]bool{}
+
+	err := s.repos.Transaction(ctx, func(tx *repositories.RepositoriesCollection) error {
+		games, err := tx.GameRepo.ListAll(ctx, repositories.ListGamesFilter{
+			SeasonID:       in.Filter.SeasonID,
+			ExhibitionOnly: in.Filter.ExhibitionOnly,
+			Status:         in.Filter.Status,
+			MatchType:      in.Filter.MatchType,
+			ScheduledFrom:  in.Filter.ScheduledFrom,
+			ScheduledTo:    in.Filter.ScheduledTo,
+			TeamID:         in.Filter.TeamID,
+			PlayerID:       in.Filter.PlayerID,
+		})
+		if err != nil {
+			return err
+		}
+		out.Matched = len(games)
+
+		moved := make([]models.Game, 0, len(games))
+		for _, g := range games {
+			if (in.ShiftMinutes != nil || date != nil) && g.ScheduledAt == nil {
+				out.Skipped = append(out.Skipped, BulkSkip{GameID: g.ID, Reason: "game has no scheduledAt"})
+				continue
+			}
+			next := g
+			fields := map[string]any{}
+			if in.ShiftMinutes != nil {
+				at := g.ScheduledAt.Add(time.Duration(*in.ShiftMinutes) * time.Minute)
+				next.ScheduledAt, fields["scheduled_at"] = &at, at
+			}
+			if date != nil {
+				loc, err := time.LoadLocation(g.Timezone)
+				if err != nil {
+					loc = time.UTC
+				}
+				local := g.ScheduledAt.In(loc)
+				at := time.Date(date.Year(), date.Month(), date.Day(), local.Hour(), local.Minute(), local.Second(), 0, loc).UTC()
+				if !at.Equal(*g.ScheduledAt) {
+					next.ScheduledAt, fields["scheduled_at"] = &at, at
+				}
+			}
+			if venue != nil && !sameID(g.VenueID, &venue.ID) {
+				next.VenueID, next.BoardID, next.Location = &venue.ID, nil, &venue.Name
+				fields["venue_id"], fields["board_id"], fields["location"] = venue.ID, nil, venue.Name
+			}
+			if in.Location != nil && (g.VenueID != nil || !sameString(g.Location, location)) {
+				next.VenueID, next.BoardID, next.Location = nil, nil, location
+				fields["venue_id"], fields["board_id"], fields["location"] = nil, nil, location
+			}
+			if len(fields) == 0 {
+				out.Skipped = append(out.Skipped, BulkSkip{GameID: g.ID, Reason: "already matches"})
+				continue
+			}
+			if _, err := tx.GameRepo.UpdateFields(ctx, g.ID, fields); err != nil {
+				return err
+			}
+			out.Changes = append(out.Changes, BulkGameChange{GameID: g.ID, Before: slotOf(g), After: slotOf(next)})
+			moved = append(moved, next)
+			if g.SeasonID != nil {
+				seasons[*g.SeasonID] = true
+			}
+		}
+		out.Changed = len(out.Changes)
+
+		avail := s.avail.withRepos(tx)
+		for i := range moved {
+			if moved[i].Status != "scheduled" {
+				continue
+			}
+			sides, err := tx.GameSideRepo.ListByGame(ctx, moved[i].ID)
+			if err != nil {
+				return err
+			}
+			cs, err := avail.CheckGame(ctx, &moved[i], sides)
+			if err != nil {
+				return err
+			}
+			out.Conflicts = append(out.Conflicts, cs...)
+		}
+
+		if blocking := blockingConflicts(out.Conflicts, in.AllowConflicts); len(blocking) > 0 {
+			conflictErr = &ScheduleConflictError{Conflicts: blocking}
+			return errBulkRollback
+		}
+		if in.DryRun {
+			return errBulkRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkRollback) {
+		return nil, err
+	}
+	if conflictErr != nil {
+		return out, conflictErr
+	}
+	if !in.DryRun {
+		out.Applied = true
+		for id := range seasons {
+			id := id
+			invalidateSeasons(s.cache, &id)
+		}
+	}
+	return out, nil
+}
+
+func slotOf(g models.Game) GameSlot {
+	return GameSlot{ScheduledAt: g.ScheduledAt, Location: g.Location, VenueID: g.VenueID, BoardID: g.BoardID}
+}
+
+func sameString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+// Built-in game settings, used when neither the request, season nor league sets one.
+const (
+	defaultTimezone     = "America/New_York"
+	defaultTargetPoints = 100
+)
+
+// GameDefaultsInput sets a league's or season's defaults for new games. On update a
+// nil field is left alone, and "" (or 0 target points) clears the default.
+type GameDefaultsInput struct {
+	TargetPoints *int    `json:"targetPoints,omitempty"`
+	MatchType    *string `json:"matchType,omitempty"`   // "teams" | "players"
+	ScoringMode  *string `json:"scoringMode,omitempty"` // "first_to" | "highest_total"
+	ColorPolicy  *string `json:"colorPolicy,omitempty"` // "natural" | "side_a_white" | "random"
+}
+
+// apply validates in and merges it into d.
+func (in *GameDefaultsInput) apply(d *models.GameDefaults) error {
+	if in == nil {
+		return nil
+	}
+	if in.TargetPoints != nil {
+		switch {
+		case *in.TargetPoints < 0:
+			return errors.New("defaults.targetPoints must be > 0")
+		case *in.TargetPoints == 0:
+			d.TargetPoints = nil
+		default:
+			v := *in.TargetPoints
+			d.TargetPoints = &v
+		}
+	}
+	if in.MatchType != nil {
+		d.MatchType = nil
+		if v := strings.ToLower(strings.TrimSpace(*in.MatchType)); v != "" {
+			if v != "teams" && v != "players" {
+				return errors.New("defaults.matchType must be 'teams' or 'players'")
+			}
+			d.MatchType = &v
+		}
+	}
+	if in.ScoringMode != nil {
+		d.ScoringMode = nil
+		if v := strings.ToLower(strings.TrimSpace(*in.ScoringMode)); v != "" {
+			mode, err := parseScoringMode(v)
+			if err != nil {
+				return errors.New("defaults." + err.Error())
+			}
+			d.ScoringMode = &mode
+		}
+	}
+	if in.ColorPolicy != nil {
+		d.ColorPolicy = nil
+		if v := strings.ToLower(strings.TrimSpace(*in.ColorPolicy)); v != "" {
+			policy, err := parseColorPolicy(v)
+			if err != nil {
+				return errors.New("defaults." + err.Error())
+			}
+			d.ColorPolicy = &policy
+		}
+	}
+	return nil
+}
+
+// defaultsFields lists d's columns for an UpdateFields map.
+func defaultsFields(d models.GameDefaults) map[string]any {
+	return map[string]any{
+		"default_target_points": d.TargetPoints,
+		"default_match_type":    d.MatchType,
+		"default_scoring_mode":  d.ScoringMode,
+		"default_color_policy":  d.ColorPolicy,
+	}
+}
+
+func parseScoringMode(s string) (models.ScoringMode, error) {
+	switch m := models.ScoringMode(strings.ToLower(strings.TrimSpace(s))); m {
+	case models.ScoringFirstTo, models.ScoringHighestTotal:
+		return m, nil
+	default:
+		return "", errors.New("scoringMode must be 'first_to' or 'highest_total'")
+	}
+}
+
+func parseColorPolicy(s string) (models.ColorPolicy, error) {
+	switch p := models.ColorPolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case models.ColorPolicyNatural, models.ColorPolicySideAWhite, models.ColorPolicyRandom:
+		return p, nil
+	default:
+		return "", errors.New("colorPolicy must be 'natural', 'side_a_white' or 'random'")
+	}
+}
+
+// gameSettingsRequest holds the settings a caller gave explicitly; nil or empty
+// fields are inherited.
+type gameSettingsRequest struct {
+	Timezone     *string
+	TargetPoints *int
+	MatchType    string
+	ScoringMode  *string
+	ColorPolicy  *string
+}
+
+// gameSettings are a new game's inheritable settings and where each came from.
+type gameSettings struct {
+	Timezone     string
+	TargetPoints int
+	MatchType    string
+	ScoringMode  models.ScoringMode
+	ColorPolicy  models.ColorPolicy
+	Provenance   models.GameProvenance
+}
+
+// resolveGameSettings fills in a new game's settings: the request wins, then the
+// venue (timezone only), the season, the season's league and the built-in default.
+// Match type has no built-in default and must come from somewhere.
+func resolveGameSettings(ctx context.Context, repos *repositories.RepositoriesCollection, seasonID *int64, venue *models.Venue, req gameSettingsRequest) (*gameSettings, error) {
+	var (
+		season *models.Season
+		league *models.League
+	)
+	if seasonID != nil {
+		sz, err := repos.SeasonRepo.GetByID(ctx, *seasonID)
+		if err != nil {
+			return nil, errors.New("season not found")
+		}
+		season = sz
+		if l, err := repos.LeagueRepo.GetByID(ctx, sz.LeagueID); err == nil {
+			league = l
+		}
+	}
+	var seasonDefaults, leagueDefaults models.GameDefaults
+	if season != nil {
+		seasonDefaults = season.Defaults
+	}
+	if league != nil {
+		leagueDefaults = league.Defaults
+	}
+	out := &gameSettings{}
+
+	// Timezone
+	switch {
+	case req.Timezone != nil && strings.TrimSpace(*req.Timezone) != "":
+		out.Timezone, out.Provenance.Timezone = strings.TrimSpace(*req.Timezone), models.SourceRequest
+	case venue != nil && venue.Timezone != "":
+		out.Timezone, out.Provenance.Timezone = venue.Timezone, models.SourceVenue
+	case season != nil && season.Timezone != "" && !season.TimezoneInherited:
+		out.Timezone, out.Provenance.Timezone = season.Timezone, models.SourceSeason
+	case league != nil && league.Timezone != nil && *league.Timezone != "":
+		out.Timezone, out.Provenance.Timezone = *league.Timezone, models.SourceLeague
+	default:
+		out.Timezone, out.Provenance.Timezone = defaultTimezone, models.SourceDefault
+	}
+	if _, err := time.LoadLocation(out.Timezone); err != nil {
+		return nil, errors.New("invalid timezone")
+	}
+
+	// Target points
+	switch {
+	case req.TargetPoints != nil && *req.TargetPoints > 0:
+		out.TargetPoints, out.Provenance.TargetPoints = *req.TargetPoints, models.SourceRequest
+	case seasonDefaults.TargetPoints != nil:
+		out.TargetPoints, out.Provenance.TargetPoints = *seasonDefaults.TargetPoints, models.SourceSeason
+	case leagueDefaults.TargetPoints != nil:
+		out.TargetPoints, out.Provenance.TargetPoints = *leagueDefaults.TargetPoints, models.SourceLeague
+	default:
+		out.TargetPoints, out.Provenance.TargetPoints = defaultTargetPoints, models.SourceDefault
+	}
+
+	// Match type
+	switch mt := strings.ToLower(strings.TrimSpace(req.MatchType)); {
+	case mt != "":
+		out.MatchType, out.Provenance.MatchType = mt, models.SourceRequest
+	case seasonDefaults.MatchType != nil:
+		out.MatchType, out.Provenance.MatchType = *seasonDefaults.MatchType, models.SourceSeason
+	case leagueDefaults.MatchType != nil:
+		out.MatchType, out.Provenance.MatchType = *leagueDefaults.MatchType, models.SourceLeague
+	}
+	if out.MatchType != "teams" && out.MatchType != "players" {
+		return nil, errors.New("matchType must be 'teams' or 'players'")
+	}
+
+	// Scoring mode
+	switch {
+	case req.ScoringMode != nil && strings.TrimSpace(*req.ScoringMode) != "":
+		mode, err := parseScoringMode(*req.ScoringMode)
+		if err != nil {
+			return nil, err
+		}
+		out.ScoringMode, out.Provenance.ScoringMode = mode, models.SourceRequest
+	case seasonDefaults.ScoringMode != nil:
+		out.ScoringMode, out.Provenance.ScoringMode = *seasonDefaults.ScoringMode, models.SourceSeason
+	case leagueDefaults.ScoringMode != nil:
+		out.ScoringMode, out.Provenance.ScoringMode = *leagueDefaults.ScoringMode, models.SourceLeague
+	default:
+		out.ScoringMode, out.Provenance.ScoringMode = models.ScoringFirstTo, models.SourceDefault
+	}
+
+	// Colour policy
+	switch {
+	case req.ColorPolicy != nil && strings.TrimSpace(*req.ColorPolicy) != "":
+		policy, err := parseColorPolicy(*req.ColorPolicy)
+		if err != nil {
+			return nil, err
+		}
+		out.ColorPolicy, out.Provenance.ColorPolicy = policy, models.SourceRequest
+	case seasonDefaults.ColorPolicy != nil:
+		out.ColorPolicy, out.Provenance.ColorPolicy = *seasonDefaults.ColorPolicy, models.SourceSeason
+	case leagueDefaults.ColorPolicy != nil:
+		out.ColorPolicy, out.Provenance.ColorPolicy = *leagueDefaults.ColorPolicy, models.SourceLeague
+	default:
+		out.ColorPolicy, out.Provenance.ColorPolicy = models.ColorPolicyNatural, models.SourceDefault
+	}
+
+	return out, nil
+}
+
+// applyColorPolicy colours sides a and b under policy, leaving any side whose colour
+// was given explicitly as it is.
+func applyColorPolicy(policy models.ColorPolicy, a, b *models.GameSide, explicitA, explicitB bool) {
+	var colorA, colorB models.DiscColor
+	switch policy {
+	case models.ColorPolicySideAWhite:
+		colorA, colorB = models.DiscWhite, models.DiscBlack
+	case models.ColorPolicyRandom:
+		colorA, colorB = models.DiscWhite, models.DiscBlack
+		if rand.IntN(2) == 1 {
+			colorA, colorB = colorB, colorA
+		}
+	default:
+		colorA, colorB = models.DiscNatural, models.DiscNatural
+	}
+	// Keep the pair opposite when only one side was coloured explicitly.
+	if policy != models.ColorPolicyNatural {
+		if explicitA && !explicitB && a.Color != models.DiscNatural {
+			colorB = oppositeColor(a.Color)
+		}
+		if explicitB && !explicitA && b.Color != models.DiscNatural {
+			colorA = oppositeColor(b.Color)
+		}
+	}
+	if !explicitA {
+		a.Color = colorA
+	}
+	if !explicitB {
+		b.Color = colorB
+	}
+}
+
+func oppositeColor(c models.DiscColor) models.DiscColor {
+	if c == models.DiscWhite {
+		return models.DiscBlack
+	}
+	return models.DiscWhite
+}
@@ -7,18 +7,27 @@ import (
 	"strings"
 	"time"
 
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
 	"github.com/matt-j-deasy/betty-crokers-api/models"
 	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
 )
 
 type GameService struct {
-	repos *repositories.RepositoriesCollection
+	repos   *repositories.RepositoriesCollection
+	cache   cache.StatsCache
+	records *RecordService
+	avail   *AvailabilityService
 }
 
-func NewGameService(repos *repositories.RepositoriesCollection) *GameService {
-	return &GameService{repos: repos}
+func NewGameService(repos *repositories.RepositoriesCollection, statsCache cache.StatsCache, records *RecordService, avail *AvailabilityService) *GameService {
+	return &GameService{repos: repos, cache: statsCache, records: records, avail: avail}
 }
 
+// ErrDraftGame rejects starting, scoring or completing a game that is not published,
+// so unpublished results never reach stats or standings.
+var ErrDraftGame = errors.New("draft games cannot be started, scored or completed; publish the game first")
+
 /* =========================
    DTOs
 ========================= */
@@ -31,27 +40,44 @@ type GameParticipantInput struct {
 
 type CreateGameInput struct {
 	SeasonID     *int64               `json:"seasonId,omitempty"`     // nil => exhibition
-	MatchType    string               `json:"matchType"`              // "teams" | "players"
-	TargetPoints *int                 `json:"targetPoints,omitempty"` // default 100
+	MatchdayID   *int64               `json:"matchdayId,omitempty"`   // implies its season; its venue is the default
+	MatchType    string               `json:"matchType,omitempty"`    // "teams" | "players"; default from season or league
+	TargetPoints *int                 `json:"targetPoints,omitempty"` // default from season or league, else 100
+	ScoringMode  *string              `json:"scoringMode,omitempty"`  // "first_to" | "highest_total"
+	ColorPolicy  *string              `json:"colorPolicy,omitempty"`  // "natural" | "side_a_white" | "random"
 	ScheduledAt  *string              `json:"scheduledAt,omitempty"`  // RFC3339
-	Timezone     *string              `json:"timezone,omitempty"`     // default from season or America/New_York
-	Location     *string              `json:"location,omitempty"`
+	Timezone     *string              `json:"timezone,omitempty"`     // default from venue, season or league, else America/New_York
+	Location     *string              `json:"location,omitempty"`     // ignored when a venue is given
+	VenueID      *int64               `json:"venueId,omitempty"`
+	BoardID      *int64               `json:"boardId,omitempty"` // implies its venue
 	Description  *string              `json:"description,omitempty"`
+	Draft        *bool                `json:"draft,omitempty"` // default: the matchday's state, else published
 	SideA        GameParticipantInput `json:"sideA"`
 	SideB        GameParticipantInput `json:"sideB"`
+	// AllowConflicts schedules despite availability, double-booking or full-venue
+	// conflicts. A board already in use is always rejected.
+	AllowConflicts bool `json:"allowConflicts,omitempty"`
 }
 
 type UpdateGameInput struct {
 	SeasonID     *int64  `json:"seasonId,omitempty"`
+	MatchdayID   *int64  `json:"matchdayId,omitempty"` // 0 detaches; a season change detaches unless given
 	TargetPoints *int    `json:"targetPoints,omitempty"`
+	ScoringMode  *string `json:"scoringMode,omitempty"` // "first_to" | "highest_total"
 	ScheduledAt  *string `json:"scheduledAt,omitempty"` // RFC3339 or "" to clear
 	Timezone     *string `json:"timezone,omitempty"`
 	Location     *string `json:"location,omitempty"`    // can be null via handler->fields map if you want clearing
+	VenueID      *int64  `json:"venueId,omitempty"`     // 0 clears venue and board
+	BoardID      *int64  `json:"boardId,omitempty"`     // 0 clears the board
 	Description  *string `json:"description,omitempty"` // can be null via handler->fields map if you want clearing
 	Status       *string `json:"status,omitempty"`      // "scheduled"|"in_progress"|"completed"|"canceled"
+	Draft        *bool   `json:"draft,omitempty"`       // true moves a scheduled game back to draft; publish to undo
 	// Note: winner is computed; do not set directly
 	SideAColor *models.DiscColor `json:"sideAColor,omitempty"` // "white" | "black" | "natural"
 	SideBColor *models.DiscColor `json:"sideBColor,omitempty"` // "white" | "black" | "natural"
+	// AllowConflicts reschedules despite availability, double-booking or full-venue
+	// conflicts. A board already in use is always rejected.
+	AllowConflicts bool `json:"allowConflicts,omitempty"`
 }
 
 type ListGamesOptions struct {
@@ -61,11 +87,18 @@ type ListGamesOptions struct {
 	MatchType      *string
 	ScheduledFrom  *time.Time
 	ScheduledTo    *time.Time
-	TeamID         *int64
-	PlayerID       *int64
-	Page           int
-	Size           int
-	OrderBy        string // e.g. "scheduled_at desc"
+	// Local calendar dates (YYYY-MM-DD, inclusive) in Timezone; they combine with
+	// ScheduledFrom/ScheduledTo.
+	ScheduledFromDate *string
+	ScheduledToDate   *string
+	Timezone          *string // default: the season's timezone, else America/New_York
+	TeamID            *int64
+	PlayerID          *int64
+	TemplateID        *int64
+	IncludeDrafts     bool // admins see unpublished games
+	Page              int
+	Size              int
+	OrderBy           string // e.g. "scheduled_at desc"
 }
 
 type PagedGames struct {
@@ -80,22 +113,50 @@ type PagedGames struct {
 ========================= */
 
 func (s *GameService) Create(ctx context.Context, in CreateGameInput) (*models.Game, []models.GameSide, error) {
-	mt := strings.ToLower(strings.TrimSpace(in.MatchType))
-	if mt != "teams" && mt != "players" {
-		return nil, nil, errors.New("matchType must be 'teams' or 'players'")
+	// Matchday (optional) supplies the season, a default venue and the draft state
+	draft := in.Draft != nil && *in.Draft
+	if in.MatchdayID != nil {
+		md, err := resolveMatchday(ctx, s.repos, *in.MatchdayID, in.SeasonID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if in.Draft == nil {
+			draft = md.Draft
+		}
+		in.SeasonID = &md.SeasonID
+		if in.VenueID == nil && in.BoardID == nil {
+			in.VenueID = md.VenueID
+		}
 	}
 
-	// Season (optional)
-	var seasonTZ string = "America/New_York"
-	if in.SeasonID != nil {
-		if _, err := s.repos.SeasonRepo.GetByID(ctx, *in.SeasonID); err != nil {
-			return nil, nil, errors.New("season not found")
-		}
-		// If you want season default TZ, fetch and use it (uncomment if needed):
-		// sz, _ := s.repos.SeasonRepo.GetByID(ctx, *in.SeasonID)
-		// seasonTZ = sz.Timezone
+	// Venue/board; the venue supplies the location and default timezone
+	venue, board, err := resolveVenueBoard(ctx, s.repos, in.VenueID, in.BoardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	location := in.Location
+	var venueID, boardID *int64
+	if venue != nil {
+		location, venueID = &venue.Name, &venue.ID
+	}
+	if board != nil {
+		boardID = &board.ID
 	}
 
+	// Timezone, target points, match type, scoring and colours: the request wins,
+	// then the venue, season, league and built-in defaults.
+	settings, err := resolveGameSettings(ctx, s.repos, in.SeasonID, venue, gameSettingsRequest{
+		Timezone:     in.Timezone,
+		TargetPoints: in.TargetPoints,
+		MatchType:    in.MatchType,
+		ScoringMode:  in.ScoringMode,
+		ColorPolicy:  in.ColorPolicy,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	mt := settings.MatchType
+
 	// Validate sides according to match type
 	sideA, err := s.buildSide(ctx, "A", mt, in.SideA)
 	if err != nil {
@@ -115,21 +176,7 @@ func (s *GameService) Create(ctx context.Context, in CreateGameInput) (*models.G
 			return nil, nil, errors.New("player A and player B cannot be the same")
 		}
 	}
-
-	// Target points
-	target := 100
-	if in.TargetPoints != nil && *in.TargetPoints > 0 {
-		target = *in.TargetPoints
-	}
-
-	// Timezone
-	tz := seasonTZ
-	if in.Timezone != nil && *in.Timezone != "" {
-		if _, err := time.LoadLocation(*in.Timezone); err != nil {
-			return nil, nil, errors.New("invalid timezone")
-		}
-		tz = *in.Timezone
-	}
+	applyColorPolicy(settings.ColorPolicy, &sideA, &sideB, in.SideA.Color != nil && *in.SideA.Color != "", in.SideB.Color != nil && *in.SideB.Color != "")
 
 	// ScheduledAt
 	var scheduledAt *time.Time
@@ -143,27 +190,31 @@ func (s *GameService) Create(ctx context.Context, in CreateGameInput) (*models.G
 
 	game := &models.Game{
 		SeasonID:     in.SeasonID,
+		MatchdayID:   in.MatchdayID,
 		MatchType:    mt,
-		TargetPoints: target,
+		TargetPoints: settings.TargetPoints,
+		ScoringMode:  settings.ScoringMode,
+		ColorPolicy:  settings.ColorPolicy,
+		Provenance:   settings.Provenance,
 		Status:       "scheduled",
+		Draft:        draft,
 		ScheduledAt:  scheduledAt,
-		Timezone:     tz,
-		Location:     in.Location,
+		Timezone:     settings.Timezone,
+		VenueID:      venueID,
+		BoardID:      boardID,
+		Location:     location,
 		Description:  in.Description,
 	}
 
-	// Defaults
-	if sideA.Color == "" {
-		sideA.Color = models.DiscNatural
-	}
-	if sideB.Color == "" {
-		sideB.Color = models.DiscNatural
+	if err := s.checkConflicts(ctx, game, []models.GameSide{sideA, sideB}, in.AllowConflicts); err != nil {
+		return nil, nil, err
 	}
 
 	// Persist in one TX
 	if err := s.repos.GameRepo.CreateWithSides(ctx, game, []models.GameSide{sideA, sideB}); err != nil {
 		return nil, nil, err
 	}
+	invalidateSeasons(s.cache, game.SeasonID)
 	return game, []models.GameSide{sideA, sideB}, nil
 }
 
@@ -183,6 +234,18 @@ func (s *GameService) Update(ctx context.Context, id int64, in UpdateGameInput)
 
 	fields := map[string]any{}
 
+	draft := cur.Draft
+	if in.Draft != nil && *in.Draft != cur.Draft {
+		if !*in.Draft {
+			return nil, errors.New("use the publish endpoint to publish a draft game")
+		}
+		if cur.Status != "scheduled" {
+			return nil, errors.New("only scheduled games can be moved back to draft")
+		}
+		fields["draft"] = true
+		draft = true
+	}
+
 	if in.SeasonID != nil {
 		if *in.SeasonID == 0 {
 			// allow clearing to exhibition
@@ -195,11 +258,47 @@ func (s *GameService) Update(ctx context.Context, id int64, in UpdateGameInput)
 		}
 	}
 
+	if in.MatchdayID != nil || in.SeasonID != nil {
+		seasonID := cur.SeasonID
+		if v, ok := fields["season_id"]; ok {
+			seasonID = nil
+			if id, ok := v.(int64); ok {
+				seasonID = &id
+			}
+		}
+		switch {
+		case in.MatchdayID != nil && *in.MatchdayID == 0:
+			fields["matchday_id"] = nil
+		case in.MatchdayID != nil:
+			if seasonID == nil {
+				return nil, errors.New("exhibition games cannot be on a matchday")
+			}
+			if _, err := resolveMatchday(ctx, s.repos, *in.MatchdayID, seasonID); err != nil {
+				return nil, err
+			}
+			fields["matchday_id"] = *in.MatchdayID
+		case cur.MatchdayID != nil && !sameID(seasonID, cur.SeasonID):
+			fields["matchday_id"] = nil // the matchday belongs to the old season
+		}
+	}
+
+	// Values set here are recorded as coming from the request.
+	prov := cur.Provenance
 	if in.TargetPoints != nil {
 		if *in.TargetPoints <= 0 {
 			return nil, errors.New("targetPoints must be > 0")
 		}
 		fields["target_points"] = *in.TargetPoints
+		prov.TargetPoints = models.SourceRequest
+	}
+
+	if in.ScoringMode != nil {
+		mode, err := parseScoringMode(*in.ScoringMode)
+		if err != nil {
+			return nil, err
+		}
+		fields["scoring_mode"] = mode
+		prov.ScoringMode = models.SourceRequest
 	}
 
 	if in.ScheduledAt != nil {
@@ -222,6 +321,11 @@ func (s *GameService) Update(ctx context.Context, id int64, in UpdateGameInput)
 			return nil, errors.New("invalid timezone")
 		}
 		fields["timezone"] = *in.Timezone
+		prov.Timezone = models.SourceRequest
+	}
+
+	if prov != cur.Provenance {
+		fields["provenance"] = prov
 	}
 
 	if in.Location != nil {
@@ -233,8 +337,47 @@ func (s *GameService) Update(ctx context.Context, id int64, in UpdateGameInput)
 		fields["description"] = in.Description
 	}
 
+	if in.VenueID != nil || in.BoardID != nil {
+		venueID, boardID := cur.VenueID, cur.BoardID
+		if in.VenueID != nil {
+			venueID = in.VenueID
+			if *in.VenueID == 0 {
+				venueID = nil
+			}
+			if !sameID(venueID, cur.VenueID) {
+				boardID = nil // the old board belongs to the old venue
+			}
+		}
+		if in.BoardID != nil {
+			boardID = in.BoardID
+			if *in.BoardID == 0 {
+				boardID = nil
+			}
+		}
+		venue, board, err := resolveVenueBoard(ctx, s.repos, venueID, boardID)
+		if err != nil {
+			return nil, err
+		}
+		fields["venue_id"], fields["board_id"] = nil, nil
+		if venue != nil {
+			fields["venue_id"] = venue.ID
+			fields["location"] = venue.Name
+		}
+		if board != nil {
+			fields["board_id"] = board.ID
+		}
+	}
+
 	if in.Status != nil {
 		ns := strings.ToLower(*in.Status)
+		if draft && ns != cur.Status {
+			return nil, ErrDraftGame
+		}
+		if ns == "in_progress" || ns == "completed" {
+			if err := requireParticipants(ctx, s.repos, id); err != nil {
+				return nil, err
+			}
+		}
 		switch ns {
 		case "scheduled":
 			fields["status"] = ns
@@ -263,6 +406,43 @@ func (s *GameService) Update(ctx context.Context, id int64, in UpdateGameInput)
 		}
 	}
 
+	if in.SeasonID != nil || in.ScheduledAt != nil || in.Status != nil || in.VenueID != nil || in.BoardID != nil {
+		next := *cur
+		if v, ok := fields["season_id"]; ok {
+			next.SeasonID = nil
+			if id, ok := v.(int64); ok {
+				next.SeasonID = &id
+			}
+		}
+		if v, ok := fields["scheduled_at"]; ok {
+			next.ScheduledAt = nil
+			if t, ok := v.(time.Time); ok {
+				next.ScheduledAt = &t
+			}
+		}
+		if v, ok := fields["status"]; ok {
+			next.Status = v.(string)
+		}
+		if _, ok := fields["venue_id"]; ok {
+			next.VenueID, next.BoardID = nil, nil
+			if id, ok := fields["venue_id"].(int64); ok {
+				next.VenueID = &id
+			}
+			if id, ok := fields["board_id"].(int64); ok {
+				next.BoardID = &id
+			}
+		}
+		if next.Status == "scheduled" {
+			sides, err := s.repos.GameSideRepo.ListByGame(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.checkConflicts(ctx, &next, sides, in.AllowConflicts); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// First update the game row (if there are any game fields)
 	var updated *models.Game
 	if len(fields) == 0 {
@@ -286,11 +466,67 @@ func (s *GameService) Update(ctx context.Context, id int64, in UpdateGameInput)
 		}
 	}
 
+	// A season move affects both the old and the new season
+	invalidateSeasons(s.cache, cur.SeasonID, updated.SeasonID)
+	if resultChanged(cur, updated) {
+		s.records.RefreshForSeasons(ctx, cur.SeasonID, updated.SeasonID)
+	}
 	return updated, nil
 }
 
+// resultChanged reports whether an update touched anything the records book is
+// derived from. Points and twenties are written through GameSideService.
+func resultChanged(cur, updated *models.Game) bool {
+	return cur.Status != updated.Status ||
+		!sameID(cur.SeasonID, updated.SeasonID) ||
+		!sameTime(cur.EndedAt, updated.EndedAt) ||
+		!sameString(cur.WinnerSide, updated.WinnerSide)
+}
+
+// checkConflicts returns a *ScheduleConflictError if game would break any
+// participant's availability in its season, double-book a player, or exceed venue
+// capacity. With allow set only board_booked conflicts are reported.
+func (s *GameService) checkConflicts(ctx context.Context, game *models.Game, sides []models.GameSide, allow bool) error {
+	conflicts, err := s.avail.CheckGame(ctx, game, sides)
+	if err != nil {
+		return err
+	}
+	if blocking := blockingConflicts(conflicts, allow); len(blocking) > 0 {
+		return &ScheduleConflictError{Conflicts: blocking}
+	}
+	return nil
+}
+
+func sameID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
 func (s *GameService) Delete(ctx context.Context, id int64) error {
-	return s.repos.GameRepo.DeleteByID(ctx, id)
+	cur, err := s.repos.GameRepo.GetByID(ctx, id)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			return nil // already gone; deletes stay idempotent
+		}
+		return err
+	}
+	if err := s.repos.GameRepo.DeleteByID(ctx, id); err != nil {
+		return err
+	}
+	invalidateSeasons(s.cache, cur.SeasonID)
+	if cur.Status == "completed" {
+		s.records.RefreshForSeasons(ctx, cur.SeasonID)
+	}
+	return nil
 }
 
 func (s *GameService) List(ctx context.Context, opts ListGamesOptions) (*PagedGames, error) {
@@ -303,6 +539,13 @@ func (s *GameService) List(ctx context.Context, opts ListGamesOptions) (*PagedGa
 		size = 25
 	}
 	slog.Debug("Listing games", "options", opts, "page", page, "size", size)
+	from, until, err := s.localDateRange(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if from != nil && (opts.ScheduledFrom == nil || from.After(*opts.ScheduledFrom)) {
+		opts.ScheduledFrom = from
+	}
 	items, total, err := s.repos.GameRepo.List(ctx, repositories.ListGamesFilter{
 		SeasonID:       opts.SeasonID,
 		ExhibitionOnly: opts.ExhibitionOnly,
@@ -310,8 +553,11 @@ func (s *GameService) List(ctx context.Context, opts ListGamesOptions) (*PagedGa
 		MatchType:      opts.MatchType,
 		ScheduledFrom:  opts.ScheduledFrom,
 		ScheduledTo:    opts.ScheduledTo,
+		ScheduledUntil: until,
 		TeamID:         opts.TeamID,
 		PlayerID:       opts.PlayerID,
+		TemplateID:     opts.TemplateID,
+		PublishedOnly:  !opts.IncludeDrafts,
 		Offset:         (page - 1) * size,
 		Limit:          size,
 		OrderBy:        opts.OrderBy,
@@ -328,6 +574,50 @@ func (s *GameService) List(ctx context.Context, opts ListGamesOptions) (*PagedGa
    Helpers
 ========================= */
 
+// localDateRange turns the local-date filters into UTC bounds: from is local
+// midnight at the start of ScheduledFromDate, until is local midnight after
+// ScheduledToDate (exclusive). Midnights are computed in the zone, so a day is
+// 23 or 25 hours long across a DST change.
+func (s *GameService) localDateRange(ctx context.Context, opts ListGamesOptions) (from, until *time.Time, err error) {
+	if opts.ScheduledFromDate == nil && opts.ScheduledToDate == nil {
+		return nil, nil, nil
+	}
+	tz := defaultTimezone
+	switch {
+	case opts.Timezone != nil && *opts.Timezone != "":
+		tz = *opts.Timezone
+	case opts.SeasonID != nil:
+		season, err := s.repos.SeasonRepo.GetByID(ctx, *opts.SeasonID)
+		if err != nil && !utils.IsNotFound(err) {
+			return nil, nil, err
+		}
+		if season != nil && season.Timezone != "" {
+			tz = season.Timezone
+		}
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, nil, errors.New("invalid timezone")
+	}
+	if opts.ScheduledFromDate != nil {
+		d, err := parseYMD(*opts.ScheduledFromDate)
+		if err != nil {
+			return nil, nil, errors.New("scheduledFrom must be RFC3339 or YYYY-MM-DD")
+		}
+		t := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc).UTC()
+		from = &t
+	}
+	if opts.ScheduledToDate != nil {
+		d, err := parseYMD(*opts.ScheduledToDate)
+		if err != nil {
+			return nil, nil, errors.New("scheduledTo must be RFC3339 or YYYY-MM-DD")
+		}
+		t := time.Date(d.Year(), d.Month(), d.Day()+1, 0, 0, 0, 0, loc).UTC()
+		until = &t
+	}
+	return from, until, nil
+}
+
 func (s *GameService) buildSide(ctx context.Context, label string, matchType string, in GameParticipantInput) (models.GameSide, error) {
 	var color models.DiscColor = models.DiscNatural
 	if in.Color != nil && *in.Color != "" {
@@ -378,6 +668,12 @@ func (s *GameService) CompleteWithWinner(ctx context.Context, id int64, winner s
 	if cur.Status == "canceled" {
 		return nil, errors.New("cannot complete a canceled game")
 	}
+	if cur.Draft {
+		return nil, ErrDraftGame
+	}
+	if err := requireParticipants(ctx, s.repos, id); err != nil {
+		return nil, err
+	}
 
 	now := time.Now().UTC()
 	fields := map[string]any{
@@ -387,5 +683,11 @@ func (s *GameService) CompleteWithWinner(ctx context.Context, id int64, winner s
 	if cur.EndedAt == nil {
 		fields["ended_at"] = &now
 	}
-	return s.repos.GameRepo.UpdateFields(ctx, id, fields)
+	updated, err := s.repos.GameRepo.UpdateFields(ctx, id, fields)
+	if err != nil {
+		return nil, err
+	}
+	invalidateSeasons(s.cache, updated.SeasonID)
+	s.records.RefreshForSeasons(ctx, updated.SeasonID)
+	return updated, nil
 }
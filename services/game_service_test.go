@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLocalDateRange(t *testing.T) {
+	str := func(s string) *string { return &s }
+	utc := func(s string) *time.Time {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			panic(err)
+		}
+		return &t
+	}
+
+	tests := []struct {
+		name      string
+		opts      ListGamesOptions
+		wantFrom  *time.Time
+		wantUntil *time.Time
+		wantErr   bool
+	}{
+		{
+			name: "no date filters",
+			opts: ListGamesOptions{Timezone: str("America/New_York")},
+		},
+		{
+			name:      "spring forward: the day is 23 hours",
+			opts:      ListGamesOptions{ScheduledFromDate: str("2025-03-09"), ScheduledToDate: str("2025-03-09"), Timezone: str("America/New_York")},
+			wantFrom:  utc("2025-03-09T05:00:00Z"),
+			wantUntil: utc("2025-03-10T04:00:00Z"),
+		},
+		{
+			name:      "fall back: the day is 25 hours",
+			opts:      ListGamesOptions{ScheduledFromDate: str("2025-11-02"), ScheduledToDate: str("2025-11-02"), Timezone: str("America/New_York")},
+			wantFrom:  utc("2025-11-02T04:00:00Z"),
+			wantUntil: utc("2025-11-03T05:00:00Z"),
+		},
+		{
+			name:      "range spanning a change uses each end's own offset",
+			opts:      ListGamesOptions{ScheduledFromDate: str("2025-03-01"), ScheduledToDate: str("2025-03-31"), Timezone: str("America/New_York")},
+			wantFrom:  utc("2025-03-01T05:00:00Z"),
+			wantUntil: utc("2025-04-01T04:00:00Z"),
+		},
+		{
+			name:      "Europe changes on a different weekend",
+			opts:      ListGamesOptions{ScheduledFromDate: str("2025-03-30"), ScheduledToDate: str("2025-03-30"), Timezone: str("Europe/London")},
+			wantFrom:  utc("2025-03-30T00:00:00Z"),
+			wantUntil: utc("2025-03-30T23:00:00Z"),
+		},
+		{
+			name:     "without a timezone or season the default zone applies",
+			opts:     ListGamesOptions{ScheduledFromDate: str("2025-07-04")},
+			wantFrom: utc("2025-07-04T04:00:00Z"),
+		},
+		{
+			name:      "only an end date",
+			opts:      ListGamesOptions{ScheduledToDate: str("2025-12-31"), Timezone: str("UTC")},
+			wantUntil: utc("2026-01-01T00:00:00Z"),
+		},
+		{
+			name:    "unknown timezone",
+			opts:    ListGamesOptions{ScheduledFromDate: str("2025-03-09"), Timezone: str("Mars/Olympus_Mons")},
+			wantErr: true,
+		},
+		{
+			name:    "malformed date",
+			opts:    ListGamesOptions{ScheduledToDate: str("2025-3-9"), Timezone: str("UTC")},
+			wantErr: true,
+		},
+	}
+
+	same := func(a, b *time.Time) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return a.Equal(*b)
+	}
+	s := &GameService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, until, err := s.localDateRange(context.Background(), tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %v, %v; want an error", from, until)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !same(from, tt.wantFrom) {
+				t.Errorf("from = %v, want %v", from, tt.wantFrom)
+			}
+			if !same(until, tt.wantUntil) {
+				t.Errorf("until = %v, want %v", until, tt.wantUntil)
+			}
+		})
+	}
+}
@@ -6,16 +6,20 @@ import (
 	"strings"
 	"time"
 
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
 	"github.com/matt-j-deasy/betty-crokers-api/models"
 	"github.com/matt-j-deasy/betty-crokers-api/repositories"
 )
 
 type GameSideService struct {
-	repos *repositories.RepositoriesCollection
+	repos   *repositories.RepositoriesCollection
+	cache   cache.StatsCache
+	records *RecordService
+	avail   *AvailabilityService
 }
 
-func NewGameSideService(repos *repositories.RepositoriesCollection) *GameSideService {
-	return &GameSideService{repos: repos}
+func NewGameSideService(repos *repositories.RepositoriesCollection, statsCache cache.StatsCache, records *RecordService, avail *AvailabilityService) *GameSideService {
+	return &GameSideService{repos: repos, cache: statsCache, records: records, avail: avail}
 }
 
 /* =========================
@@ -40,6 +44,23 @@ type SetPointsInput struct {
 	Points int    `json:"points"` // >= 0
 }
 
+type SetTwentiesInput struct {
+	GameID   int64  `json:"gameId"`
+	Side     string `json:"side"`     // "A"|"B"
+	Twenties int    `json:"twenties"` // >= 0
+}
+
+// SetSideParticipantInput draws a side's participant, typically for a game
+// materialized from a template with the side "to be drawn".
+type SetSideParticipantInput struct {
+	GameID   int64  `json:"gameId"`
+	Side     string `json:"side"` // "A"|"B"
+	TeamID   *int64 `json:"teamId,omitempty"`
+	PlayerID *int64 `json:"playerId,omitempty"`
+	// AllowConflicts assigns despite availability, double-booking or full-venue conflicts.
+	AllowConflicts bool `json:"allowConflicts,omitempty"`
+}
+
 /* =========================
    Operations
 ========================= */
@@ -63,9 +84,73 @@ func (s *GameSideService) SetColor(ctx context.Context, in SetSideColorInput) (*
 	if game.Status == "completed" || game.Status == "canceled" {
 		return nil, errors.New("cannot change color for completed/canceled game")
 	}
-	return s.repos.GameSideRepo.UpdateFieldsByGameAndSide(ctx, in.GameID, side, map[string]any{
+	out, err := s.repos.GameSideRepo.UpdateFieldsByGameAndSide(ctx, in.GameID, side, map[string]any{
 		"color": in.Color,
 	})
+	if err != nil {
+		return nil, err
+	}
+	invalidateSeasons(s.cache, game.SeasonID)
+	return out, nil
+}
+
+// SetParticipant sets who plays a side of a game that has not started yet.
+func (s *GameSideService) SetParticipant(ctx context.Context, in SetSideParticipantInput) (*models.GameSide, error) {
+	side := normalizeSide(in.Side)
+	if side == "" {
+		return nil, errors.New("side must be 'A' or 'B'")
+	}
+	game, sides, err := s.repos.GameRepo.GetWithSides(ctx, in.GameID)
+	if err != nil {
+		return nil, err
+	}
+	if game.Status != "scheduled" {
+		return nil, errors.New("participants can only be changed before the game starts")
+	}
+
+	var teamID, playerID *int64
+	if game.MatchType == "teams" {
+		if in.TeamID == nil || in.PlayerID != nil {
+			return nil, errors.New("teamId is required for team match")
+		}
+		if _, err := s.repos.TeamRepo.GetByID(ctx, *in.TeamID); err != nil {
+			return nil, errors.New("team not found")
+		}
+		teamID = in.TeamID
+	} else {
+		if in.PlayerID == nil || in.TeamID != nil {
+			return nil, errors.New("playerId is required for player match")
+		}
+		if _, err := s.repos.PlayerRepo.GetByID(ctx, *in.PlayerID); err != nil {
+			return nil, errors.New("player not found")
+		}
+		playerID = in.PlayerID
+	}
+
+	for i := range sides {
+		if sides[i].Side == side {
+			sides[i].TeamID, sides[i].PlayerID = teamID, playerID
+		} else if sameID(sides[i].TeamID, teamID) && sameID(sides[i].PlayerID, playerID) {
+			return nil, errors.New("side A and side B cannot be the same")
+		}
+	}
+	conflicts, err := s.avail.CheckGame(ctx, game, sides)
+	if err != nil {
+		return nil, err
+	}
+	if blocking := blockingConflicts(conflicts, in.AllowConflicts); len(blocking) > 0 {
+		return nil, &ScheduleConflictError{Conflicts: blocking}
+	}
+
+	out, err := s.repos.GameSideRepo.UpdateFieldsByGameAndSide(ctx, in.GameID, side, map[string]any{
+		"team_id":   teamID,
+		"player_id": playerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	invalidateSeasons(s.cache, game.SeasonID)
+	return out, nil
 }
 
 func (s *GameSideService) AddPoints(ctx context.Context, in AddPointsInput) (*models.Game, []models.GameSide, error) {
@@ -82,6 +167,39 @@ func (s *GameSideService) SetPoints(ctx context.Context, in SetPointsInput) (*mo
 	return s.adjustPoints(ctx, in.GameID, normalizeSide(in.Side), &in.Points, nil)
 }
 
+// SetTwenties records the side's twenty count. Unlike points, it may be corrected
+// after completion since twenties are often tallied once the game is over.
+func (s *GameSideService) SetTwenties(ctx context.Context, in SetTwentiesInput) (*models.GameSide, error) {
+	side := normalizeSide(in.Side)
+	if side == "" {
+		return nil, errors.New("side must be 'A' or 'B'")
+	}
+	if in.Twenties < 0 {
+		return nil, errors.New("twenties must be >= 0")
+	}
+	game, err := s.repos.GameRepo.GetByID(ctx, in.GameID)
+	if err != nil {
+		return nil, err
+	}
+	if game.Status == "canceled" {
+		return nil, errors.New("cannot change twenties for canceled game")
+	}
+	if game.Draft {
+		return nil, ErrDraftGame
+	}
+	out, err := s.repos.GameSideRepo.UpdateFieldsByGameAndSide(ctx, in.GameID, side, map[string]any{
+		"twenties": in.Twenties,
+	})
+	if err != nil {
+		return nil, err
+	}
+	invalidateSeasons(s.cache, game.SeasonID)
+	if game.Status == "completed" {
+		s.records.RefreshForSeasons(ctx, game.SeasonID)
+	}
+	return out, nil
+}
+
 /* =========================
    Internal
 ========================= */
@@ -95,8 +213,14 @@ func (s *GameSideService) adjustPoints(ctx context.Context, gameID int64, side s
 	if err != nil {
 		return nil, nil, err
 	}
+	if game.Draft {
+		return nil, nil, ErrDraftGame
+	}
 	switch game.Status {
 	case "scheduled":
+		if err := requireParticipants(ctx, s.repos, gameID); err != nil {
+			return nil, nil, err
+		}
 		// first score starts the game
 		now := time.Now().UTC()
 		if _, err := s.repos.GameRepo.UpdateFields(ctx, gameID, map[string]any{
@@ -135,6 +259,8 @@ func (s *GameSideService) adjustPoints(ctx context.Context, gameID int64, side s
 		return nil, nil, err
 	}
 
+	invalidateSeasons(s.cache, game.SeasonID)
+
 	if newPoints == sd.Points {
 		game, err := s.repos.GameRepo.GetByID(ctx, gameID)
 		if err != nil {
@@ -184,6 +310,20 @@ func (s *GameSideService) adjustPoints(ctx context.Context, gameID int64, side s
 	return game, sides, nil
 }
 
+// requireParticipants rejects starting a game while a side is still to be drawn.
+func requireParticipants(ctx context.Context, repos *repositories.RepositoriesCollection, gameID int64) error {
+	sides, err := repos.GameSideRepo.ListByGame(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	for _, sd := range sides {
+		if sd.TeamID == nil && sd.PlayerID == nil {
+			return errors.New("side " + sd.Side + " has no participant yet")
+		}
+	}
+	return nil
+}
+
 func validateColor(c models.DiscColor) error {
 	switch c {
 	case models.DiscWhite, models.DiscBlack, models.DiscNatural:
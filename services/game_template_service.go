@@ -0,0 +1,762 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
+	"github.com/matt-j-deasy/betty-crokers-api/ical"
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+// GameTemplateService manages recurring game templates and materializes their
+// occurrences as Games up to weeksAhead weeks out. Materialization is serialized
+// within the process, and the unique (template, occurrence date) index keeps
+// instances sharing a database from creating the same game twice.
+type GameTemplateService struct {
+	repos      *repositories.RepositoriesCollection
+	cache      cache.StatsCache
+	avail      *AvailabilityService
+	weeksAhead int
+
+	mu sync.Mutex
+}
+
+func NewGameTemplateService(repos *repositories.RepositoriesCollection, statsCache cache.StatsCache, avail *AvailabilityService, weeksAhead int) *GameTemplateService {
+	return &GameTemplateService{repos: repos, cache: statsCache, avail: avail, weeksAhead: weeksAhead}
+}
+
+// -------- DTOs
+
+// TemplateSideInput names a side's team or player; leave both empty for "to be drawn".
+type TemplateSideInput struct {
+	TeamID   *int64 `json:"teamId,omitempty"`
+	PlayerID *int64 `json:"playerId,omitempty"`
+}
+
+type CreateGameTemplateInput struct {
+	Name         string            `json:"name"`
+	SeasonID     *int64            `json:"seasonId,omitempty"`  // nil => exhibition games
+	MatchType    string            `json:"matchType,omitempty"` // "teams" | "players"; default from season or league
+	TargetPoints *int              `json:"targetPoints,omitempty"`
+	ScoringMode  *string           `json:"scoringMode,omitempty"`
+	ColorPolicy  *string           `json:"colorPolicy,omitempty"`
+	Timezone     *string           `json:"timezone,omitempty"` // default from venue, season or league, else America/New_York
+	VenueID      *int64            `json:"venueId,omitempty"`
+	Location     *string           `json:"location,omitempty"` // ignored when a venue is given
+	Description  *string           `json:"description,omitempty"`
+	Recurrence   string            `json:"recurrence"` // e.g. "FREQ=WEEKLY;BYDAY=TU"
+	StartTime    string            `json:"startTime"`  // "HH:MM", local
+	StartsOn     string            `json:"startsOn"`   // "YYYY-MM-DD"
+	EndsOn       *string           `json:"endsOn,omitempty"`
+	SideA        TemplateSideInput `json:"sideA"`
+	SideB        TemplateSideInput `json:"sideB"`
+	Active       *bool             `json:"active,omitempty"` // default true
+}
+
+// UpdateGameTemplateInput changes the template; future unplayed occurrences follow.
+type UpdateGameTemplateInput struct {
+	Name         *string            `json:"name,omitempty"`
+	SeasonID     *int64             `json:"seasonId,omitempty"` // 0 => exhibition
+	TargetPoints *int               `json:"targetPoints,omitempty"`
+	ScoringMode  *string            `json:"scoringMode,omitempty"`
+	ColorPolicy  *string            `json:"colorPolicy,omitempty"`
+	Timezone     *string            `json:"timezone,omitempty"`
+	VenueID      *int64             `json:"venueId,omitempty"`     // 0 clears
+	Location     *string            `json:"location,omitempty"`    // "" clears; ignored with a venue
+	Description  *string            `json:"description,omitempty"` // "" clears
+	Recurrence   *string            `json:"recurrence,omitempty"`
+	StartTime    *string            `json:"startTime,omitempty"`
+	StartsOn     *string            `json:"startsOn,omitempty"`
+	EndsOn       *string            `json:"endsOn,omitempty"` // "" clears
+	SideA        *TemplateSideInput `json:"sideA,omitempty"`
+	SideB        *TemplateSideInput `json:"sideB,omitempty"`
+	Active       *bool              `json:"active,omitempty"`
+}
+
+type ListGameTemplatesOptions struct {
+	SeasonID   *int64
+	ActiveOnly bool
+	Page       int
+	Size       int
+}
+
+type PagedGameTemplates struct {
+	Data  []models.GameTemplate `json:"data"`
+	Total int64                 `json:"total"`
+	Page  int                   `json:"page"`
+	Size  int                   `json:"size"`
+}
+
+// TemplateSkip is an occurrence left unmaterialized because it would conflict with
+// a participant's availability, double-book a player or overfill the venue. It is
+// retried on every pass.
+type TemplateSkip struct {
+	Date      string             `json:"date"` // YYYY-MM-DD
+	Conflicts []ScheduleConflict `json:"conflicts"`
+}
+
+// TemplateSyncResult reports one materialization pass over a template.
+type TemplateSyncResult struct {
+	TemplateID int64          `json:"templateId"`
+	Created    []models.Game  `json:"created"`
+	Updated    []models.Game  `json:"updated"`
+	Removed    []int64        `json:"removed"` // unplayed occurrences the template no longer produces
+	Skipped    []TemplateSkip `json:"skipped"`
+	// Conflicts that updated occurrences now run into; reported, not enforced.
+	Conflicts []ScheduleConflict `json:"conflicts"`
+}
+
+type GameTemplateWithSync struct {
+	Template models.GameTemplate `json:"template"`
+	Sync     *TemplateSyncResult `json:"sync"`
+}
+
+// -------- CRUD
+
+func (s *GameTemplateService) Create(ctx context.Context, in CreateGameTemplateInput) (*GameTemplateWithSync, error) {
+	t := &models.GameTemplate{
+		Name:          strings.TrimSpace(in.Name),
+		SeasonID:      in.SeasonID,
+		Location:      trimmedOrNil(in.Location),
+		Description:   trimmedOrNil(in.Description),
+		Recurrence:    in.Recurrence,
+		StartTime:     strings.TrimSpace(in.StartTime),
+		SideATeamID:   in.SideA.TeamID,
+		SideAPlayerID: in.SideA.PlayerID,
+		SideBTeamID:   in.SideB.TeamID,
+		SideBPlayerID: in.SideB.PlayerID,
+		Active:        true,
+	}
+	if in.Active != nil {
+		t.Active = *in.Active
+	}
+	if in.TargetPoints != nil && *in.TargetPoints <= 0 {
+		return nil, errors.New("targetPoints must be > 0")
+	}
+
+	// Settings not given are resolved here for display and again whenever an
+	// occurrence is materialized, so later default changes reach future games.
+	var venue *models.Venue
+	if in.VenueID != nil {
+		v, err := s.repos.VenueRepo.GetByID(ctx, *in.VenueID)
+		if err != nil {
+			return nil, errors.New("venue not found")
+		}
+		venue, t.VenueID = v, in.VenueID
+	}
+	settings, err := resolveGameSettings(ctx, s.repos, in.SeasonID, venue, gameSettingsRequest{
+		Timezone:     in.Timezone,
+		TargetPoints: in.TargetPoints,
+		MatchType:    in.MatchType,
+		ScoringMode:  in.ScoringMode,
+		ColorPolicy:  in.ColorPolicy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	t.MatchType, t.TargetPoints, t.Timezone = settings.MatchType, settings.TargetPoints, settings.Timezone
+	t.ScoringMode, t.ColorPolicy, t.Provenance = settings.ScoringMode, settings.ColorPolicy, settings.Provenance
+
+	d, err := parseYMD(strings.TrimSpace(in.StartsOn))
+	if err != nil {
+		return nil, errors.New("startsOn must be YYYY-MM-DD")
+	}
+	t.StartsOn = d
+	if in.EndsOn != nil && strings.TrimSpace(*in.EndsOn) != "" {
+		e, err := parseYMD(strings.TrimSpace(*in.EndsOn))
+		if err != nil {
+			return nil, errors.New("endsOn must be YYYY-MM-DD")
+		}
+		t.EndsOn = &e
+	}
+
+	if err := s.validate(ctx, t); err != nil {
+		return nil, err
+	}
+	if err := s.repos.GameTemplateRepo.Create(ctx, t); err != nil {
+		return nil, err
+	}
+	res, err := s.Materialize(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &GameTemplateWithSync{Template: *t, Sync: res}, nil
+}
+
+func (s *GameTemplateService) GetByID(ctx context.Context, id int64) (*models.GameTemplate, error) {
+	return s.repos.GameTemplateRepo.GetByID(ctx, id)
+}
+
+func (s *GameTemplateService) List(ctx context.Context, opts ListGameTemplatesOptions) (*PagedGameTemplates, error) {
+	page := opts.Page
+	size := opts.Size
+	if page < 1 {
+		page = 1
+	}
+	if size <= 0 || size > 100 {
+		size = 25
+	}
+
+	items, total, err := s.repos.GameTemplateRepo.List(ctx, repositories.ListGameTemplatesFilter{
+		SeasonID:   opts.SeasonID,
+		ActiveOnly: opts.ActiveOnly,
+		Offset:     (page - 1) * size,
+		Limit:      size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &PagedGameTemplates{Data: items, Total: total, Page: page, Size: size}, nil
+}
+
+// Update applies in and pushes the new settings to every occurrence that is still
+// scheduled and in the future. A drawn participant is kept unless the template now
+// names one for that side; the match type cannot change.
+func (s *GameTemplateService) Update(ctx context.Context, id int64, in UpdateGameTemplateInput) (*GameTemplateWithSync, error) {
+	t, err := s.repos.GameTemplateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Name != nil {
+		t.Name = strings.TrimSpace(*in.Name)
+	}
+	if in.SeasonID != nil {
+		t.SeasonID = nil
+		if *in.SeasonID != 0 {
+			t.SeasonID = in.SeasonID
+		}
+	}
+	if in.TargetPoints != nil {
+		t.TargetPoints = *in.TargetPoints
+		t.Provenance.TargetPoints = models.SourceRequest
+	}
+	if in.ScoringMode != nil {
+		mode, err := parseScoringMode(*in.ScoringMode)
+		if err != nil {
+			return nil, err
+		}
+		t.ScoringMode, t.Provenance.ScoringMode = mode, models.SourceRequest
+	}
+	if in.ColorPolicy != nil {
+		policy, err := parseColorPolicy(*in.ColorPolicy)
+		if err != nil {
+			return nil, err
+		}
+		t.ColorPolicy, t.Provenance.ColorPolicy = policy, models.SourceRequest
+	}
+	if in.Timezone != nil {
+		t.Timezone = strings.TrimSpace(*in.Timezone)
+		t.Provenance.Timezone = models.SourceRequest
+	}
+	if in.VenueID != nil {
+		t.VenueID = nil
+		if *in.VenueID != 0 {
+			t.VenueID = in.VenueID
+		}
+	}
+	if in.Location != nil {
+		t.Location = trimmedOrNil(in.Location)
+	}
+	if in.Description != nil {
+		t.Description = trimmedOrNil(in.Description)
+	}
+	if in.Recurrence != nil {
+		t.Recurrence = *in.Recurrence
+	}
+	if in.StartTime != nil {
+		t.StartTime = strings.TrimSpace(*in.StartTime)
+	}
+	if in.StartsOn != nil {
+		d, err := parseYMD(strings.TrimSpace(*in.StartsOn))
+		if err != nil {
+			return nil, errors.New("startsOn must be YYYY-MM-DD")
+		}
+		t.StartsOn = d
+	}
+	if in.EndsOn != nil {
+		t.EndsOn = nil
+		if v := strings.TrimSpace(*in.EndsOn); v != "" {
+			e, err := parseYMD(v)
+			if err != nil {
+				return nil, errors.New("endsOn must be YYYY-MM-DD")
+			}
+			t.EndsOn = &e
+		}
+	}
+	if in.SideA != nil {
+		t.SideATeamID, t.SideAPlayerID = in.SideA.TeamID, in.SideA.PlayerID
+	}
+	if in.SideB != nil {
+		t.SideBTeamID, t.SideBPlayerID = in.SideB.TeamID, in.SideB.PlayerID
+	}
+	if in.Active != nil {
+		t.Active = *in.Active
+	}
+
+	if err := s.validate(ctx, t); err != nil {
+		return nil, err
+	}
+	updated, err := s.repos.GameTemplateRepo.UpdateFields(ctx, id, map[string]any{
+		"name":             t.Name,
+		"season_id":        t.SeasonID,
+		"target_points":    t.TargetPoints,
+		"timezone":         t.Timezone,
+		"scoring_mode":     t.ScoringMode,
+		"color_policy":     t.ColorPolicy,
+		"provenance":       t.Provenance,
+		"venue_id":         t.VenueID,
+		"location":         t.Location,
+		"description":      t.Description,
+		"recurrence":       t.Recurrence,
+		"start_time":       t.StartTime,
+		"starts_on":        t.StartsOn,
+		"ends_on":          t.EndsOn,
+		"side_a_team_id":   t.SideATeamID,
+		"side_a_player_id": t.SideAPlayerID,
+		"side_b_team_id":   t.SideBTeamID,
+		"side_b_player_id": t.SideBPlayerID,
+		"active":           t.Active,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, err := s.sync(ctx, updated, true)
+	if err != nil {
+		return nil, err
+	}
+	return &GameTemplateWithSync{Template: *updated, Sync: res}, nil
+}
+
+// Delete removes the template and its future unplayed occurrences. Games already
+// played (or underway) stay.
+func (s *GameTemplateService) Delete(ctx context.Context, id int64) error {
+	t, err := s.repos.GameTemplateRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t.Active = false
+	if _, err := s.sync(ctx, t, true); err != nil {
+		return err
+	}
+	return s.repos.GameTemplateRepo.DeleteByID(ctx, id)
+}
+
+// -------- Materialization
+
+// Materialize brings one template's occurrences up to date now, as the scheduler
+// would, and reports what changed.
+func (s *GameTemplateService) Materialize(ctx context.Context, id int64) (*TemplateSyncResult, error) {
+	t, err := s.repos.GameTemplateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sync(ctx, t, false)
+}
+
+// MaterializeAll runs one pass over every active template. A failing template is
+// logged and skipped so the others still get their games.
+func (s *GameTemplateService) MaterializeAll(ctx context.Context) error {
+	templates, err := s.repos.GameTemplateRepo.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range templates {
+		res, err := s.sync(ctx, &templates[i], false)
+		if err != nil {
+			slog.Error("Failed to materialize game template", "templateId", templates[i].ID, "err", err)
+			continue
+		}
+		if len(res.Created) > 0 || len(res.Removed) > 0 || len(res.Skipped) > 0 {
+			slog.Info("Materialized game template",
+				"templateId", res.TemplateID,
+				"created", len(res.Created),
+				"removed", len(res.Removed),
+				"skipped", len(res.Skipped),
+			)
+		}
+	}
+	return nil
+}
+
+// RunScheduler materializes every active template immediately and then once per
+// interval until ctx is done.
+func (s *GameTemplateService) RunScheduler(ctx context.Context, interval time.Duration) {
+	slog.Info("Starting game template scheduler", "interval", interval, "weeksAhead", s.weeksAhead)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.MaterializeAll(ctx); err != nil {
+			slog.Error("Game template scheduler pass failed", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync reconciles the template's games from today (in its timezone) onwards:
+//   - occurrences up to weeksAhead weeks out that have no game get one, unless it
+//     would raise a blocking scheduling conflict;
+//   - unplayed future games on dates the template no longer produces are removed;
+//   - with propagate, unplayed future games take the template's current settings.
+//
+// A date whose game was deleted by hand is never refilled. Callers hold s.mu, which
+// only serializes this process; the unique occurrence index settles races with other
+// instances.
+func (s *GameTemplateService) sync(ctx context.Context, t *models.GameTemplate, propagate bool) (*TemplateSyncResult, error) {
+	res := &TemplateSyncResult{
+		TemplateID: t.ID,
+		Created:    []models.Game{},
+		Updated:    []models.Game{},
+		Removed:    []int64{},
+		Skipped:    []TemplateSkip{},
+		Conflicts:  []ScheduleConflict{},
+	}
+	settings, err := s.templateSettings(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := ical.ParseRRule(t.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	horizon := today.AddDate(0, 0, 7*s.weeksAhead)
+
+	existing, err := s.repos.GameTemplateRepo.ListOccurrences(ctx, t.ID, today)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expand far enough to judge every existing occurrence, not only the horizon.
+	last := horizon
+	for _, g := range existing {
+		if g.OccurrenceDate.After(last) {
+			last = *g.OccurrenceDate
+		}
+	}
+	if t.EndsOn != nil && t.EndsOn.Before(last) {
+		last = *t.EndsOn
+	}
+	want := map[string]time.Time{}
+	var dates []time.Time
+	if t.Active {
+		dates = rule.Dates(t.StartsOn, today, last)
+		for _, d := range dates {
+			want[d.Format("2006-01-02")] = d
+		}
+	}
+
+	have := map[string]bool{}
+	var purge []int64
+	touched := false
+	for _, g := range existing {
+		key := g.OccurrenceDate.Format("2006-01-02")
+		if g.DeletedAt.Valid {
+			have[key] = true
+			continue
+		}
+		unplayed := g.Status == "scheduled" && (g.ScheduledAt == nil || g.ScheduledAt.After(now))
+		if _, ok := want[key]; !ok {
+			if unplayed {
+				purge = append(purge, g.ID)
+			}
+			continue
+		}
+		have[key] = true
+		if propagate && unplayed {
+			updated, conflicts, err := s.applyTemplate(ctx, t, settings, &g, loc)
+			if err != nil {
+				return nil, err
+			}
+			res.Updated = append(res.Updated, *updated)
+			res.Conflicts = append(res.Conflicts, conflicts...)
+			touched = true
+		}
+	}
+
+	if err := s.repos.GameTemplateRepo.PurgeOccurrences(ctx, purge); err != nil {
+		return nil, err
+	}
+	res.Removed = append(res.Removed, purge...)
+	touched = touched || len(purge) > 0
+
+	for _, d := range dates {
+		key := d.Format("2006-01-02")
+		if have[key] || d.After(horizon) {
+			continue
+		}
+		game, sides := templateOccurrence(t, settings, d, loc)
+		if game.ScheduledAt.Before(now) {
+			continue // today's slot has already passed
+		}
+		conflicts, err := s.avail.CheckGame(ctx, game, sides)
+		if err != nil {
+			return nil, err
+		}
+		if blocking := blockingConflicts(conflicts, false); len(blocking) > 0 {
+			res.Skipped = append(res.Skipped, TemplateSkip{Date: key, Conflicts: blocking})
+			continue
+		}
+		if err := s.repos.GameRepo.CreateWithSides(ctx, game, sides); err != nil {
+			if utils.IsUniqueViolation(err) {
+				continue // another instance materialized this date first
+			}
+			return nil, err
+		}
+		res.Created = append(res.Created, *game)
+		touched = true
+	}
+
+	if touched {
+		invalidateSeasons(s.cache, t.SeasonID)
+	}
+	return res, nil
+}
+
+// applyTemplate rewrites an unplayed occurrence from the template, returning the
+// updated game and any scheduling conflicts it now has.
+func (s *GameTemplateService) applyTemplate(ctx context.Context, t *models.GameTemplate, settings *gameSettings, g *models.Game, loc *time.Location) (*models.Game, []ScheduleConflict, error) {
+	fresh, _ := templateOccurrence(t, settings, *g.OccurrenceDate, loc)
+	fields := map[string]any{
+		"season_id":     t.SeasonID,
+		"target_points": fresh.TargetPoints,
+		"scheduled_at":  fresh.ScheduledAt,
+		"timezone":      fresh.Timezone,
+		"scoring_mode":  fresh.ScoringMode,
+		"color_policy":  fresh.ColorPolicy,
+		"provenance":    fresh.Provenance,
+		"venue_id":      t.VenueID,
+		"location":      fresh.Location,
+		"description":   t.Description,
+	}
+	if !sameID(g.VenueID, t.VenueID) {
+		fields["board_id"] = nil
+	}
+	if !sameID(g.SeasonID, t.SeasonID) {
+		fields["matchday_id"] = nil
+	}
+	updated, err := s.repos.GameRepo.UpdateFields(ctx, g.ID, fields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	named := map[string][2]*int64{
+		"A": {t.SideATeamID, t.SideAPlayerID},
+		"B": {t.SideBTeamID, t.SideBPlayerID},
+	}
+	for side, ids := range named {
+		if ids[0] == nil && ids[1] == nil {
+			continue // to be drawn: keep whoever was drawn
+		}
+		if _, err := s.repos.GameSideRepo.UpdateFieldsByGameAndSide(ctx, g.ID, side, map[string]any{
+			"team_id":   ids[0],
+			"player_id": ids[1],
+		}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sides, err := s.repos.GameSideRepo.ListByGame(ctx, g.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	conflicts, err := s.avail.CheckGame(ctx, updated, sides)
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, conflicts, nil
+}
+
+// templateSettings resolves the settings for the template's occurrences. Settings
+// the template was given explicitly are kept; the rest are inherited afresh from the
+// venue, season and league. Templates from before provenance was recorded keep
+// everything they have.
+func (s *GameTemplateService) templateSettings(ctx context.Context, t *models.GameTemplate) (*gameSettings, error) {
+	var venue *models.Venue
+	if t.VenueID != nil {
+		if v, err := s.repos.VenueRepo.GetByID(ctx, *t.VenueID); err == nil {
+			venue = v
+		}
+	}
+	explicit := func(source string) bool { return source == models.SourceRequest || source == "" }
+
+	req := gameSettingsRequest{MatchType: t.MatchType} // fixed once created
+	if explicit(t.Provenance.Timezone) {
+		req.Timezone = &t.Timezone
+	}
+	if explicit(t.Provenance.TargetPoints) {
+		req.TargetPoints = &t.TargetPoints
+	}
+	if explicit(t.Provenance.ScoringMode) {
+		mode := string(t.ScoringMode)
+		req.ScoringMode = &mode
+	}
+	if explicit(t.Provenance.ColorPolicy) {
+		policy := string(t.ColorPolicy)
+		req.ColorPolicy = &policy
+	}
+	settings, err := resolveGameSettings(ctx, s.repos, t.SeasonID, venue, req)
+	if err != nil {
+		return nil, err
+	}
+	settings.Provenance.MatchType = t.Provenance.MatchType
+	return settings, nil
+}
+
+// templateOccurrence builds the game and sides for the template's occurrence on date.
+func templateOccurrence(t *models.GameTemplate, settings *gameSettings, date time.Time, loc *time.Location) (*models.Game, []models.GameSide) {
+	clock, _ := time.Parse("15:04", t.StartTime) // validated on save
+	at := time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, loc).UTC()
+	occurrence := date
+	templateID := t.ID
+	game := &models.Game{
+		SeasonID:       t.SeasonID,
+		MatchType:      t.MatchType,
+		TargetPoints:   settings.TargetPoints,
+		ScoringMode:    settings.ScoringMode,
+		ColorPolicy:    settings.ColorPolicy,
+		Provenance:     settings.Provenance,
+		Status:         "scheduled",
+		ScheduledAt:    &at,
+		Timezone:       settings.Timezone,
+		VenueID:        t.VenueID,
+		Location:       t.Location,
+		Description:    t.Description,
+		TemplateID:     &templateID,
+		OccurrenceDate: &occurrence,
+	}
+	sides := []models.GameSide{
+		{Side: "A", TeamID: t.SideATeamID, PlayerID: t.SideAPlayerID},
+		{Side: "B", TeamID: t.SideBTeamID, PlayerID: t.SideBPlayerID},
+	}
+	applyColorPolicy(settings.ColorPolicy, &sides[0], &sides[1], false, false)
+	return game, sides
+}
+
+// -------- Validation
+
+// validate checks t and normalizes it in place: canonical recurrence, venue name as
+// location and venue timezone as the default.
+func (s *GameTemplateService) validate(ctx context.Context, t *models.GameTemplate) error {
+	if t.Name == "" {
+		return errors.New("name is required")
+	}
+	if t.MatchType != "teams" && t.MatchType != "players" {
+		return errors.New("matchType must be 'teams' or 'players'")
+	}
+	if t.TargetPoints <= 0 {
+		return errors.New("targetPoints must be > 0")
+	}
+	if t.SeasonID != nil {
+		if _, err := s.repos.SeasonRepo.GetByID(ctx, *t.SeasonID); err != nil {
+			return errors.New("season not found")
+		}
+	}
+
+	if t.VenueID != nil {
+		venue, err := s.repos.VenueRepo.GetByID(ctx, *t.VenueID)
+		if err != nil {
+			return errors.New("venue not found")
+		}
+		t.Location = &venue.Name
+		if t.Timezone == "" {
+			t.Timezone = venue.Timezone
+		}
+	}
+	if t.Timezone == "" {
+		t.Timezone = "America/New_York"
+	}
+	if _, err := time.LoadLocation(t.Timezone); err != nil {
+		return errors.New("invalid timezone")
+	}
+
+	rule, err := ical.ParseRRule(t.Recurrence)
+	if err != nil {
+		return err
+	}
+	t.Recurrence = rule.String()
+	if _, err := time.Parse("15:04", t.StartTime); err != nil {
+		return errors.New("startTime must be HH:MM")
+	}
+	if t.EndsOn != nil && t.EndsOn.Before(t.StartsOn) {
+		return errors.New("endsOn must be on or after startsOn")
+	}
+
+	a, err := s.validateTemplateSide(ctx, "A", t.MatchType, t.SideATeamID, t.SideAPlayerID)
+	if err != nil {
+		return err
+	}
+	b, err := s.validateTemplateSide(ctx, "B", t.MatchType, t.SideBTeamID, t.SideBPlayerID)
+	if err != nil {
+		return err
+	}
+	if a != 0 && a == b {
+		return errors.New("side A and side B cannot be the same")
+	}
+	return nil
+}
+
+// validateTemplateSide checks a side's participant against the match type and
+// returns its ID, or 0 when the side is to be drawn.
+func (s *GameTemplateService) validateTemplateSide(ctx context.Context, label, matchType string, teamID, playerID *int64) (int64, error) {
+	if matchType == "teams" {
+		if playerID != nil {
+			return 0, errors.New("side " + label + ": playerId is not allowed for team match")
+		}
+		if teamID == nil {
+			return 0, nil
+		}
+		if _, err := s.repos.TeamRepo.GetByID(ctx, *teamID); err != nil {
+			return 0, errors.New("side " + label + ": team not found")
+		}
+		return *teamID, nil
+	}
+	if teamID != nil {
+		return 0, errors.New("side " + label + ": teamId is not allowed for player match")
+	}
+	if playerID == nil {
+		return 0, nil
+	}
+	if _, err := s.repos.PlayerRepo.GetByID(ctx, *playerID); err != nil {
+		return 0, errors.New("side " + label + ": player not found")
+	}
+	return *playerID, nil
+}
+
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -1,7 +1,11 @@
 package services
 
 import (
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
 	"github.com/matt-j-deasy/betty-crokers-api/config"
+	"github.com/matt-j-deasy/betty-crokers-api/notify"
 	"github.com/matt-j-deasy/betty-crokers-api/repositories"
 )
 
@@ -9,29 +13,60 @@ func InitializeServices(
 	repos *repositories.RepositoriesCollection,
 	cfg config.Environment,
 ) (*ServicesCollection, error) {
+	statsCache := cache.NewLRU(cfg.StatsCacheSize)
+	recordService := NewRecordService(repos)
+	gameDuration := time.Duration(cfg.GameMinutes) * time.Minute
+	availabilityService := NewAvailabilityService(repos, gameDuration)
+	var notifier notify.Notifier = notify.Log{}
+	if cfg.ScheduleWebhookURL != "" {
+		notifier = notify.NewWebhook(cfg.ScheduleWebhookURL)
+	}
+
 	return &ServicesCollection{
-		AuthService:        NewAuthService(repos, cfg),
-		UserService:        NewUserService(repos),
-		PlayerService:      NewPlayerService(repos),
-		LeagueService:      NewLeagueService(repos),
-		SeasonService:      NewSeasonService(repos),
-		TeamService:        NewTeamService(repos),
-		TeamSeasonService:  NewTeamSeasonService(repos),
-		GameService:        NewGameService(repos),
-		GameSideService:    NewGameSideService(repos),
-		SeasonStatsService: NewSeasonStatsService(repos),
+		AuthService:         NewAuthService(repos, cfg),
+		UserService:         NewUserService(repos),
+		PlayerService:       NewPlayerService(repos, statsCache),
+		LeagueService:       NewLeagueService(repos, statsCache),
+		SeasonService:       NewSeasonService(repos, statsCache),
+		TeamService:         NewTeamService(repos, statsCache),
+		TeamSeasonService:   NewTeamSeasonService(repos, statsCache),
+		GameService:         NewGameService(repos, statsCache, recordService, availabilityService),
+		GameSideService:     NewGameSideService(repos, statsCache, recordService, availabilityService),
+		SeasonStatsService:  NewSeasonStatsService(repos),
+		CareerStatsService:  NewCareerStatsService(repos),
+		AwardService:        NewAwardService(repos),
+		RecordService:       recordService,
+		AdvantageService:    NewAdvantageService(repos),
+		AvailabilityService: availabilityService,
+		VenueService:        NewVenueService(repos, statsCache),
+		CalendarService:     NewCalendarService(repos, gameDuration),
+		MatchdayService:     NewMatchdayService(repos, statsCache, availabilityService),
+		GameTemplateService: NewGameTemplateService(repos, statsCache, availabilityService, cfg.TemplateWeeksAhead),
+		PublishService:      NewPublishService(repos, notifier),
+		StatsCache:          statsCache,
 	}, nil
 }
 
 type ServicesCollection struct {
-	AuthService        *AuthService
-	UserService        *UserService
-	PlayerService      *PlayerService
-	LeagueService      *LeagueService
-	SeasonService      *SeasonService
-	TeamService        *TeamService
-	TeamSeasonService  *TeamSeasonService
-	GameService        *GameService
-	GameSideService    *GameSideService
-	SeasonStatsService *SeasonStatsService
+	AuthService         *AuthService
+	UserService         *UserService
+	PlayerService       *PlayerService
+	LeagueService       *LeagueService
+	SeasonService       *SeasonService
+	TeamService         *TeamService
+	TeamSeasonService   *TeamSeasonService
+	GameService         *GameService
+	GameSideService     *GameSideService
+	SeasonStatsService  *SeasonStatsService
+	CareerStatsService  *CareerStatsService
+	AwardService        *AwardService
+	RecordService       *RecordService
+	AdvantageService    *AdvantageService
+	AvailabilityService *AvailabilityService
+	VenueService        *VenueService
+	CalendarService     *CalendarService
+	MatchdayService     *MatchdayService
+	GameTemplateService *GameTemplateService
+	PublishService      *PublishService
+	StatsCache          cache.StatsCache
 }
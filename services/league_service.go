@@ -4,25 +4,33 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
 	"github.com/matt-j-deasy/betty-crokers-api/models"
 	"github.com/matt-j-deasy/betty-crokers-api/repositories"
 )
 
 type LeagueService struct {
-	repo *repositories.LeagueRepository
+	repo    *repositories.LeagueRepository
+	seasons *repositories.SeasonRepository
+	cache   cache.StatsCache
 }
 
-func NewLeagueService(repos *repositories.RepositoriesCollection) *LeagueService {
-	return &LeagueService{repo: repos.LeagueRepo}
+func NewLeagueService(repos *repositories.RepositoriesCollection, statsCache cache.StatsCache) *LeagueService {
+	return &LeagueService{repo: repos.LeagueRepo, seasons: repos.SeasonRepo, cache: statsCache}
 }
 
 type CreateLeagueInput struct {
-	Name string `json:"name"`
+	Name     string             `json:"name"`
+	Timezone *string            `json:"timezone,omitempty"` // IANA; default for new seasons
+	Defaults *GameDefaultsInput `json:"defaults,omitempty"` // for new games, below season defaults
 }
 
 type UpdateLeagueInput struct {
-	Name *string `json:"name"`
+	Name     *string            `json:"name"`
+	Timezone *string            `json:"timezone,omitempty"` // "" clears; seasons without their own follow
+	Defaults *GameDefaultsInput `json:"defaults,omitempty"`
 }
 
 type ListLeaguesOptions struct {
@@ -44,6 +52,15 @@ func (s *LeagueService) Create(ctx context.Context, in CreateLeagueInput) (*mode
 		return nil, errors.New("name is required")
 	}
 	l := &models.League{Name: name}
+	if in.Timezone != nil && *in.Timezone != "" {
+		if _, err := time.LoadLocation(*in.Timezone); err != nil {
+			return nil, errors.New("timezone must be a valid IANA timezone")
+		}
+		l.Timezone = in.Timezone
+	}
+	if err := in.Defaults.apply(&l.Defaults); err != nil {
+		return nil, err
+	}
 	if err := s.repo.Create(ctx, l); err != nil {
 		return nil, err
 	}
@@ -65,10 +82,49 @@ func (s *LeagueService) Update(ctx context.Context, id int64, in UpdateLeagueInp
 		fields["name"] = n
 	}
 
+	if in.Timezone != nil {
+		fields["timezone"] = nil
+		if *in.Timezone != "" {
+			if _, err := time.LoadLocation(*in.Timezone); err != nil {
+				return nil, errors.New("timezone must be a valid IANA timezone")
+			}
+			fields["timezone"] = *in.Timezone
+		}
+	}
+
+	if in.Defaults != nil {
+		cur, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := in.Defaults.apply(&cur.Defaults); err != nil {
+			return nil, err
+		}
+		for k, v := range defaultsFields(cur.Defaults) {
+			fields[k] = v
+		}
+	}
+
 	if len(fields) == 0 {
 		return s.repo.GetByID(ctx, id)
 	}
-	return s.repo.UpdateFields(ctx, id, fields)
+	updated, err := s.repo.UpdateFields(ctx, id, fields)
+	if err != nil {
+		return nil, err
+	}
+	if in.Timezone != nil {
+		tz := defaultTimezone
+		if updated.Timezone != nil {
+			tz = *updated.Timezone
+		}
+		// Season timezones feed the cached standings.
+		ids, err := s.seasons.SetInheritedTimezone(ctx, id, tz)
+		if err != nil {
+			return nil, err
+		}
+		invalidateSeasonList(s.cache, ids, nil)
+	}
+	return updated, nil
 }
 
 func (s *LeagueService) Delete(ctx context.Context, id int64) error {
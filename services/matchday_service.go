@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+type MatchdayService struct {
+	repos *repositories.RepositoriesCollection
+	cache cache.StatsCache
+	avail *AvailabilityService
+}
+
+func NewMatchdayService(repos *repositories.RepositoriesCollection, statsCache cache.StatsCache, avail *AvailabilityService) *MatchdayService {
+	return &MatchdayService{repos: repos, cache: statsCache, avail: avail}
+}
+
+// -------- DTOs
+
+type CreateMatchdayInput struct {
+	Number  *int    `json:"number,omitempty"` // default: one past the season's last matchday
+	Date    string  `json:"date"`             // "YYYY-MM-DD"
+	Name    *string `json:"name,omitempty"`
+	VenueID *int64  `json:"venueId,omitempty"`
+	Draft   bool    `json:"draft,omitempty"` // hidden from the public until published
+}
+
+type UpdateMatchdayInput struct {
+	Date    *string `json:"date,omitempty"`    // "YYYY-MM-DD"; moves only the matchday, use Postpone to shift games
+	Name    *string `json:"name,omitempty"`    // "" clears
+	VenueID *int64  `json:"venueId,omitempty"` // 0 clears
+}
+
+// PostponeMatchdayInput moves a matchday by Days, or to Date; exactly one is required.
+type PostponeMatchdayInput struct {
+	Date *string `json:"date,omitempty"` // "YYYY-MM-DD"
+	Days *int    `json:"days,omitempty"`
+	// AllowConflicts postpones despite availability, double-booking or full-venue
+	// conflicts. A board already in use is always rejected.
+	AllowConflicts bool `json:"allowConflicts,omitempty"`
+}
+
+type MatchdayGame struct {
+	Game  models.Game       `json:"game"`
+	Sides []models.GameSide `json:"sides"`
+}
+
+// MatchdayFixtures splits a matchday's games into results (completed) and fixtures
+// (everything else, canceled included).
+type MatchdayFixtures struct {
+	Matchday models.Matchday `json:"matchday"`
+	Fixtures []MatchdayGame  `json:"fixtures"`
+	Results  []MatchdayGame  `json:"results"`
+}
+
+type PostponeResult struct {
+	Matchday models.Matchday `json:"matchday"`
+	Days     int             `json:"days"`
+	Shifted  []models.Game   `json:"shifted"` // scheduled games moved with the matchday
+	// Conflicts the new times run into that AllowConflicts let through.
+	Conflicts []ScheduleConflict `json:"conflicts"`
+}
+
+// -------- CRUD
+
+func (s *MatchdayService) Create(ctx context.Context, seasonID int64, in CreateMatchdayInput) (*models.Matchday, error) {
+	if _, err := s.repos.SeasonRepo.GetByID(ctx, seasonID); err != nil {
+		return nil, err
+	}
+	date, err := parseYMD(in.Date)
+	if err != nil {
+		return nil, errors.New("date must be YYYY-MM-DD")
+	}
+
+	number := 0
+	if in.Number != nil {
+		if *in.Number < 1 {
+			return nil, errors.New("number must be >= 1")
+		}
+		number = *in.Number
+		if _, err := s.repos.MatchdayRepo.GetByNumber(ctx, seasonID, number); err == nil {
+			return nil, errors.New("matchday number already exists in this season")
+		} else if !utils.IsNotFound(err) {
+			return nil, err
+		}
+	} else {
+		max, err := s.repos.MatchdayRepo.MaxNumber(ctx, seasonID)
+		if err != nil {
+			return nil, err
+		}
+		number = max + 1
+	}
+
+	m := &models.Matchday{SeasonID: seasonID, Number: number, Date: date, Draft: in.Draft}
+	if in.Name != nil && strings.TrimSpace(*in.Name) != "" {
+		n := strings.TrimSpace(*in.Name)
+		m.Name = &n
+	}
+	if in.VenueID != nil {
+		if _, err := s.repos.VenueRepo.GetByID(ctx, *in.VenueID); err != nil {
+			return nil, errors.New("venue not found")
+		}
+		m.VenueID = in.VenueID
+	}
+	if err := s.repos.MatchdayRepo.Create(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// List returns the season's matchdays; drafts only when includeDrafts is set.
+func (s *MatchdayService) List(ctx context.Context, seasonID int64, includeDrafts bool) ([]models.Matchday, error) {
+	if _, err := s.repos.SeasonRepo.GetByID(ctx, seasonID); err != nil {
+		return nil, err
+	}
+	items, err := s.repos.MatchdayRepo.ListBySeason(ctx, seasonID)
+	if err != nil || includeDrafts {
+		return items, err
+	}
+	out := make([]models.Matchday, 0, len(items))
+	for _, m := range items {
+		if !m.Draft {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (s *MatchdayService) Get(ctx context.Context, seasonID int64, number int) (*models.Matchday, error) {
+	return s.repos.MatchdayRepo.GetByNumber(ctx, seasonID, number)
+}
+
+// GetFixtures returns the matchday with its games and sides. Without includeDrafts a
+// draft matchday is not found and draft games are left out.
+func (s *MatchdayService) GetFixtures(ctx context.Context, seasonID int64, number int, includeDrafts bool) (*MatchdayFixtures, error) {
+	m, err := s.repos.MatchdayRepo.GetByNumber(ctx, seasonID, number)
+	if err != nil {
+		return nil, err
+	}
+	if m.Draft && !includeDrafts {
+		return nil, gorm.ErrRecordNotFound
+	}
+	all, err := s.repos.MatchdayRepo.ListGames(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+	games := make([]models.Game, 0, len(all))
+	for _, g := range all {
+		if includeDrafts || !g.Draft {
+			games = append(games, g)
+		}
+	}
+	ids := make([]int64, 0, len(games))
+	for _, g := range games {
+		ids = append(ids, g.ID)
+	}
+	sides, err := s.repos.GameSideRepo.ListByGames(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byGame := map[int64][]models.GameSide{}
+	for _, sd := range sides {
+		byGame[sd.GameID] = append(byGame[sd.GameID], sd)
+	}
+
+	out := &MatchdayFixtures{Matchday: *m, Fixtures: []MatchdayGame{}, Results: []MatchdayGame{}}
+	for _, g := range games {
+		mg := MatchdayGame{Game: g, Sides: byGame[g.ID]}
+		if g.Status == "completed" {
+			out.Results = append(out.Results, mg)
+		} else {
+			out.Fixtures = append(out.Fixtures, mg)
+		}
+	}
+	return out, nil
+}
+
+func (s *MatchdayService) Update(ctx context.Context, seasonID int64, number int, in UpdateMatchdayInput) (*models.Matchday, error) {
+	m, err := s.repos.MatchdayRepo.GetByNumber(ctx, seasonID, number)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]any{}
+	if in.Date != nil {
+		d, err := parseYMD(*in.Date)
+		if err != nil {
+			return nil, errors.New("date must be YYYY-MM-DD")
+		}
+		fields["date"] = d
+	}
+	if in.Name != nil {
+		if n := strings.TrimSpace(*in.Name); n != "" {
+			fields["name"] = n
+		} else {
+			fields["name"] = nil
+		}
+	}
+	if in.VenueID != nil {
+		if *in.VenueID == 0 {
+			fields["venue_id"] = nil
+		} else {
+			if _, err := s.repos.VenueRepo.GetByID(ctx, *in.VenueID); err != nil {
+				return nil, errors.New("venue not found")
+			}
+			fields["venue_id"] = *in.VenueID
+		}
+	}
+	if len(fields) == 0 {
+		return m, nil
+	}
+	return s.repos.MatchdayRepo.UpdateFields(ctx, m.ID, fields)
+}
+
+// Delete removes the matchday; its games stay in the season, unattached.
+func (s *MatchdayService) Delete(ctx context.Context, seasonID int64, number int) error {
+	m, err := s.repos.MatchdayRepo.GetByNumber(ctx, seasonID, number)
+	if err != nil {
+		return err
+	}
+	if err := s.repos.MatchdayRepo.DeleteByID(ctx, m.ID); err != nil {
+		return err
+	}
+	invalidateSeasons(s.cache, &seasonID)
+	return nil
+}
+
+// Postpone shifts the matchday and all of its scheduled games by whole days. Games
+// move in their own timezone, so kick-off wall-clock times survive DST changes.
+// In-progress, completed and canceled games stay where they are. The moved games are
+// checked against each other and the rest of the schedule before the shift commits;
+// blocking conflicts roll it back and are returned as a *ScheduleConflictError.
+func (s *MatchdayService) Postpone(ctx context.Context, seasonID int64, number int, in PostponeMatchdayInput) (*PostponeResult, error) {
+	m, err := s.repos.MatchdayRepo.GetByNumber(ctx, seasonID, number)
+	if err != nil {
+		return nil, err
+	}
+	if (in.Date == nil) == (in.Days == nil) {
+		return nil, errors.New("exactly one of date or days is required")
+	}
+	days := 0
+	if in.Days != nil {
+		days = *in.Days
+	} else {
+		d, err := parseYMD(*in.Date)
+		if err != nil {
+			return nil, errors.New("date must be YYYY-MM-DD")
+		}
+		days = int(d.Sub(m.Date).Hours() / 24)
+	}
+	if days <= 0 {
+		return nil, errors.New("postpone must move the matchday later")
+	}
+
+	games, err := s.repos.MatchdayRepo.ListGames(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+	moved := map[int64]time.Time{}
+	shifted := []models.Game{}
+	for _, g := range games {
+		if g.Status != "scheduled" || g.ScheduledAt == nil {
+			continue
+		}
+		loc, err := time.LoadLocation(g.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		at := g.ScheduledAt.In(loc).AddDate(0, 0, days).UTC()
+		moved[g.ID] = at
+		g.ScheduledAt = &at
+		shifted = append(shifted, g)
+	}
+
+	newDate := m.Date.AddDate(0, 0, days)
+	conflicts := []ScheduleConflict{}
+	err = s.repos.Transaction(ctx, func(tx *repositories.RepositoriesCollection) error {
+		if err := tx.MatchdayRepo.Postpone(ctx, m.ID, newDate, moved); err != nil {
+			return err
+		}
+		avail := s.avail.withRepos(tx)
+		for i := range shifted {
+			sides, err := tx.GameSideRepo.ListByGame(ctx, shifted[i].ID)
+			if err != nil {
+				return err
+			}
+			cs, err := avail.CheckGame(ctx, &shifted[i], sides)
+			if err != nil {
+				return err
+			}
+			conflicts = append(conflicts, cs...)
+		}
+		if blocking := blockingConflicts(conflicts, in.AllowConflicts); len(blocking) > 0 {
+			return &ScheduleConflictError{Conflicts: blocking}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	invalidateSeasons(s.cache, &seasonID)
+	m.Date = newDate
+
+	return &PostponeResult{Matchday: *m, Days: days, Shifted: shifted, Conflicts: conflicts}, nil
+}
+
+// resolveMatchday checks that matchdayID exists and belongs to seasonID (when set).
+// It returns the matchday so callers can inherit its season and venue.
+func resolveMatchday(ctx context.Context, repos *repositories.RepositoriesCollection, matchdayID int64, seasonID *int64) (*models.Matchday, error) {
+	m, err := repos.MatchdayRepo.GetByID(ctx, matchdayID)
+	if err != nil {
+		return nil, errors.New("matchday not found")
+	}
+	if seasonID != nil && *seasonID != m.SeasonID {
+		return nil, errors.New("matchday does not belong to the game's season")
+	}
+	return m, nil
+}
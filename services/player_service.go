@@ -5,17 +5,24 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
 	"github.com/matt-j-deasy/betty-crokers-api/models"
 	"github.com/matt-j-deasy/betty-crokers-api/repositories"
 )
 
 type PlayerService struct {
-	repo *repositories.PlayerRepository
+	repo   *repositories.PlayerRepository
+	awards *repositories.AwardRepository
+	stats  *repositories.StatsRepository
+	cache  cache.StatsCache
 }
 
-func NewPlayerService(repos *repositories.RepositoriesCollection) *PlayerService {
+func NewPlayerService(repos *repositories.RepositoriesCollection, statsCache cache.StatsCache) *PlayerService {
 	return &PlayerService{
-		repo: repos.PlayerRepo,
+		repo:   repos.PlayerRepo,
+		awards: repos.AwardRepo,
+		stats:  repos.StatsRepo,
+		cache:  statsCache,
 	}
 }
 
@@ -66,6 +73,28 @@ func (s *PlayerService) GetByID(ctx context.Context, id int64) (*models.Player,
 	return s.repo.GetByID(ctx, id)
 }
 
+// PlayerProfile is a player plus the season awards they have been locked in for.
+type PlayerProfile struct {
+	*models.Player
+	Awards []LockedAward `json:"awards"`
+}
+
+func (s *PlayerService) GetProfile(ctx context.Context, id int64) (*PlayerProfile, error) {
+	p, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.awards.ListByPlayer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	out := &PlayerProfile{Player: p, Awards: make([]LockedAward, 0, len(rows))}
+	for _, r := range rows {
+		out.Awards = append(out.Awards, *toLockedAward(r))
+	}
+	return out, nil
+}
+
 func (s *PlayerService) Update(ctx context.Context, id int64, in UpdatePlayerInput) (*models.Player, error) {
 	fields := map[string]any{}
 	if in.Nickname != nil {
@@ -89,11 +118,22 @@ func (s *PlayerService) Update(ctx context.Context, id int64, in UpdatePlayerInp
 		// no-op; fetch and return current
 		return s.repo.GetByID(ctx, id)
 	}
-	return s.repo.UpdateFields(ctx, id, fields)
+	updated, err := s.repo.UpdateFields(ctx, id, fields)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := s.stats.ListSeasonIDsForPlayer(ctx, id)
+	invalidateSeasonList(s.cache, ids, err)
+	return updated, nil
 }
 
 func (s *PlayerService) Delete(ctx context.Context, id int64) error {
-	return s.repo.DeleteByID(ctx, id)
+	ids, lookupErr := s.stats.ListSeasonIDsForPlayer(ctx, id)
+	if err := s.repo.DeleteByID(ctx, id); err != nil {
+		return err
+	}
+	invalidateSeasonList(s.cache, ids, lookupErr)
+	return nil
 }
 
 func (s *PlayerService) List(ctx context.Context, opts ListPlayersOptions) (*PagedPlayers, error) {
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/notify"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+// PublishService moves drafted games and matchdays into public view and announces it.
+type PublishService struct {
+	repos    *repositories.RepositoriesCollection
+	notifier notify.Notifier
+}
+
+func NewPublishService(repos *repositories.RepositoriesCollection, notifier notify.Notifier) *PublishService {
+	return &PublishService{repos: repos, notifier: notifier}
+}
+
+// PublishResult lists what a publish call made public; both are empty when
+// everything selected was already published.
+type PublishResult struct {
+	MatchdayIDs []int64 `json:"matchdayIds"`
+	GameIDs     []int64 `json:"gameIds"`
+}
+
+// PublishGame publishes one game.
+func (s *PublishService) PublishGame(ctx context.Context, gameID int64) (*PublishResult, error) {
+	g, err := s.repos.GameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return s.publish(ctx, g.SeasonID, repositories.PublishFilter{GameID: &gameID})
+}
+
+// PublishMatchday publishes the matchday together with all of its games.
+func (s *PublishService) PublishMatchday(ctx context.Context, seasonID int64, number int) (*PublishResult, error) {
+	m, err := s.repos.MatchdayRepo.GetByNumber(ctx, seasonID, number)
+	if err != nil {
+		return nil, err
+	}
+	return s.publish(ctx, &seasonID, repositories.PublishFilter{MatchdayID: &m.ID})
+}
+
+// PublishSeason publishes every draft matchday and game in the season at once.
+func (s *PublishService) PublishSeason(ctx context.Context, seasonID int64) (*PublishResult, error) {
+	if _, err := s.repos.SeasonRepo.GetByID(ctx, seasonID); err != nil {
+		return nil, err
+	}
+	return s.publish(ctx, &seasonID, repositories.PublishFilter{SeasonID: &seasonID})
+}
+
+func (s *PublishService) publish(ctx context.Context, seasonID *int64, f repositories.PublishFilter) (*PublishResult, error) {
+	matchdayIDs, gameIDs, err := s.repos.GameRepo.PublishDrafts(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	out := &PublishResult{MatchdayIDs: matchdayIDs, GameIDs: gameIDs}
+	if out.MatchdayIDs == nil {
+		out.MatchdayIDs = []int64{}
+	}
+	if out.GameIDs == nil {
+		out.GameIDs = []int64{}
+	}
+	if len(matchdayIDs) > 0 || len(gameIDs) > 0 {
+		s.notifier.Notify(ctx, notify.Event{
+			Type:        notify.EventSchedulePublished,
+			SeasonID:    seasonID,
+			MatchdayIDs: out.MatchdayIDs,
+			GameIDs:     out.GameIDs,
+			At:          time.Now().UTC(),
+		})
+	}
+	return out, nil
+}
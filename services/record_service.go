@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+type RecordService struct {
+	repos *repositories.RepositoriesCollection
+}
+
+func NewRecordService(repos *repositories.RepositoriesCollection) *RecordService {
+	return &RecordService{repos: repos}
+}
+
+// recordOrder is the display order and the full set of tracked records.
+var recordOrder = []models.RecordType{
+	models.RecordBiggestMargin,
+	models.RecordHighestScore,
+	models.RecordMostTwenties,
+	models.RecordLongestWinStreak,
+	models.RecordMostGamesPlayed,
+}
+
+// -------- DTOs
+
+type RecordHolder struct {
+	Value    int64        `json:"value"`
+	PlayerID *int64       `json:"playerId"`
+	TeamID   *int64       `json:"teamId"`
+	GameID   *int64       `json:"gameId"`
+	GameURL  *string      `json:"gameUrl"` // API path of the record-setting game
+	Game     *models.Game `json:"game"`
+	SetAt    *time.Time   `json:"setAt"`
+
+	SupersededAt *time.Time `json:"supersededAt,omitempty"`
+}
+
+type LeagueRecordEntry struct {
+	Record  models.RecordType `json:"record"`
+	Current *RecordHolder     `json:"current"` // nil until a completed game sets it
+	History []RecordHolder    `json:"history"` // previous holders, newest first
+}
+
+type LeagueRecords struct {
+	LeagueID int64               `json:"leagueId"`
+	Records  []LeagueRecordEntry `json:"records"`
+}
+
+// -------- Operations
+
+// GetLeagueRecords returns every record with its holder history. A league that has
+// never been evaluated (e.g. games completed before records existed) is seeded first.
+func (s *RecordService) GetLeagueRecords(ctx context.Context, leagueID int64) (*LeagueRecords, error) {
+	if _, err := s.repos.LeagueRepo.GetByID(ctx, leagueID); err != nil {
+		return nil, err
+	}
+	rows, err := s.repos.LeagueRecordRepo.ListByLeague(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		if err := s.Refresh(ctx, leagueID); err != nil {
+			return nil, err
+		}
+		if rows, err = s.repos.LeagueRecordRepo.ListByLeague(ctx, leagueID); err != nil {
+			return nil, err
+		}
+	}
+
+	games := map[int64]*models.Game{}
+	for _, r := range rows {
+		if r.GameID == nil {
+			continue
+		}
+		if _, ok := games[*r.GameID]; ok {
+			continue
+		}
+		g, err := s.repos.GameRepo.GetByID(ctx, *r.GameID)
+		if err != nil && !utils.IsNotFound(err) {
+			return nil, err
+		}
+		games[*r.GameID] = g // nil if the game has since been deleted
+	}
+
+	byRecord := map[models.RecordType]*LeagueRecordEntry{}
+	out := &LeagueRecords{LeagueID: leagueID, Records: make([]LeagueRecordEntry, 0, len(recordOrder))}
+	for _, rt := range recordOrder {
+		out.Records = append(out.Records, LeagueRecordEntry{Record: rt, History: []RecordHolder{}})
+		byRecord[rt] = &out.Records[len(out.Records)-1]
+	}
+	for _, r := range rows {
+		e, ok := byRecord[r.Record]
+		if !ok {
+			continue
+		}
+		h := RecordHolder{
+			Value: r.Value, PlayerID: r.PlayerID, TeamID: r.TeamID,
+			GameID: r.GameID, SetAt: r.SetAt, SupersededAt: r.SupersededAt,
+		}
+		if r.GameID != nil {
+			url := "/api/v1/games/" + strconv.FormatInt(*r.GameID, 10)
+			h.GameURL = &url
+			h.Game = games[*r.GameID]
+		}
+		if r.SupersededAt == nil && e.Current == nil {
+			e.Current = &h
+		} else {
+			e.History = append(e.History, h)
+		}
+	}
+	return out, nil
+}
+
+// RefreshForSeasons re-evaluates the records of each season's league. Failures are
+// logged rather than returned: records must never block a game write.
+func (s *RecordService) RefreshForSeasons(ctx context.Context, seasonIDs ...*int64) {
+	seen := map[int64]bool{}
+	for _, id := range seasonIDs {
+		if id == nil {
+			continue // exhibition games do not count towards league records
+		}
+		season, err := s.repos.SeasonRepo.GetByID(ctx, *id)
+		if err != nil {
+			slog.Warn("records: season lookup failed", "seasonID", *id, "err", err)
+			continue
+		}
+		if seen[season.LeagueID] {
+			continue
+		}
+		seen[season.LeagueID] = true
+		if err := s.Refresh(ctx, season.LeagueID); err != nil {
+			slog.Warn("records: refresh failed", "leagueID", season.LeagueID, "err", err)
+		}
+	}
+}
+
+// Refresh recomputes the league's best value for every record and reconciles it with
+// the stored holder: a higher value by someone else supersedes the holder (kept as
+// history), while the holder extending their own record or a correction to the
+// holder's own game is applied in place.
+func (s *RecordService) Refresh(ctx context.Context, leagueID int64) error {
+	best, err := s.computeBest(ctx, leagueID)
+	if err != nil {
+		return err
+	}
+	for _, rt := range recordOrder {
+		if err := s.reconcile(ctx, leagueID, rt, best[rt]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// -------- Internal
+
+func (s *RecordService) reconcile(ctx context.Context, leagueID int64, rt models.RecordType, best *repositories.RecordCandidate) error {
+	cur, err := s.repos.LeagueRecordRepo.GetCurrent(ctx, leagueID, rt)
+	if err != nil && !utils.IsNotFound(err) {
+		return err
+	}
+	if err != nil {
+		cur = nil
+	}
+
+	switch {
+	case best == nil && cur == nil:
+		return nil
+	case best == nil:
+		// The only record-setting game was deleted or un-completed.
+		return s.repos.LeagueRecordRepo.DeleteByID(ctx, cur.ID)
+	case cur != nil && best.Value > cur.Value && sameHolder(cur, best):
+		// The holder extended their own record: history lists previous holders only.
+		return s.repos.LeagueRecordRepo.UpdateFields(ctx, cur.ID, map[string]any{
+			"value":   best.Value,
+			"game_id": best.GameID,
+			"set_at":  best.EndedAt,
+		})
+	case cur == nil || best.Value > cur.Value:
+		gameID := best.GameID
+		return s.repos.LeagueRecordRepo.Supersede(ctx, cur, &models.LeagueRecord{
+			LeagueID: leagueID,
+			Record:   rt,
+			Value:    best.Value,
+			PlayerID: best.PlayerID,
+			TeamID:   best.TeamID,
+			GameID:   &gameID,
+			SetAt:    best.EndedAt,
+		})
+	case best.Value == cur.Value:
+		return nil // ties never take a record
+	default:
+		// The holder's value dropped (score corrected, game deleted): fix in place.
+		return s.repos.LeagueRecordRepo.UpdateFields(ctx, cur.ID, map[string]any{
+			"value":     best.Value,
+			"player_id": best.PlayerID,
+			"team_id":   best.TeamID,
+			"game_id":   best.GameID,
+			"set_at":    best.EndedAt,
+		})
+	}
+}
+
+func (s *RecordService) computeBest(ctx context.Context, leagueID int64) (map[models.RecordType]*repositories.RecordCandidate, error) {
+	out := map[models.RecordType]*repositories.RecordCandidate{}
+	for _, rt := range []models.RecordType{models.RecordBiggestMargin, models.RecordHighestScore, models.RecordMostTwenties} {
+		c, err := s.repos.LeagueRecordRepo.BestSideRecord(ctx, leagueID, rt)
+		if err != nil {
+			return nil, err
+		}
+		out[rt] = c
+	}
+
+	// Player records walk every result in the league in EndedAt order.
+	results, err := s.repos.StatsRepo.ListPlayerResults(ctx, repositories.ResultsFilter{LeagueID: &leagueID})
+	if err != nil {
+		return nil, err
+	}
+	out[models.RecordLongestWinStreak], out[models.RecordMostGamesPlayed] = bestPlayerRecords(results)
+	return out, nil
+}
+
+// bestPlayerRecords finds the longest win streak and most games played in results,
+// which are grouped by player and in EndedAt order. Only wins extend a streak; a
+// tie or loss ends it. An equal value never displaces the earlier holder.
+func bestPlayerRecords(results []repositories.ResultRow) (streak, games *repositories.RecordCandidate) {
+	better := func(best *repositories.RecordCandidate, value int64, r repositories.ResultRow) *repositories.RecordCandidate {
+		if best != nil && (value < best.Value || (value == best.Value && !endedBefore(r.EndedAt, best.EndedAt))) {
+			return best
+		}
+		pid := r.ParticipantID
+		return &repositories.RecordCandidate{Value: value, PlayerID: &pid, GameID: r.GameID, EndedAt: r.EndedAt}
+	}
+
+	var (
+		curPlayer   int64
+		run, played int64
+		started     bool
+	)
+	for _, r := range results {
+		if !started || r.ParticipantID != curPlayer {
+			curPlayer, run, played, started = r.ParticipantID, 0, 0, true
+		}
+		played++
+		games = better(games, played, r)
+		if r.Result == "W" {
+			run++
+			streak = better(streak, run, r)
+		} else {
+			run = 0
+		}
+	}
+	return streak, games
+}
+
+// sameHolder reports whether a candidate belongs to the stored record's holder.
+func sameHolder(cur *models.LeagueRecord, best *repositories.RecordCandidate) bool {
+	return sameID(cur.PlayerID, best.PlayerID) && sameID(cur.TeamID, best.TeamID)
+}
+
+// endedBefore orders timestamps with nil last, so undated games never steal a tie.
+func endedBefore(a, b *time.Time) bool {
+	switch {
+	case a == nil:
+		return false
+	case b == nil:
+		return true
+	default:
+		return a.Before(*b)
+	}
+}
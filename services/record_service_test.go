@@ -0,0 +1,99 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+// results builds one player's results from a string of W/L/T, a day apart,
+// numbering games from firstGame.
+func results(playerID, firstGame int64, outcomes string) []repositories.ResultRow {
+	base := time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC)
+	out := make([]repositories.ResultRow, 0, len(outcomes))
+	for i, o := range outcomes {
+		ended := base.AddDate(0, 0, int(firstGame)+i)
+		out = append(out, repositories.ResultRow{
+			ParticipantID: playerID,
+			GameID:        firstGame + int64(i),
+			EndedAt:       &ended,
+			Result:        string(o),
+		})
+	}
+	return out
+}
+
+func TestBestPlayerRecords(t *testing.T) {
+	type want struct {
+		value  int64
+		player int64
+		game   int64
+	}
+	tests := []struct {
+		name       string
+		results    [][]repositories.ResultRow
+		wantStreak *want
+		wantGames  *want
+	}{
+		{
+			name: "no results",
+		},
+		{
+			name:      "no wins sets games played only",
+			results:   [][]repositories.ResultRow{results(1, 1, "LTL")},
+			wantGames: &want{3, 1, 3},
+		},
+		{
+			name:       "a tie ends a streak",
+			results:    [][]repositories.ResultRow{results(1, 1, "WWTWW")},
+			wantStreak: &want{2, 1, 2},
+			wantGames:  &want{5, 1, 5},
+		},
+		{
+			name:       "the longest run wins, not the latest",
+			results:    [][]repositories.ResultRow{results(1, 1, "WWWLWW")},
+			wantStreak: &want{3, 1, 3},
+			wantGames:  &want{6, 1, 6},
+		},
+		{
+			name:       "streaks do not carry across players",
+			results:    [][]repositories.ResultRow{results(1, 1, "LWW"), results(2, 10, "WL")},
+			wantStreak: &want{2, 1, 3},
+			wantGames:  &want{3, 1, 3},
+		},
+		{
+			// Player 2 reaches 2 wins and 2 games earlier in time, so player 1
+			// matching them later never takes either record.
+			name:       "an equal value keeps the earlier holder",
+			results:    [][]repositories.ResultRow{results(1, 10, "WW"), results(2, 1, "WW")},
+			wantStreak: &want{2, 2, 2},
+			wantGames:  &want{2, 2, 2},
+		},
+	}
+
+	check := func(t *testing.T, label string, got *repositories.RecordCandidate, w *want) {
+		t.Helper()
+		switch {
+		case w == nil && got == nil:
+		case w == nil:
+			t.Errorf("%s = %+v, want none", label, *got)
+		case got == nil:
+			t.Errorf("%s = none, want %+v", label, *w)
+		case got.Value != w.value || got.PlayerID == nil || *got.PlayerID != w.player || got.GameID != w.game:
+			t.Errorf("%s = value %d player %v game %d, want %+v", label, got.Value, got.PlayerID, got.GameID, *w)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []repositories.ResultRow
+			for _, r := range tt.results {
+				rows = append(rows, r...)
+			}
+			streak, games := bestPlayerRecords(rows)
+			check(t, "streak", streak, tt.wantStreak)
+			check(t, "games", games, tt.wantGames)
+		})
+	}
+}
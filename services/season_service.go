@@ -5,19 +5,26 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
 	"github.com/matt-j-deasy/betty-crokers-api/models"
 	"github.com/matt-j-deasy/betty-crokers-api/repositories"
 )
 
 type SeasonService struct {
-	repo *repositories.SeasonRepository
+	repo      *repositories.SeasonRepository
+	leagues   *repositories.LeagueRepository
+	matchdays *repositories.MatchdayRepository
+	stats     *repositories.StatsRepository
+	cache     cache.StatsCache
 }
 
-func NewSeasonService(repos *repositories.RepositoriesCollection) *SeasonService {
-	return &SeasonService{repo: repos.SeasonRepo}
+func NewSeasonService(repos *repositories.RepositoriesCollection, statsCache cache.StatsCache) *SeasonService {
+	return &SeasonService{repo: repos.SeasonRepo, leagues: repos.LeagueRepo, matchdays: repos.MatchdayRepo, stats: repos.StatsRepo, cache: statsCache}
 }
 
 // -------- Inputs / Outputs
@@ -27,8 +34,13 @@ type CreateSeasonInput struct {
 	Name        string  `json:"name"`
 	StartsOn    string  `json:"startsOn"`           // "YYYY-MM-DD"
 	EndsOn      string  `json:"endsOn"`             // "YYYY-MM-DD"
-	Timezone    *string `json:"timezone,omitempty"` // IANA; default from model if nil/empty
+	Timezone    *string `json:"timezone,omitempty"` // IANA; default from the league, else America/New_York
 	Description *string `json:"description,omitempty"`
+
+	Defaults *GameDefaultsInput `json:"defaults,omitempty"` // for new games; unset values fall back to the league
+
+	Tiebreakers    []string `json:"tiebreakers,omitempty"`    // ordered; default wins, point_diff, points_for
+	TiebreakerSeed *int64   `json:"tiebreakerSeed,omitempty"` // coin_flip seed
 }
 
 type UpdateSeasonInput struct {
@@ -36,8 +48,13 @@ type UpdateSeasonInput struct {
 	Name        *string `json:"name,omitempty"`
 	StartsOn    *string `json:"startsOn,omitempty"` // "YYYY-MM-DD"
 	EndsOn      *string `json:"endsOn,omitempty"`   // "YYYY-MM-DD"
-	Timezone    *string `json:"timezone,omitempty"` // IANA
+	Timezone    *string `json:"timezone,omitempty"` // IANA; "" follows the league again
 	Description *string `json:"description,omitempty"`
+
+	Defaults *GameDefaultsInput `json:"defaults,omitempty"`
+
+	Tiebreakers    []string `json:"tiebreakers,omitempty"`
+	TiebreakerSeed *int64   `json:"tiebreakerSeed,omitempty"`
 }
 
 type ListSeasonsOptions struct {
@@ -87,21 +104,40 @@ func (s *SeasonService) Create(ctx context.Context, in CreateSeasonInput) (*mode
 		return nil, errors.New("endsOn must be on or after startsOn")
 	}
 
-	tz := "America/New_York"
+	tz, inherited := s.leagueTimezone(ctx, in.LeagueID), true
 	if in.Timezone != nil && *in.Timezone != "" {
 		if _, err := time.LoadLocation(*in.Timezone); err != nil {
 			return nil, errors.New("timezone must be a valid IANA timezone")
 		}
-		tz = *in.Timezone
+		tz, inherited = *in.Timezone, false
+	}
+
+	tiebreakers := models.DefaultTiebreakers
+	if len(in.Tiebreakers) > 0 {
+		tb, err := joinTiebreakers(in.Tiebreakers)
+		if err != nil {
+			return nil, err
+		}
+		tiebreakers = tb
+	}
+	var seed int64
+	if in.TiebreakerSeed != nil {
+		seed = *in.TiebreakerSeed
 	}
 
 	season := &models.Season{
-		LeagueID:    in.LeagueID,
-		Name:        in.Name,
-		StartsOn:    start,
-		EndsOn:      end,
-		Timezone:    tz,
-		Description: in.Description,
+		LeagueID:          in.LeagueID,
+		Name:              in.Name,
+		StartsOn:          start,
+		EndsOn:            end,
+		Timezone:          tz,
+		TimezoneInherited: inherited,
+		Description:       in.Description,
+		Tiebreakers:       tiebreakers,
+		TiebreakerSeed:    seed,
+	}
+	if err := in.Defaults.apply(&season.Defaults); err != nil {
+		return nil, err
 	}
 	if err := s.repo.Create(ctx, season); err != nil {
 		return nil, err
@@ -109,6 +145,15 @@ func (s *SeasonService) Create(ctx context.Context, in CreateSeasonInput) (*mode
 	return season, nil
 }
 
+// leagueTimezone is the timezone a season of leagueID inherits: the league's, else
+// the built-in default.
+func (s *SeasonService) leagueTimezone(ctx context.Context, leagueID int64) string {
+	if l, err := s.leagues.GetByID(ctx, leagueID); err == nil && l.Timezone != nil && *l.Timezone != "" {
+		return *l.Timezone
+	}
+	return defaultTimezone
+}
+
 func (s *SeasonService) GetByID(ctx context.Context, id int64) (*models.Season, error) {
 	return s.repo.GetByID(ctx, id)
 }
@@ -159,28 +204,66 @@ func (s *SeasonService) Update(ctx context.Context, id int64, in UpdateSeasonInp
 		return nil, errors.New("endsOn must be on or after startsOn")
 	}
 
-	if in.Timezone != nil {
-		if *in.Timezone == "" {
-			return nil, errors.New("timezone cannot be empty")
-		}
+	leagueID := cur.LeagueID
+	if in.LeagueID != nil {
+		leagueID = *in.LeagueID
+	}
+	switch {
+	case in.Timezone != nil && *in.Timezone != "":
 		if _, err := time.LoadLocation(*in.Timezone); err != nil {
 			return nil, errors.New("timezone must be a valid IANA timezone")
 		}
 		fields["timezone"] = *in.Timezone
+		fields["timezone_inherited"] = false
+	case in.Timezone != nil || (cur.TimezoneInherited && leagueID != cur.LeagueID):
+		// "" goes back to the league's timezone; an inherited one follows a move to another league.
+		fields["timezone"] = s.leagueTimezone(ctx, leagueID)
+		fields["timezone_inherited"] = true
 	}
 
 	if in.Description != nil {
 		fields["description"] = in.Description // can be nil to clear
 	}
 
+	if in.Defaults != nil {
+		d := cur.Defaults
+		if err := in.Defaults.apply(&d); err != nil {
+			return nil, err
+		}
+		for k, v := range defaultsFields(d) {
+			fields[k] = v
+		}
+	}
+
+	if in.Tiebreakers != nil {
+		tb, err := joinTiebreakers(in.Tiebreakers)
+		if err != nil {
+			return nil, err
+		}
+		fields["tiebreakers"] = tb
+	}
+	if in.TiebreakerSeed != nil {
+		fields["tiebreaker_seed"] = *in.TiebreakerSeed
+	}
+
 	if len(fields) == 0 {
 		return cur, nil
 	}
-	return s.repo.UpdateFields(ctx, id, fields)
+	updated, err := s.repo.UpdateFields(ctx, id, fields)
+	if err != nil {
+		return nil, err
+	}
+	// tiebreakers, timezone and start date all feed the cached standings
+	invalidateSeasons(s.cache, &id)
+	return updated, nil
 }
 
 func (s *SeasonService) Delete(ctx context.Context, id int64) error {
-	return s.repo.DeleteByID(ctx, id)
+	if err := s.repo.DeleteByID(ctx, id); err != nil {
+		return err
+	}
+	invalidateSeasons(s.cache, &id)
+	return nil
 }
 
 func (s *SeasonService) List(ctx context.Context, opts ListSeasonsOptions) (*PagedSeasons, error) {
@@ -211,16 +294,63 @@ func (s *SeasonService) List(ctx context.Context, opts ListSeasonsOptions) (*Pag
 
 type SeasonStandings []repositories.SeasonStandingsRow
 
-func (s *SeasonService) GetStandings(ctx context.Context, seasonID int64) (SeasonStandings, error) {
-	// Optional: check that season exists to return 404 vs empty list
-	if _, err := s.repo.GetByID(ctx, seasonID); err != nil {
+// GetStandings ranks the season's teams. When asOf is set, only games that ended before it count.
+func (s *SeasonService) GetStandings(ctx context.Context, seasonID int64, asOf *time.Time) (SeasonStandings, error) {
+	// Check that season exists to return 404 vs empty list
+	season, err := s.repo.GetByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	return s.rankTeams(ctx, repositories.SeasonScope(season.ID), season, asOf)
+}
+
+// GetMatchdayStandings ranks the season's teams and players over games on matchdays
+// 1..number, regardless of when those games were actually played.
+func (s *SeasonService) GetMatchdayStandings(ctx context.Context, seasonID int64, number int) (*MatchdayStandings, error) {
+	season, err := s.repo.GetByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.matchdays.GetByNumber(ctx, seasonID, number); err != nil {
+		return nil, err
+	}
+	scope := repositories.SeasonScope(seasonID)
+	scope.ThroughMatchday = &number
+
+	teams, err := s.rankTeams(ctx, scope, season, nil)
+	if err != nil {
 		return nil, err
 	}
-	rows, err := s.repo.GetStandings(ctx, seasonID)
+	players, err := s.rankPlayers(ctx, scope, season, nil)
 	if err != nil {
 		return nil, err
 	}
-	return rows, nil
+	return &MatchdayStandings{ThroughMatchday: number, Teams: teams, Players: players}, nil
+}
+
+type MatchdayStandings struct {
+	ThroughMatchday int                 `json:"throughMatchday"`
+	Teams           SeasonStandings     `json:"teams"`
+	Players         []PlayerStandingDTO `json:"players"`
+}
+
+// GetScopedStandings ranks teams over any stats scope. A season scope uses that season's
+// tiebreakers; wider scopes use the defaults.
+func (s *SeasonService) GetScopedStandings(ctx context.Context, scope repositories.StatsScope, asOf *time.Time) (SeasonStandings, error) {
+	tb, err := resolveStatsScope(ctx, s.repo, s.leagues, scope)
+	if err != nil {
+		return nil, err
+	}
+	return s.rankTeams(ctx, scope, tb, asOf)
+}
+
+// GetScopedPlayerStandings ranks every player over any stats scope, unpaged.
+func (s *SeasonService) GetScopedPlayerStandings(ctx context.Context, scope repositories.StatsScope, asOf *time.Time) ([]PlayerStandingDTO, error) {
+	tb, err := resolveStatsScope(ctx, s.repo, s.leagues, scope)
+	if err != nil {
+		return nil, err
+	}
+	return s.rankPlayers(ctx, scope, tb, asOf)
 }
 
 type PlayerStandingDTO struct {
@@ -233,34 +363,358 @@ type PlayerStandingDTO struct {
 	PointDiff     int64   `json:"pointDiff"`
 	WinPct        float64 `json:"winPct"`
 	Rank          int     `json:"rank"`
+	DecidedBy     string  `json:"decidedBy"`
+
+	Form models.FormGuide `json:"form"`
+	models.ScheduleStrength
 }
 
-func (s *SeasonService) ListPlayerStandings(ctx context.Context, seasonID int64, limit int, cursor *repositories.PlayerStandingsCursor) ([]PlayerStandingDTO, *string, error) {
-	rows, next, err := s.repo.ListPlayerStandings(ctx, repositories.ListPlayerStandingsQuery{
-		SeasonID: seasonID, Limit: limit, Cursor: cursor,
-	})
+// ErrStaleCursor is returned when a standings cursor's player is no longer ranked.
+var ErrStaleCursor = errors.New("cursor no longer matches the standings; start from the first page")
+
+// ListPlayerStandings ranks the season's players and returns one page, resuming after the cursor's player.
+// When asOf is set, only games that ended before it count.
+func (s *SeasonService) ListPlayerStandings(ctx context.Context, seasonID int64, asOf *time.Time, limit int, cursor *repositories.PlayerStandingsCursor) ([]PlayerStandingDTO, *string, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	season, err := s.repo.GetByID(ctx, seasonID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranked, err := s.rankPlayers(ctx, repositories.SeasonScope(season.ID), season, asOf)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	out := make([]PlayerStandingDTO, 0, len(rows))
-	for i, r := range rows {
-		out = append(out, PlayerStandingDTO{
-			PlayerID: r.PlayerID, Games: r.Games, Wins: r.Wins, Losses: r.Losses,
-			PointsFor: r.PointsFor, PointsAgainst: r.PointsAgainst, PointDiff: r.PointDiff,
-			WinPct: r.WinPct, Rank: i + 1,
-		})
+	start := 0
+	if cursor != nil {
+		start = -1
+		for i, r := range ranked {
+			if r.PlayerID == cursor.PlayerID {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, nil, ErrStaleCursor
+		}
+	}
+	end := start + limit
+	if end > len(ranked) {
+		end = len(ranked)
 	}
+	out := ranked[start:end]
 
 	var nc *string
-	if next != nil {
-		b, _ := json.Marshal(next)
+	if end < len(ranked) && len(out) > 0 {
+		last := out[len(out)-1]
+		b, _ := json.Marshal(repositories.PlayerStandingsCursor{PlayerID: last.PlayerID})
 		enc := base64.StdEncoding.EncodeToString(b)
 		nc = &enc
 	}
 	return out, nc, nil
 }
 
+// -------- Rank progression
+
+// maxProgressionWeeks bounds the replay for seasons whose dates are far from their games.
+const maxProgressionWeeks = 104
+
+type ProgressionWeek struct {
+	Week int       `json:"week"`
+	AsOf time.Time `json:"asOf"` // standings count games that ended before this instant
+}
+
+type ProgressionSeries struct {
+	ID    int64  `json:"id"`    // team or player id
+	Ranks []*int `json:"ranks"` // one per week; null until the participant appears in the standings
+}
+
+type RankProgression struct {
+	SeasonID int64               `json:"seasonId"`
+	Type     string              `json:"type"` // "teams" | "players"
+	Weeks    []ProgressionWeek   `json:"weeks"`
+	Series   []ProgressionSeries `json:"series"`
+}
+
+// GetRankProgression replays the standings at the end of each week of the season, for bump charts.
+// Weeks start on the season's StartsOn (or its first completed game) in the season's timezone.
+func (s *SeasonService) GetRankProgression(ctx context.Context, seasonID int64, kind string) (*RankProgression, error) {
+	if kind != "teams" && kind != "players" {
+		return nil, errors.New("type must be 'teams' or 'players'")
+	}
+	season, err := s.repo.GetByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	out := &RankProgression{SeasonID: seasonID, Type: kind, Weeks: []ProgressionWeek{}, Series: []ProgressionSeries{}}
+
+	first, last, err := s.repo.GetCompletedRange(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if first == nil || last == nil {
+		return out, nil
+	}
+
+	loc, err := time.LoadLocation(season.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	start := first.In(loc)
+	if !season.StartsOn.IsZero() {
+		start = season.StartsOn
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+
+	// Load the season's matchups once and replay each week's standings from them,
+	// rather than re-running the standings queries per week.
+	scope := repositories.SeasonScope(season.ID)
+	var (
+		matchups []repositories.MatchupRow
+		names    = map[int64]string{}
+		roster   []int64 // players listed before they have played
+	)
+	if kind == "teams" {
+		if matchups, err = s.stats.ListTeamMatchups(ctx, scope, nil); err != nil {
+			return nil, err
+		}
+		rows, err := s.repo.GetStandings(ctx, scope, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			names[r.TeamID] = r.TeamName
+		}
+	} else {
+		if matchups, err = s.stats.ListPlayerMatchups(ctx, scope, nil); err != nil {
+			return nil, err
+		}
+		if roster, err = s.repo.ListRosterPlayerIDs(ctx, season.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	seriesIdx := map[int64]int{}
+	for week := 1; ; week++ {
+		cutoff := start.AddDate(0, 0, 7*week)
+		out.Weeks = append(out.Weeks, ProgressionWeek{Week: week, AsOf: cutoff.UTC()})
+
+		played := matchupsBefore(matchups, cutoff)
+		entries := standingsFromMatchups(played, kind == "teams", roster)
+		winPct := make(map[int64]float64, len(entries))
+		for _, e := range entries {
+			e.Name = names[e.ID]
+			winPct[e.ID] = e.WinPct
+		}
+		sched := scheduleStrength(played, winPct)
+		for _, e := range entries {
+			e.Schedule = sched[e.ID]
+		}
+		newTiebreakContext(season, played).rank(entries)
+
+		ranks := make(map[int64]int, len(entries))
+		for _, e := range entries {
+			ranks[e.ID] = e.Rank
+		}
+
+		for id, rank := range ranks {
+			i, ok := seriesIdx[id]
+			if !ok {
+				out.Series = append(out.Series, ProgressionSeries{ID: id, Ranks: make([]*int, week-1)})
+				i = len(out.Series) - 1
+				seriesIdx[id] = i
+			}
+			r := rank
+			out.Series[i].Ranks = append(out.Series[i].Ranks, &r)
+		}
+		for i := range out.Series {
+			if len(out.Series[i].Ranks) < week {
+				out.Series[i].Ranks = append(out.Series[i].Ranks, nil)
+			}
+		}
+
+		if cutoff.After(*last) || week >= maxProgressionWeeks {
+			break
+		}
+	}
+
+	sort.Slice(out.Series, func(i, j int) bool { return out.Series[i].ID < out.Series[j].ID })
+	return out, nil
+}
+
+// -------- Ranking
+
+// matchupsBefore keeps the matchups whose game ended before cutoff, as an asOf
+// query would.
+func matchupsBefore(rows []repositories.MatchupRow, cutoff time.Time) []repositories.MatchupRow {
+	out := make([]repositories.MatchupRow, 0, len(rows))
+	for _, m := range rows {
+		if m.EndedAt != nil && m.EndedAt.Before(cutoff) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// standingsFromMatchups totals each participant's games the way the standings
+// queries do. Team win% counts ties as half a win, rounded to 4 places; player win%
+// is wins over games. Roster players are listed even with no games.
+func standingsFromMatchups(rows []repositories.MatchupRow, teams bool, roster []int64) []*standingEntry {
+	type tally struct{ games, wins, ties, pf, pa int64 }
+	per := map[int64]*tally{}
+	var order []int64
+	seen := map[[2]int64]bool{} // a team game has two opposing players; count it once
+	for _, id := range roster {
+		if per[id] == nil {
+			per[id] = &tally{}
+			order = append(order, id)
+		}
+	}
+	for _, m := range rows {
+		k := [2]int64{m.ParticipantID, m.GameID}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		t := per[m.ParticipantID]
+		if t == nil {
+			t = &tally{}
+			per[m.ParticipantID] = t
+			order = append(order, m.ParticipantID)
+		}
+		t.games++
+		t.pf += m.PointsFor
+		t.pa += m.PointsAgainst
+		switch m.Result {
+		case "W":
+			t.wins++
+		case "T":
+			t.ties++
+		}
+	}
+
+	out := make([]*standingEntry, 0, len(order))
+	for _, id := range order {
+		t := per[id]
+		e := &standingEntry{ID: id, Wins: t.wins, PointDiff: t.pf - t.pa, PointsFor: t.pf}
+		if t.games > 0 {
+			if teams {
+				e.WinPct = math.Round((float64(t.wins)+0.5*float64(t.ties))/float64(t.games)*10000) / 10000
+			} else {
+				e.WinPct = float64(t.wins) / float64(t.games)
+			}
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// rankTeams ranks the scope's teams with season's tiebreakers.
+func (s *SeasonService) rankTeams(ctx context.Context, scope repositories.StatsScope, season *models.Season, asOf *time.Time) (SeasonStandings, error) {
+	rows, err := s.repo.GetStandings(ctx, scope, asOf)
+	if err != nil {
+		return nil, err
+	}
+	matchups, err := s.stats.ListTeamMatchups(ctx, scope, asOf)
+	if err != nil {
+		return nil, err
+	}
+	filter := scope.Results()
+	filter.EndedBefore = asOf
+	results, err := s.stats.ListTeamResults(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	winPct := make(map[int64]float64, len(rows))
+	for _, r := range rows {
+		winPct[r.TeamID] = r.WinPct
+	}
+	sched := scheduleStrength(matchups, winPct)
+
+	byID := make(map[int64]repositories.SeasonStandingsRow, len(rows))
+	entries := make([]*standingEntry, 0, len(rows))
+	for _, r := range rows {
+		byID[r.TeamID] = r
+		entries = append(entries, &standingEntry{
+			ID: r.TeamID, Name: r.TeamName,
+			Wins: int64(r.Wins), WinPct: r.WinPct,
+			PointDiff: int64(r.PointDiff), PointsFor: int64(r.PointsFor),
+			Schedule: sched[r.TeamID],
+		})
+	}
+	newTiebreakContext(season, matchups).rank(entries)
+
+	forms := formByParticipant(results)
+	out := make(SeasonStandings, 0, len(entries))
+	for _, e := range entries {
+		r := byID[e.ID]
+		r.Rank = e.Rank
+		r.DecidedBy = e.DecidedBy
+		r.Form = forms[e.ID]
+		r.ScheduleStrength = e.Schedule
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// rankPlayers ranks the scope's players with season's tiebreakers.
+func (s *SeasonService) rankPlayers(ctx context.Context, scope repositories.StatsScope, season *models.Season, asOf *time.Time) ([]PlayerStandingDTO, error) {
+	rows, err := s.repo.ListPlayerStandings(ctx, scope, asOf)
+	if err != nil {
+		return nil, err
+	}
+	matchups, err := s.stats.ListPlayerMatchups(ctx, scope, asOf)
+	if err != nil {
+		return nil, err
+	}
+	filter := scope.Results()
+	filter.EndedBefore = asOf
+	results, err := s.stats.ListPlayerResults(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	winPct := make(map[int64]float64, len(rows))
+	for _, r := range rows {
+		winPct[r.PlayerID] = r.WinPct
+	}
+	sched := scheduleStrength(matchups, winPct)
+
+	byID := make(map[int64]repositories.PlayerStandingsRow, len(rows))
+	entries := make([]*standingEntry, 0, len(rows))
+	for _, r := range rows {
+		byID[r.PlayerID] = r
+		entries = append(entries, &standingEntry{
+			ID:   r.PlayerID,
+			Wins: r.Wins, WinPct: r.WinPct,
+			PointDiff: r.PointDiff, PointsFor: r.PointsFor,
+			Schedule: sched[r.PlayerID],
+		})
+	}
+	newTiebreakContext(season, matchups).rank(entries)
+
+	forms := formByParticipant(results)
+	out := make([]PlayerStandingDTO, 0, len(entries))
+	for _, e := range entries {
+		r := byID[e.ID]
+		out = append(out, PlayerStandingDTO{
+			PlayerID: r.PlayerID, Games: r.Games, Wins: r.Wins, Losses: r.Losses,
+			PointsFor: r.PointsFor, PointsAgainst: r.PointsAgainst, PointDiff: r.PointDiff,
+			WinPct: r.WinPct, Rank: e.Rank, DecidedBy: e.DecidedBy,
+			Form:             forms[r.PlayerID],
+			ScheduleStrength: e.Schedule,
+		})
+	}
+	return out, nil
+}
+
 // -------- Helpers
 
 func parseYMD(s string) (time.Time, error) {
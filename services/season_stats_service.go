@@ -3,9 +3,14 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"sort"
+	"time"
 
+	"github.com/matt-j-deasy/betty-crokers-api/models"
 	"github.com/matt-j-deasy/betty-crokers-api/repositories"
 )
 
@@ -26,6 +31,8 @@ type PlayerStats struct {
 	WhiteGames   int64 `json:"whiteGames"`
 	BlackGames   int64 `json:"blackGames"`
 	NaturalGames int64 `json:"naturalGames"`
+
+	models.ScheduleStrength
 }
 
 type TeamStats struct {
@@ -46,6 +53,113 @@ type TeamStats struct {
 
 	BestLocation     *string `json:"bestLocation"`
 	BestLocationWins int64   `json:"bestLocationWins"`
+
+	models.ScheduleStrength
+}
+
+// SeasonStatsQuery narrows, sorts and pages the season stats listings.
+// Size 0 returns every row.
+type SeasonStatsQuery struct {
+	From       *time.Time // by EndedAt
+	To         *time.Time // by EndedAt
+	Location   *string
+	Color      *string // "white" | "black" | "natural"
+	OpponentID *int64  // player stats: an opposing player; team stats: the opposing team
+	MatchType  *string // "teams"/"doubles" | "players"/"singles"
+	MinGames   int
+
+	Sort  string // a stat column, e.g. "winPct", "games", "whiteWins"; default "winPct"
+	Order string // "asc" | "desc"; default "desc"
+	Page  int
+	Size  int
+}
+
+type PagedPlayerStats struct {
+	Data  []PlayerStats `json:"data"`
+	Total int64         `json:"total"`
+	Page  int           `json:"page"`
+	Size  int           `json:"size"`
+}
+
+type PagedTeamStats struct {
+	Data  []TeamStats `json:"data"`
+	Total int64       `json:"total"`
+	Page  int         `json:"page"`
+	Size  int         `json:"size"`
+}
+
+// repoQuery validates q and translates it for the repository.
+func (q SeasonStatsQuery) repoQuery(scope repositories.StatsScope) (repositories.StatsQuery, error) {
+	rq := repositories.StatsQuery{
+		Scope:      scope,
+		From:       q.From,
+		To:         q.To,
+		Location:   q.Location,
+		OpponentID: q.OpponentID,
+		MinGames:   q.MinGames,
+		SortBy:     q.Sort,
+		Desc:       true,
+	}
+	if q.From != nil && q.To != nil && q.To.Before(*q.From) {
+		return rq, errors.New("to must be on or after from")
+	}
+	if q.MinGames < 0 {
+		return rq, errors.New("minGames must be >= 0")
+	}
+	if q.Color != nil {
+		switch models.DiscColor(*q.Color) {
+		case models.DiscWhite, models.DiscBlack, models.DiscNatural:
+			rq.Color = q.Color
+		default:
+			return rq, errors.New("color must be 'white', 'black' or 'natural'")
+		}
+	}
+	if q.MatchType != nil {
+		var mt string
+		switch *q.MatchType {
+		case "teams", "doubles":
+			mt = "teams"
+		case "players", "singles":
+			mt = "players"
+		default:
+			return rq, errors.New("matchType must be 'singles' or 'doubles'")
+		}
+		rq.MatchType = &mt
+	}
+	if rq.SortBy == "" {
+		rq.SortBy = "winPct"
+	}
+	if _, ok := repositories.StatsSortColumns[rq.SortBy]; !ok {
+		return rq, errors.New("unsupported sort column: " + q.Sort)
+	}
+	switch q.Order {
+	case "", "desc":
+	case "asc":
+		rq.Desc = false
+	default:
+		return rq, errors.New("order must be 'asc' or 'desc'")
+	}
+	if q.Size > 0 {
+		page := q.Page
+		if page < 1 {
+			page = 1
+		}
+		rq.Limit = q.Size
+		rq.Offset = (page - 1) * q.Size
+	}
+	return rq, nil
+}
+
+// Validate reports a malformed query, so handlers can answer 400 before any lookup.
+func (q SeasonStatsQuery) Validate() error {
+	_, err := q.repoQuery(repositories.StatsScope{})
+	return err
+}
+
+// narrowed reports whether q drops any of the season's participants from the result.
+func (q SeasonStatsQuery) narrowed() bool {
+	return q.From != nil || q.To != nil || q.Location != nil || q.Color != nil ||
+		q.OpponentID != nil || q.MatchType != nil || q.MinGames > 0 || q.Size > 0
 }
 
 type SeasonStatsService struct {
@@ -71,21 +185,55 @@ func (s *SeasonStatsService) validateSeasonExists(ctx context.Context, seasonID
 	return nil
 }
 
+// validateScope checks that the scope's season or league exists.
+func (s *SeasonStatsService) validateScope(ctx context.Context, scope repositories.StatsScope) error {
+	if scope.Kind == repositories.ScopeSeason {
+		return s.validateSeasonExists(ctx, scope.ID)
+	}
+	_, err := resolveStatsScope(ctx, s.repositories.SeasonRepo, s.repositories.LeagueRepo, scope)
+	return err
+}
+
+// ListPlayerStats aggregates player stats over any stats scope (a season, a league,
+// exhibition games or everything).
 func (s *SeasonStatsService) ListPlayerStats(
 	ctx context.Context,
-	seasonID int64,
-) ([]PlayerStats, error) {
-	if err := s.validateSeasonExists(ctx, seasonID); err != nil {
-		slog.Error("season validation failed", "seasonID", seasonID, "error", err)
+	scope repositories.StatsScope,
+	q SeasonStatsQuery,
+) (*PagedPlayerStats, error) {
+	if err := s.validateScope(ctx, scope); err != nil {
+		slog.Error("scope validation failed", "scope", scope.Kind, "id", scope.ID, "error", err)
+		return nil, err
+	}
+	rq, err := q.repoQuery(scope)
+	if err != nil {
 		return nil, err
 	}
 
-	rows, err := s.repositories.SeasonRepo.ListPlayerStats(ctx, seasonID)
+	rows, total, err := s.repositories.SeasonRepo.ListPlayerStats(ctx, rq)
 	if err != nil {
-		slog.Error("failed to list player stats from repo", "seasonID", seasonID, "error", err)
+		slog.Error("failed to list player stats from repo", "scope", scope.Kind, "id", scope.ID, "error", err)
 		return nil, err
 	}
 
+	// Schedule strength rates opponents on the whole scope, whatever this page shows.
+	all := rows
+	if q.narrowed() {
+		if all, _, err = s.repositories.SeasonRepo.ListPlayerStats(ctx, repositories.StatsQuery{Scope: scope}); err != nil {
+			return nil, err
+		}
+	}
+	matchups, err := s.repositories.StatsRepo.ListPlayerMatchups(ctx, scope, nil)
+	if err != nil {
+		slog.Error("failed to list player matchups from repo", "scope", scope.Kind, "id", scope.ID, "error", err)
+		return nil, err
+	}
+	winPct := make(map[int64]float64, len(all))
+	for _, r := range all {
+		winPct[r.PlayerID] = r.WinPct
+	}
+	sched := scheduleStrength(matchups, winPct)
+
 	out := make([]PlayerStats, 0, len(rows))
 	for _, r := range rows {
 		out = append(out, PlayerStats{
@@ -100,27 +248,54 @@ func (s *SeasonStatsService) ListPlayerStats(
 			WhiteGames:   r.WhiteGames,
 			BlackGames:   r.BlackGames,
 			NaturalGames: r.NaturalGames,
+
+			ScheduleStrength: sched[r.PlayerID],
 		})
 	}
 
-	slog.Info("fetched player stats", "seasonID", seasonID, "count", len(out))
-	return out, nil
+	slog.Info("fetched player stats", "scope", scope.Kind, "id", scope.ID, "count", len(out))
+	return &PagedPlayerStats{Data: out, Total: total, Page: q.Page, Size: q.Size}, nil
 }
 
+// ListTeamStats aggregates team stats over any stats scope (a season, a league,
+// exhibition games or everything).
 func (s *SeasonStatsService) ListTeamStats(
 	ctx context.Context,
-	seasonID int64,
-) ([]TeamStats, error) {
-	if err := s.validateSeasonExists(ctx, seasonID); err != nil {
-		slog.Error("season validation failed", "seasonID", seasonID, "error", err)
+	scope repositories.StatsScope,
+	q SeasonStatsQuery,
+) (*PagedTeamStats, error) {
+	if err := s.validateScope(ctx, scope); err != nil {
+		slog.Error("scope validation failed", "scope", scope.Kind, "id", scope.ID, "error", err)
+		return nil, err
+	}
+	rq, err := q.repoQuery(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, total, err := s.repositories.SeasonRepo.ListTeamStats(ctx, rq)
+	if err != nil {
+		slog.Error("failed to list team stats from repo", "scope", scope.Kind, "id", scope.ID, "error", err)
 		return nil, err
 	}
 
-	rows, err := s.repositories.SeasonRepo.ListTeamStats(ctx, seasonID)
+	// Schedule strength rates opponents on the whole scope, whatever this page shows.
+	all := rows
+	if q.narrowed() {
+		if all, _, err = s.repositories.SeasonRepo.ListTeamStats(ctx, repositories.StatsQuery{Scope: scope}); err != nil {
+			return nil, err
+		}
+	}
+	matchups, err := s.repositories.StatsRepo.ListTeamMatchups(ctx, scope, nil)
 	if err != nil {
-		slog.Error("failed to list team stats from repo", "seasonID", seasonID, "error", err)
+		slog.Error("failed to list team matchups from repo", "scope", scope.Kind, "id", scope.ID, "error", err)
 		return nil, err
 	}
+	winPct := make(map[int64]float64, len(all))
+	for _, r := range all {
+		winPct[r.TeamID] = r.WinPct
+	}
+	sched := scheduleStrength(matchups, winPct)
 
 	out := make([]TeamStats, 0, len(rows))
 	for _, r := range rows {
@@ -138,9 +313,167 @@ func (s *SeasonStatsService) ListTeamStats(
 			NaturalGames:     r.NaturalGames,
 			BestLocation:     r.BestLocation,
 			BestLocationWins: r.BestLocationWins,
+
+			ScheduleStrength: sched[r.TeamID],
 		})
 	}
 
-	slog.Info("fetched team stats", "seasonID", seasonID, "count", len(out))
+	slog.Info("fetched team stats", "scope", scope.Kind, "id", scope.ID, "count", len(out))
+	return &PagedTeamStats{Data: out, Total: total, Page: q.Page, Size: q.Size}, nil
+}
+
+// ---------- Duration / pace
+
+// DefaultStaleAfter is how long a game may sit in_progress before it is flagged.
+const DefaultStaleAfter = 3 * time.Hour
+
+// DurationSummary describes game lengths in seconds.
+type DurationSummary struct {
+	Games         int64   `json:"games"`
+	MeanSeconds   float64 `json:"meanSeconds"`
+	MedianSeconds float64 `json:"medianSeconds"`
+	P90Seconds    float64 `json:"p90Seconds"`
+}
+
+type LocationDuration struct {
+	Location string `json:"location"` // lower-cased and trimmed; "Unknown" when the game has none
+	DurationSummary
+}
+
+type MatchTypeDuration struct {
+	MatchType string `json:"matchType"`
+	DurationSummary
+}
+
+type ParticipantDuration struct {
+	ID int64 `json:"id"`
+	DurationSummary
+}
+
+// StaleGame is a game left in_progress for longer than the threshold.
+type StaleGame struct {
+	GameID         int64     `json:"gameId"`
+	Location       *string   `json:"location"`
+	StartedAt      time.Time `json:"startedAt"`
+	RunningSeconds int64     `json:"runningSeconds"`
+}
+
+type SeasonDurationStats struct {
+	SeasonID    int64                 `json:"seasonId"`
+	Overall     DurationSummary       `json:"overall"`
+	ByLocation  []LocationDuration    `json:"byLocation"`
+	ByMatchType []MatchTypeDuration   `json:"byMatchType"`
+	ByPlayer    []ParticipantDuration `json:"byPlayer"`
+	ByTeam      []ParticipantDuration `json:"byTeam"`
+
+	StaleAfterSeconds int64       `json:"staleAfterSeconds"`
+	StaleInProgress   []StaleGame `json:"staleInProgress"`
+}
+
+// GetDurationStats summarises how long the season's completed games took (StartedAt to
+// EndedAt) and flags games that have been in_progress for longer than staleAfter.
+func (s *SeasonStatsService) GetDurationStats(ctx context.Context, seasonID int64, staleAfter time.Duration) (*SeasonDurationStats, error) {
+	if err := s.validateSeasonExists(ctx, seasonID); err != nil {
+		return nil, err
+	}
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleAfter
+	}
+
+	rows, err := s.repositories.StatsRepo.ListSeasonGameDurations(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		overall     []float64
+		byLocation  = map[string][]float64{}
+		byMatchType = map[string][]float64{}
+		byPlayer    = map[int64][]float64{}
+		byTeam      = map[int64][]float64{}
+	)
+	for _, r := range rows {
+		switch r.Kind {
+		case "game":
+			overall = append(overall, r.DurationSeconds)
+			byLocation[r.Location] = append(byLocation[r.Location], r.DurationSeconds)
+			byMatchType[r.MatchType] = append(byMatchType[r.MatchType], r.DurationSeconds)
+		case "team":
+			byTeam[r.ParticipantID] = append(byTeam[r.ParticipantID], r.DurationSeconds)
+		default:
+			byPlayer[r.ParticipantID] = append(byPlayer[r.ParticipantID], r.DurationSeconds)
+		}
+	}
+
+	out := &SeasonDurationStats{
+		SeasonID:          seasonID,
+		Overall:           summarizeDurations(overall),
+		ByLocation:        []LocationDuration{},
+		ByMatchType:       []MatchTypeDuration{},
+		ByPlayer:          participantDurations(byPlayer),
+		ByTeam:            participantDurations(byTeam),
+		StaleAfterSeconds: int64(staleAfter / time.Second),
+		StaleInProgress:   []StaleGame{},
+	}
+	for loc, ds := range byLocation {
+		out.ByLocation = append(out.ByLocation, LocationDuration{Location: loc, DurationSummary: summarizeDurations(ds)})
+	}
+	sort.Slice(out.ByLocation, func(i, j int) bool { return out.ByLocation[i].Location < out.ByLocation[j].Location })
+	for mt, ds := range byMatchType {
+		out.ByMatchType = append(out.ByMatchType, MatchTypeDuration{MatchType: mt, DurationSummary: summarizeDurations(ds)})
+	}
+	sort.Slice(out.ByMatchType, func(i, j int) bool { return out.ByMatchType[i].MatchType < out.ByMatchType[j].MatchType })
+
+	now := time.Now().UTC()
+	stale, err := s.repositories.GameRepo.ListStaleInProgress(ctx, seasonID, now.Add(-staleAfter))
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range stale {
+		if g.StartedAt == nil {
+			continue
+		}
+		out.StaleInProgress = append(out.StaleInProgress, StaleGame{
+			GameID:         g.ID,
+			Location:       g.Location,
+			StartedAt:      *g.StartedAt,
+			RunningSeconds: int64(now.Sub(*g.StartedAt) / time.Second),
+		})
+	}
 	return out, nil
 }
+
+func participantDurations(m map[int64][]float64) []ParticipantDuration {
+	out := make([]ParticipantDuration, 0, len(m))
+	for id, ds := range m {
+		out = append(out, ParticipantDuration{ID: id, DurationSummary: summarizeDurations(ds)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// summarizeDurations sorts ds in place. P90 uses the nearest-rank method.
+func summarizeDurations(ds []float64) DurationSummary {
+	out := DurationSummary{Games: int64(len(ds))}
+	if len(ds) == 0 {
+		return out
+	}
+	sort.Float64s(ds)
+
+	var sum float64
+	for _, d := range ds {
+		sum += d
+	}
+	out.MeanSeconds = math.Round(sum / float64(len(ds)))
+
+	mid := len(ds) / 2
+	if len(ds)%2 == 0 {
+		out.MedianSeconds = math.Round((ds[mid-1] + ds[mid]) / 2)
+	} else {
+		out.MedianSeconds = math.Round(ds[mid])
+	}
+
+	rank := int(math.Ceil(0.9 * float64(len(ds))))
+	out.P90Seconds = math.Round(ds[rank-1])
+	return out
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+// standingEntry is the tiebreaker view of a team or player standings row.
+type standingEntry struct {
+	ID        int64
+	Name      string // final fallback (ascending) when every tiebreaker is level; ID if empty
+	Wins      int64
+	WinPct    float64
+	PointDiff int64
+	PointsFor int64
+	Schedule  models.ScheduleStrength
+
+	Rank      int
+	DecidedBy string
+}
+
+type h2hRecord struct {
+	Games int64
+	Wins  int64
+	Ties  int64
+}
+
+// tiebreakContext holds the season's ordered criteria plus the data some of them need.
+type tiebreakContext struct {
+	criteria []models.Tiebreaker
+	seed     int64
+	h2h      map[[2]int64]h2hRecord // (participant, opponent) -> participant's record vs opponent
+}
+
+func newTiebreakContext(season *models.Season, matchups []repositories.MatchupRow) tiebreakContext {
+	criteria, err := parseTiebreakers(season.Tiebreakers)
+	if err != nil || len(criteria) == 0 {
+		criteria, _ = parseTiebreakers(models.DefaultTiebreakers)
+	}
+
+	h2h := map[[2]int64]h2hRecord{}
+	for _, m := range matchups {
+		k := [2]int64{m.ParticipantID, m.OpponentID}
+		rec := h2h[k]
+		rec.Games++
+		switch m.Result {
+		case "W":
+			rec.Wins++
+		case "T":
+			rec.Ties++
+		}
+		h2h[k] = rec
+	}
+	return tiebreakContext{criteria: criteria, seed: season.TiebreakerSeed, h2h: h2h}
+}
+
+// rank orders entries in place and stamps Rank and DecidedBy.
+func (tc tiebreakContext) rank(entries []*standingEntry) {
+	tc.split(entries, 0)
+	for i, e := range entries {
+		e.Rank = i + 1
+	}
+}
+
+// split sorts a group that is level on every earlier criterion by criteria[depth],
+// then recurses into each sub-group that is still level. Head-to-head keys depend
+// on who is in the group, so a smaller sub-group still level on head-to-head is
+// re-run as its own mini-league before moving on to the next criterion.
+func (tc tiebreakContext) split(group []*standingEntry, depth int) {
+	if len(group) < 2 {
+		return
+	}
+	if depth == len(tc.criteria) {
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].Name != group[j].Name {
+				return group[i].Name < group[j].Name
+			}
+			return group[i].ID < group[j].ID
+		})
+		decided := "id"
+		if group[0].Name != "" {
+			decided = "name"
+		}
+		for _, e := range group {
+			e.DecidedBy = decided
+		}
+		return
+	}
+
+	c := tc.criteria[depth]
+	keys := tc.keys(c, group)
+	sort.SliceStable(group, func(i, j int) bool {
+		return keys[group[i].ID] > keys[group[j].ID]
+	})
+
+	for start := 0; start < len(group); {
+		end := start + 1
+		for end < len(group) && keys[group[end].ID] == keys[group[start].ID] {
+			end++
+		}
+		switch {
+		case end-start == 1:
+			group[start].DecidedBy = string(c)
+		case c == models.TiebreakHeadToHead && end-start < len(group):
+			tc.split(group[start:end], depth)
+		default:
+			tc.split(group[start:end], depth+1)
+		}
+		start = end
+	}
+}
+
+// keys returns a "higher is better" value per entry for one criterion.
+func (tc tiebreakContext) keys(c models.Tiebreaker, group []*standingEntry) map[int64]float64 {
+	out := make(map[int64]float64, len(group))
+	switch c {
+	case models.TiebreakWins:
+		for _, e := range group {
+			out[e.ID] = float64(e.Wins)
+		}
+	case models.TiebreakWinPct:
+		for _, e := range group {
+			out[e.ID] = e.WinPct
+		}
+	case models.TiebreakPointDiff:
+		for _, e := range group {
+			out[e.ID] = float64(e.PointDiff)
+		}
+	case models.TiebreakPointsFor:
+		for _, e := range group {
+			out[e.ID] = float64(e.PointsFor)
+		}
+	case models.TiebreakStrengthOfSchedule:
+		for _, e := range group {
+			out[e.ID] = e.Schedule.OppWinPct
+		}
+	case models.TiebreakOppOppWinPct:
+		for _, e := range group {
+			out[e.ID] = e.Schedule.OppOppWinPct
+		}
+	case models.TiebreakAdjustedPointDiff:
+		for _, e := range group {
+			out[e.ID] = e.Schedule.AdjustedPointDiff
+		}
+	case models.TiebreakHeadToHead:
+		// Mini-table: win% (ties count half) in games among the tied group only.
+		// If anyone in the group has not played the others, head-to-head cannot separate it.
+		for _, e := range group {
+			var rec h2hRecord
+			for _, o := range group {
+				if o.ID == e.ID {
+					continue
+				}
+				r := tc.h2h[[2]int64{e.ID, o.ID}]
+				rec.Games += r.Games
+				rec.Wins += r.Wins
+				rec.Ties += r.Ties
+			}
+			if rec.Games == 0 {
+				for _, x := range group {
+					out[x.ID] = 0
+				}
+				return out
+			}
+			out[e.ID] = (float64(rec.Wins) + 0.5*float64(rec.Ties)) / float64(rec.Games)
+		}
+	case models.TiebreakCoinFlip:
+		for _, e := range group {
+			out[e.ID] = coinFlip(tc.seed, e.ID)
+		}
+	}
+	return out
+}
+
+// coinFlip is a deterministic per-(seed, id) draw in [0, 2^53).
+func coinFlip(seed, id int64) float64 {
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], uint64(seed))
+	binary.BigEndian.PutUint64(buf[8:], uint64(id))
+	h := fnv.New64a()
+	_, _ = h.Write(buf[:])
+	return float64(h.Sum64() >> 11)
+}
+
+// scheduleStrength derives each participant's ScheduleStrength from its matchups.
+// winPct is the season win% the opponents are judged by. Every matchup row counts
+// once, so team games weigh each opposing player equally in player schedules.
+func scheduleStrength(matchups []repositories.MatchupRow, winPct map[int64]float64) map[int64]models.ScheduleStrength {
+	type acc struct {
+		n      float64
+		owp    float64
+		margin float64
+	}
+	per := map[int64]*acc{}
+	for _, m := range matchups {
+		a := per[m.ParticipantID]
+		if a == nil {
+			a = &acc{}
+			per[m.ParticipantID] = a
+		}
+		a.n++
+		a.owp += winPct[m.OpponentID]
+		a.margin += float64(m.PointsFor - m.PointsAgainst)
+	}
+
+	owp := make(map[int64]float64, len(per))
+	avgMargin := make(map[int64]float64, len(per))
+	for id, a := range per {
+		owp[id] = a.owp / a.n
+		avgMargin[id] = a.margin / a.n
+	}
+
+	// Second pass needs every participant's OWP and average margin.
+	oowpSum := map[int64]float64{}
+	adjSum := map[int64]float64{}
+	for _, m := range matchups {
+		oowpSum[m.ParticipantID] += owp[m.OpponentID]
+		adjSum[m.ParticipantID] += float64(m.PointsFor-m.PointsAgainst) + avgMargin[m.OpponentID]
+	}
+
+	out := make(map[int64]models.ScheduleStrength, len(per))
+	for id, a := range per {
+		out[id] = models.ScheduleStrength{
+			OppWinPct:         owp[id],
+			OppOppWinPct:      oowpSum[id] / a.n,
+			AdjustedPointDiff: adjSum[id] / a.n,
+		}
+	}
+	return out
+}
+
+// parseTiebreakers validates a comma-separated tiebreaker list.
+func parseTiebreakers(s string) ([]models.Tiebreaker, error) {
+	var out []models.Tiebreaker
+	seen := map[models.Tiebreaker]bool{}
+	for _, part := range strings.Split(s, ",") {
+		t := models.Tiebreaker(strings.ToLower(strings.TrimSpace(part)))
+		if t == "" {
+			continue
+		}
+		switch t {
+		case models.TiebreakWins, models.TiebreakWinPct, models.TiebreakHeadToHead,
+			models.TiebreakPointDiff, models.TiebreakPointsFor,
+			models.TiebreakStrengthOfSchedule, models.TiebreakOppOppWinPct,
+			models.TiebreakAdjustedPointDiff, models.TiebreakCoinFlip:
+		default:
+			return nil, errors.New("invalid tiebreaker: " + string(t))
+		}
+		if seen[t] {
+			return nil, errors.New("duplicate tiebreaker: " + string(t))
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// joinTiebreakers validates a tiebreaker list and renders it for storage.
+func joinTiebreakers(in []string) (string, error) {
+	parsed, err := parseTiebreakers(strings.Join(in, ","))
+	if err != nil {
+		return "", err
+	}
+	if len(parsed) == 0 {
+		return "", errors.New("tiebreakers cannot be empty")
+	}
+	parts := make([]string, 0, len(parsed))
+	for _, t := range parsed {
+		parts = append(parts, string(t))
+	}
+	return strings.Join(parts, ","), nil
+}
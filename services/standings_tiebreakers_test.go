@@ -0,0 +1,116 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+// beat records a single game won by winner, from both participants' points of view.
+func beat(winner, loser int64) []repositories.MatchupRow {
+	return []repositories.MatchupRow{
+		{ParticipantID: winner, OpponentID: loser, Result: "W"},
+		{ParticipantID: loser, OpponentID: winner, Result: "L"},
+	}
+}
+
+func TestTiebreakRank(t *testing.T) {
+	const a, b, c, d = 1, 2, 3, 4
+
+	tests := []struct {
+		name        string
+		tiebreakers string
+		matchups    [][]repositories.MatchupRow
+		entries     []standingEntry
+		wantOrder   []int64
+		wantDecided []string
+	}{
+		{
+			name:        "wins then point diff",
+			tiebreakers: "wins,point_diff",
+			entries: []standingEntry{
+				{ID: a, Wins: 2, PointDiff: 5},
+				{ID: b, Wins: 3, PointDiff: -1},
+				{ID: c, Wins: 2, PointDiff: 9},
+			},
+			wantOrder:   []int64{b, c, a},
+			wantDecided: []string{"wins", "point_diff", "point_diff"},
+		},
+		{
+			name:        "level on everything falls back to name",
+			tiebreakers: "wins",
+			entries: []standingEntry{
+				{ID: a, Name: "Zed", Wins: 1},
+				{ID: b, Name: "Amy", Wins: 1},
+			},
+			wantOrder:   []int64{b, a},
+			wantDecided: []string{"name", "name"},
+		},
+		{
+			name:        "head-to-head without a full round robin cannot separate",
+			tiebreakers: "head_to_head,point_diff",
+			matchups:    [][]repositories.MatchupRow{beat(a, b)},
+			entries: []standingEntry{
+				{ID: a, PointDiff: 1},
+				{ID: b, PointDiff: 2},
+				{ID: c, PointDiff: 3},
+			},
+			wantOrder:   []int64{c, b, a},
+			wantDecided: []string{"point_diff", "point_diff", "point_diff"},
+		},
+		{
+			// In the four-way mini-table A and D go 2-1 and B and C go 1-2. Each pair is
+			// then re-run among just its own members: A beat D and B beat C, so point
+			// diff (which favours D and C) never comes into it.
+			name:        "head-to-head re-runs on each smaller tied group",
+			tiebreakers: "head_to_head,point_diff",
+			matchups: [][]repositories.MatchupRow{
+				beat(a, b), beat(c, a), beat(a, d),
+				beat(b, c), beat(d, b), beat(d, c),
+			},
+			entries: []standingEntry{
+				{ID: a, PointDiff: 5},
+				{ID: b, PointDiff: 1},
+				{ID: c, PointDiff: 3},
+				{ID: d, PointDiff: 10},
+			},
+			wantOrder:   []int64{a, d, b, c},
+			wantDecided: []string{"head_to_head", "head_to_head", "head_to_head", "head_to_head"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var matchups []repositories.MatchupRow
+			for _, m := range tt.matchups {
+				matchups = append(matchups, m...)
+			}
+			tc := newTiebreakContext(&models.Season{Tiebreakers: tt.tiebreakers}, matchups)
+
+			entries := make([]*standingEntry, len(tt.entries))
+			for i := range tt.entries {
+				e := tt.entries[i]
+				entries[i] = &e
+			}
+			tc.rank(entries)
+
+			var order []int64
+			var decided []string
+			for i, e := range entries {
+				if e.Rank != i+1 {
+					t.Errorf("entry %d has rank %d", e.ID, e.Rank)
+				}
+				order = append(order, e.ID)
+				decided = append(decided, e.DecidedBy)
+			}
+			if !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", order, tt.wantOrder)
+			}
+			if !reflect.DeepEqual(decided, tt.wantDecided) {
+				t.Errorf("decidedBy = %v, want %v", decided, tt.wantDecided)
+			}
+		})
+	}
+}
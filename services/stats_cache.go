@@ -0,0 +1,32 @@
+package services
+
+import (
+	"log/slog"
+
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
+)
+
+// invalidateSeasons drops cached standings/stats for every non-nil season.
+// Exhibition games (nil season) have nothing cached.
+func invalidateSeasons(c cache.StatsCache, seasonIDs ...*int64) {
+	if c == nil {
+		return
+	}
+	for _, id := range seasonIDs {
+		if id != nil {
+			c.InvalidateSeason(*id)
+		}
+	}
+}
+
+// invalidateSeasonList drops cached standings/stats for seasons looked up after a
+// write. The write has already succeeded, so a failed lookup is only logged.
+func invalidateSeasonList(c cache.StatsCache, ids []int64, err error) {
+	if err != nil {
+		slog.Error("failed to look up seasons to invalidate", "err", err)
+		return
+	}
+	for i := range ids {
+		invalidateSeasons(c, &ids[i])
+	}
+}
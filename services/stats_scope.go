@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+)
+
+// ErrInvalidScope is returned for an unknown scope kind.
+var ErrInvalidScope = errors.New("scope must be 'season', 'league', 'exhibition' or 'global'")
+
+// resolveStatsScope checks that the scope's season or league exists and returns the
+// season whose tiebreakers rank it. Scopes wider than one season rank with the defaults.
+func resolveStatsScope(
+	ctx context.Context,
+	seasons *repositories.SeasonRepository,
+	leagues *repositories.LeagueRepository,
+	scope repositories.StatsScope,
+) (*models.Season, error) {
+	switch scope.Kind {
+	case repositories.ScopeSeason:
+		return seasons.GetByID(ctx, scope.ID)
+	case repositories.ScopeLeague:
+		if _, err := leagues.GetByID(ctx, scope.ID); err != nil {
+			return nil, err
+		}
+	case repositories.ScopeExhibition, repositories.ScopeGlobal:
+	default:
+		return nil, ErrInvalidScope
+	}
+	return &models.Season{Tiebreakers: models.DefaultTiebreakers}, nil
+}
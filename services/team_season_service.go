@@ -4,16 +4,18 @@ import (
 	"context"
 	"errors"
 
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
 	"github.com/matt-j-deasy/betty-crokers-api/models"
 	"github.com/matt-j-deasy/betty-crokers-api/repositories"
 )
 
 type TeamSeasonService struct {
 	repos *repositories.RepositoriesCollection
+	cache cache.StatsCache
 }
 
-func NewTeamSeasonService(repos *repositories.RepositoriesCollection) *TeamSeasonService {
-	return &TeamSeasonService{repos: repos}
+func NewTeamSeasonService(repos *repositories.RepositoriesCollection, statsCache cache.StatsCache) *TeamSeasonService {
+	return &TeamSeasonService{repos: repos, cache: statsCache}
 }
 
 // ---------- Inputs / Outputs
@@ -64,7 +66,12 @@ func (s *TeamSeasonService) Link(ctx context.Context, in LinkTeamSeasonInput) (*
 	if in.IsActive != nil {
 		active = *in.IsActive
 	}
-	return s.repos.TeamSeasonRepo.UpsertLink(ctx, in.TeamID, in.SeasonID, active)
+	ts, err := s.repos.TeamSeasonRepo.UpsertLink(ctx, in.TeamID, in.SeasonID, active)
+	if err != nil {
+		return nil, err
+	}
+	invalidateSeasons(s.cache, &in.SeasonID)
+	return ts, nil
 }
 
 func (s *TeamSeasonService) SetActive(ctx context.Context, in SetTeamSeasonActiveInput) (*models.TeamSeason, error) {
@@ -75,7 +82,12 @@ func (s *TeamSeasonService) SetActive(ctx context.Context, in SetTeamSeasonActiv
 	if _, err := s.repos.TeamSeasonRepo.Get(ctx, in.TeamID, in.SeasonID); err != nil {
 		return nil, err
 	}
-	return s.repos.TeamSeasonRepo.SetActive(ctx, in.TeamID, in.SeasonID, in.IsActive)
+	ts, err := s.repos.TeamSeasonRepo.SetActive(ctx, in.TeamID, in.SeasonID, in.IsActive)
+	if err != nil {
+		return nil, err
+	}
+	invalidateSeasons(s.cache, &in.SeasonID)
+	return ts, nil
 }
 
 func (s *TeamSeasonService) Unlink(ctx context.Context, teamID, seasonID int64) error {
@@ -84,7 +96,11 @@ func (s *TeamSeasonService) Unlink(ctx context.Context, teamID, seasonID int64)
 	}
 	// Idempotent: deleting a non-existent row is fine (no-op) with soft deletes,
 	// but the repo will return nil only if the Delete query itself succeeds.
-	return s.repos.TeamSeasonRepo.Unlink(ctx, teamID, seasonID)
+	if err := s.repos.TeamSeasonRepo.Unlink(ctx, teamID, seasonID); err != nil {
+		return err
+	}
+	invalidateSeasons(s.cache, &seasonID)
+	return nil
 }
 
 func (s *TeamSeasonService) List(ctx context.Context, opts ListTeamSeasonsOptions) (*PagedTeamSeasons, error) {
@@ -7,16 +7,18 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
 	"github.com/matt-j-deasy/betty-crokers-api/models"
 	"github.com/matt-j-deasy/betty-crokers-api/repositories"
 )
 
 type TeamService struct {
 	repos *repositories.RepositoriesCollection
+	cache cache.StatsCache
 }
 
-func NewTeamService(repos *repositories.RepositoriesCollection) *TeamService {
-	return &TeamService{repos: repos}
+func NewTeamService(repos *repositories.RepositoriesCollection, statsCache cache.StatsCache) *TeamService {
+	return &TeamService{repos: repos, cache: statsCache}
 }
 
 // ---------- Inputs / Outputs
@@ -157,11 +159,23 @@ func (s *TeamService) Update(ctx context.Context, id int64, in UpdateTeamInput)
 	if len(fields) == 0 {
 		return cur, nil
 	}
-	return s.repos.TeamRepo.UpdateFields(ctx, id, fields)
+	updated, err := s.repos.TeamRepo.UpdateFields(ctx, id, fields)
+	if err != nil {
+		return nil, err
+	}
+	// The name shows in standings; the players feed player stats.
+	ids, err := s.repos.StatsRepo.ListSeasonIDsForTeam(ctx, id)
+	invalidateSeasonList(s.cache, ids, err)
+	return updated, nil
 }
 
 func (s *TeamService) Delete(ctx context.Context, id int64) error {
-	return s.repos.TeamRepo.DeleteByID(ctx, id)
+	ids, lookupErr := s.repos.StatsRepo.ListSeasonIDsForTeam(ctx, id)
+	if err := s.repos.TeamRepo.DeleteByID(ctx, id); err != nil {
+		return err
+	}
+	invalidateSeasonList(s.cache, ids, lookupErr)
+	return nil
 }
 
 func (s *TeamService) List(ctx context.Context, opts ListTeamsOptions) (*PagedTeams, error) {
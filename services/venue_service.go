@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/matt-j-deasy/betty-crokers-api/cache"
+	"github.com/matt-j-deasy/betty-crokers-api/models"
+	"github.com/matt-j-deasy/betty-crokers-api/repositories"
+	"github.com/matt-j-deasy/betty-crokers-api/utils"
+)
+
+// MaxBoardsPerVenue bounds BoardCount so a typo can't create thousands of rows.
+const MaxBoardsPerVenue = 64
+
+type VenueService struct {
+	repos *repositories.RepositoriesCollection
+	cache cache.StatsCache
+}
+
+func NewVenueService(repos *repositories.RepositoriesCollection, statsCache cache.StatsCache) *VenueService {
+	return &VenueService{repos: repos, cache: statsCache}
+}
+
+type CreateVenueInput struct {
+	Name       string  `json:"name"`
+	Address    *string `json:"address,omitempty"`
+	Timezone   *string `json:"timezone,omitempty"`   // default America/New_York
+	BoardCount *int    `json:"boardCount,omitempty"` // default 1
+}
+
+type UpdateVenueInput struct {
+	Name       *string `json:"name,omitempty"`
+	Address    *string `json:"address,omitempty"`
+	Timezone   *string `json:"timezone,omitempty"`
+	BoardCount *int    `json:"boardCount,omitempty"` // shrinking removes the highest-numbered boards
+}
+
+type ListVenuesOptions struct {
+	Search string
+	Page   int
+	Size   int
+}
+
+type PagedVenues struct {
+	Data  []models.Venue `json:"data"`
+	Total int64          `json:"total"`
+	Page  int            `json:"page"`
+	Size  int            `json:"size"`
+}
+
+func (s *VenueService) Create(ctx context.Context, in CreateVenueInput) (*models.Venue, error) {
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	if err := s.ensureNameFree(ctx, name, 0); err != nil {
+		return nil, err
+	}
+	v := &models.Venue{Name: name, Address: in.Address, Timezone: "America/New_York", BoardCount: 1}
+	if in.Timezone != nil && *in.Timezone != "" {
+		if _, err := time.LoadLocation(*in.Timezone); err != nil {
+			return nil, errors.New("invalid timezone")
+		}
+		v.Timezone = *in.Timezone
+	}
+	if in.BoardCount != nil {
+		if *in.BoardCount < 1 || *in.BoardCount > MaxBoardsPerVenue {
+			return nil, errors.New("boardCount must be between 1 and 64")
+		}
+		v.BoardCount = *in.BoardCount
+	}
+	if err := s.repos.VenueRepo.Create(ctx, v); err != nil {
+		return nil, err
+	}
+	return s.repos.VenueRepo.GetByID(ctx, v.ID)
+}
+
+func (s *VenueService) GetByID(ctx context.Context, id int64) (*models.Venue, error) {
+	return s.repos.VenueRepo.GetByID(ctx, id)
+}
+
+func (s *VenueService) Update(ctx context.Context, id int64, in UpdateVenueInput) (*models.Venue, error) {
+	if _, err := s.repos.VenueRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	fields := map[string]any{}
+	if in.Name != nil {
+		n := strings.TrimSpace(*in.Name)
+		if n == "" {
+			return nil, errors.New("name cannot be empty")
+		}
+		if err := s.ensureNameFree(ctx, n, id); err != nil {
+			return nil, err
+		}
+		fields["name"] = n
+	}
+	if in.Address != nil {
+		fields["address"] = in.Address
+	}
+	if in.Timezone != nil {
+		if _, err := time.LoadLocation(*in.Timezone); err != nil || *in.Timezone == "" {
+			return nil, errors.New("invalid timezone")
+		}
+		fields["timezone"] = *in.Timezone
+	}
+	if in.BoardCount != nil && (*in.BoardCount < 1 || *in.BoardCount > MaxBoardsPerVenue) {
+		return nil, errors.New("boardCount must be between 1 and 64")
+	}
+	updated, err := s.repos.VenueRepo.Update(ctx, id, fields, in.BoardCount)
+	if err != nil {
+		return nil, err
+	}
+	if _, renamed := fields["name"]; renamed {
+		// The rename is copied to the games' locations, which stats group by.
+		ids, err := s.repos.StatsRepo.ListSeasonIDsForVenue(ctx, id)
+		invalidateSeasonList(s.cache, ids, err)
+	}
+	return updated, nil
+}
+
+// RenameBoard sets a board's display name; nil or "" clears it.
+func (s *VenueService) RenameBoard(ctx context.Context, venueID, boardID int64, name *string) (*models.Board, error) {
+	b, err := s.repos.VenueRepo.GetBoard(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if b.VenueID != venueID {
+		return nil, errors.New("board does not belong to this venue")
+	}
+	var v any
+	if name != nil && strings.TrimSpace(*name) != "" {
+		v = strings.TrimSpace(*name)
+	}
+	return s.repos.VenueRepo.UpdateBoard(ctx, boardID, map[string]any{"name": v})
+}
+
+func (s *VenueService) Delete(ctx context.Context, id int64) error {
+	return s.repos.VenueRepo.DeleteByID(ctx, id)
+}
+
+func (s *VenueService) List(ctx context.Context, opts ListVenuesOptions) (*PagedVenues, error) {
+	page := opts.Page
+	size := opts.Size
+	if page < 1 {
+		page = 1
+	}
+	if size <= 0 || size > 100 {
+		size = 25
+	}
+
+	items, total, err := s.repos.VenueRepo.List(ctx, repositories.ListVenuesFilter{
+		Search: opts.Search,
+		Offset: (page - 1) * size,
+		Limit:  size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &PagedVenues{
+		Data:  items,
+		Total: total,
+		Page:  page,
+		Size:  size,
+	}, nil
+}
+
+func (s *VenueService) ensureNameFree(ctx context.Context, name string, selfID int64) error {
+	existing, err := s.repos.VenueRepo.GetByName(ctx, name)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != selfID {
+		return errors.New("a venue with that name already exists")
+	}
+	return nil
+}
+
+// resolveVenueBoard validates an optional venue/board pair for a game. A board alone
+// implies its venue; a board must belong to the given venue.
+func resolveVenueBoard(ctx context.Context, repos *repositories.RepositoriesCollection, venueID, boardID *int64) (*models.Venue, *models.Board, error) {
+	var board *models.Board
+	if boardID != nil {
+		b, err := repos.VenueRepo.GetBoard(ctx, *boardID)
+		if err != nil {
+			return nil, nil, errors.New("board not found")
+		}
+		if venueID != nil && *venueID != b.VenueID {
+			return nil, nil, errors.New("board does not belong to venue")
+		}
+		venueID, board = &b.VenueID, b
+	}
+	if venueID == nil {
+		return nil, nil, nil
+	}
+	v, err := repos.VenueRepo.GetByID(ctx, *venueID)
+	if err != nil {
+		return nil, nil, errors.New("venue not found")
+	}
+	return v, board, nil
+}